@@ -3,13 +3,16 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/masmgr/bugspots-go/config"
 	"github.com/masmgr/bugspots-go/internal/aggregation"
 	"github.com/masmgr/bugspots-go/internal/burst"
 	"github.com/masmgr/bugspots-go/internal/complexity"
+	"github.com/masmgr/bugspots-go/internal/gate"
 	"github.com/masmgr/bugspots-go/internal/git"
 	"github.com/masmgr/bugspots-go/internal/output"
 	"github.com/masmgr/bugspots-go/internal/scoring"
@@ -17,32 +20,36 @@ import (
 
 // AnalyzeCmd returns the analyze command.
 func AnalyzeCmd() *cli.Command {
-	flags := append(commonFlags(),
-		&cli.IntFlag{
-			Name:  "half-life",
-			Usage: "Half-life in days for recency decay",
-			Value: 30,
+	flags := append(commonFlags(), fileScoringFlags()...)
+	flags = append(flags,
+		&cli.StringFlag{
+			Name:  "diff",
+			Usage: "Analyze only files changed between refs (e.g., origin/main...HEAD)",
+		},
+		&cli.Float64Flag{
+			Name:  "ci-threshold",
+			Usage: "Exit with non-zero status if any reported file (changed file with --diff) scores at or above this",
 		},
 		&cli.IntFlag{
-			Name:  "window-days",
-			Usage: "Window size in days for burst detection",
-			Value: 7,
+			Name:  "max-high-risk",
+			Usage: "Exit with non-zero status if more than this many reported files are high risk",
 		},
-		&cli.StringSliceFlag{
-			Name:  "bug-patterns",
-			Usage: "Regex patterns for bugfix commit detection (can be specified multiple times)",
+		&cli.StringFlag{
+			Name:  "baseline-report",
+			Usage: "Previous `analyze --format json` report to compare scores against",
 		},
 		&cli.StringFlag{
-			Name:  "diff",
-			Usage: "Analyze only files changed between refs (e.g., origin/main...HEAD)",
+			Name:  "baseline",
+			Usage: "Baseline file of accepted hotspots and suppressions (see the baseline command); only new or risen hotspots fail the gate",
 		},
 		&cli.Float64Flag{
-			Name:  "ci-threshold",
-			Usage: "Exit with non-zero status if any file exceeds this risk score",
+			Name:  "max-increase",
+			Usage: "Score increase over the baseline tolerated before exiting with non-zero status",
+			Value: 0.05,
 		},
-		&cli.BoolFlag{
-			Name:  "include-complexity",
-			Usage: "Include file complexity (line count) in scoring",
+		&cli.StringFlag{
+			Name:  "gate-output",
+			Usage: "Write the quality gate result as JSON to this path",
 		},
 	)
 
@@ -56,52 +63,20 @@ func AnalyzeCmd() *cli.Command {
 }
 
 func analyzeAction(c *cli.Context) error {
-	return executeWithContext(c, git.ChangeDetailFull, func(ctx *CommandContext, c *cli.Context) error {
-		// Aggregate file metrics
-		aggregator := aggregation.NewFileMetricsAggregator()
-		metrics := aggregator.Process(ctx.ChangeSets)
-
-		// Detect bugfix commits and apply counts
-		bugPatterns := resolveBugPatterns(c, ctx.Config)
-		if len(bugPatterns) > 0 {
-			if _, err := detectAndApplyBugfixes(ctx.ChangeSets, metrics, aggregator, bugPatterns); err != nil {
-				return err
-			}
-		}
-
-		// Calculate burst scores
-		burstCalc := burst.NewCalculator(ctx.Config.Burst.WindowDays)
-		burstCalc.Compute(metrics)
+	if _, err := fileReportWriter(c); err != nil {
+		return err
+	}
+	gateOpts, err := parseGateOptions(c)
+	if err != nil {
+		return err
+	}
 
-		// Measure file complexity (line counts) if requested
-		if c.Bool("include-complexity") {
-			pathSet := make(map[string]struct{}, len(metrics))
-			for p := range metrics {
-				pathSet[p] = struct{}{}
-			}
-			branch := ctx.Branch
-			if branch == "" {
-				branch = "HEAD"
-			}
-			lineCounts, err := complexity.FileLineCounts(c.Context, ctx.RepoPath, branch, pathSet)
-			if err != nil {
-				return fmt.Errorf("failed to measure file complexity: %w", err)
-			}
-			for path, count := range lineCounts {
-				if fm, ok := metrics[path]; ok {
-					fm.FileSize = count
-				}
-			}
-		} else {
-			// Zero out complexity weight when not measuring
-			ctx.Config.Scoring.Weights.Complexity = 0
+	return executeWithContext(c, git.ChangeDetailFull, func(ctx *CommandContext, c *cli.Context) error {
+		items, err := scoreFiles(ctx, c, c.Bool("explain") || needsBreakdown(c))
+		if err != nil {
+			return err
 		}
 
-		// Calculate risk scores
-		explain := c.Bool("explain")
-		scorer := scoring.NewFileScorer(ctx.Config.Scoring)
-		items := scorer.ScoreAndRank(metrics, explain, ctx.Until)
-
 		// Filter by diff if specified
 		if diffSpec := c.String("diff"); diffSpec != "" {
 			diffResult, err := git.ReadDiff(context.Background(), git.DiffOptions{
@@ -121,6 +96,8 @@ func analyzeAction(c *cli.Context) error {
 			Until:       ctx.Until,
 			GeneratedAt: time.Now(),
 			Items:       items,
+			Thresholds:  ctx.Config.FileScoring.Thresholds,
+			ChangeSets:  ctx.ChangeSets,
 		}
 
 		// Output results
@@ -128,18 +105,181 @@ func analyzeAction(c *cli.Context) error {
 			return err
 		}
 
-		// Check CI threshold
-		if threshold := c.Float64("ci-threshold"); threshold > 0 && len(items) > 0 {
-			if items[0].RiskScore >= threshold {
-				return fmt.Errorf("risk threshold exceeded: %s has score %.4f (threshold: %.4f)",
-					items[0].Path, items[0].RiskScore, threshold)
+		// Evaluate the quality gate
+		if !gateOpts.Enabled() {
+			return nil
+		}
+		result := gate.Evaluate(items, gateOpts)
+		for _, s := range result.ExpiredSuppressions {
+			fmt.Fprintf(os.Stderr, "Warning: suppression for %s expired on %s (%s)\n", s.Path, s.Expires, s.Reason)
+		}
+		if path := c.String("gate-output"); path != "" {
+			if err := result.WriteFile(path); err != nil {
+				return err
 			}
 		}
-
-		return nil
+		return result.Err()
 	})
 }
 
+// parseGateOptions builds the quality gate from CLI flags. --diff scopes every check
+// to the changed files, since only those are reported.
+func parseGateOptions(c *cli.Context) (gate.Options, error) {
+	opts := gate.Options{
+		Threshold:   c.Float64("ci-threshold"),
+		MaxHighRisk: -1,
+		MaxIncrease: c.Float64("max-increase"),
+		Changed:     c.String("diff") != "",
+	}
+	if c.IsSet("max-high-risk") {
+		opts.MaxHighRisk = c.Int("max-high-risk")
+		if opts.MaxHighRisk < 0 {
+			return opts, fmt.Errorf("--max-high-risk must be zero or greater, got %d", opts.MaxHighRisk)
+		}
+	}
+	if path := c.String("baseline-report"); path != "" {
+		if c.String("baseline") != "" {
+			return opts, fmt.Errorf("--baseline cannot be combined with --baseline-report")
+		}
+		scores, err := gate.LoadReportScores(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.Baseline = scores
+	}
+	if path := c.String("baseline"); path != "" {
+		baseline, err := gate.LoadBaseline(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.Baseline = baseline.Scores()
+		opts.AcceptBaseline = true
+		opts.Suppressions = baseline.Suppressions
+		opts.Now = time.Now()
+		// Without an explicit check, any new high-risk file fails the gate
+		if opts.Threshold <= 0 && opts.MaxHighRisk < 0 {
+			opts.MaxHighRisk = 0
+		}
+	}
+	if c.String("gate-output") != "" && !opts.Enabled() {
+		return opts, fmt.Errorf("--gate-output requires a gate: --ci-threshold, --max-high-risk, --baseline-report or --baseline")
+	}
+	return opts, nil
+}
+
+// fileScoringFlags tune file hotspot scoring for the commands that score files.
+func fileScoringFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  "half-life",
+			Usage: "Half-life in days for recency decay",
+			Value: 30,
+		},
+		&cli.IntFlag{
+			Name:  "window-days",
+			Usage: "Window size in days for burst detection",
+			Value: 7,
+		},
+		&cli.StringSliceFlag{
+			Name:  "bug-patterns",
+			Usage: "Regex patterns for bugfix commit detection (can be specified multiple times)",
+		},
+		&cli.BoolFlag{
+			Name:  "include-complexity",
+			Usage: "Include file complexity (line count) in scoring",
+		},
+		&cli.Float64Flag{
+			Name:  "coupling-weight",
+			Usage: "Weight of the change coupling degree factor (0 disables it)",
+		},
+	}
+}
+
+// scoreFiles aggregates the history into per-file metrics and ranks files by risk.
+func scoreFiles(ctx *CommandContext, c *cli.Context, explain bool) ([]scoring.FileRiskItem, error) {
+	// Aggregate file metrics
+	aggregator := aggregation.NewFileMetricsAggregator()
+	metrics := aggregator.Process(ctx.ChangeSets)
+
+	// Detect bugfix commits and apply counts
+	bugPatterns, types := resolveBugPatterns(c, ctx.Config), bugfixTypes(ctx.Config)
+	if len(bugPatterns) > 0 || len(types) > 0 {
+		if _, err := detectAndApplyBugfixes(ctx.ChangeSets, metrics, aggregator, bugPatterns, types); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate burst scores
+	burstCalc := burst.NewCalculator(ctx.Config.Burst.WindowDays)
+	burstCalc.Compute(metrics)
+
+	// Measure file complexity (line counts) if requested, or to size treemap tiles
+	includeComplexity := c.Bool("include-complexity")
+	if includeComplexity || needsFileSizes(c) {
+		pathSet := make(map[string]struct{}, len(metrics))
+		for p := range metrics {
+			pathSet[p] = struct{}{}
+		}
+		branch := ctx.Branch
+		if branch == "" {
+			branch = "HEAD"
+		}
+		lineCounts, err := complexity.FileLineCounts(c.Context, ctx.RepoPath, branch, pathSet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure file complexity: %w", err)
+		}
+		for path, count := range lineCounts {
+			if fm, ok := metrics[path]; ok {
+				fm.FileSize = count
+			}
+		}
+	}
+	overrides := ctx.Config.FileScoring.Overrides
+	if !includeComplexity {
+		// Zero out complexity weight when not requested
+		ctx.Config.Scoring.Weights.Complexity = 0
+		overrides = withoutComplexityWeight(overrides)
+	}
+
+	// Compute coupling degrees only when the factor carries weight
+	if ctx.Config.Scoring.Weights.Coupling > 0 || overridesCouplingWeight(overrides) {
+		if err := applyCouplingDegrees(ctx, metrics); err != nil {
+			return nil, fmt.Errorf("failed to compute coupling degree: %w", err)
+		}
+	}
+
+	// Calculate risk scores
+	scorer := scoring.NewFileScorer(ctx.Config.Scoring).
+		WithThresholds(ctx.Config.FileScoring.Thresholds).
+		WithOverrides(overrides)
+	return scorer.ScoreAndRank(metrics, explain, ctx.Until), nil
+}
+
+// withoutComplexityWeight drops the complexity weight from path overrides, since line
+// counts are only measured with --include-complexity.
+func withoutComplexityWeight(overrides []config.PathOverride) []config.PathOverride {
+	result := make([]config.PathOverride, len(overrides))
+	for i, o := range overrides {
+		if o.Weights != nil && o.Weights.Complexity != nil {
+			weights := *o.Weights
+			weights.Complexity = nil
+			o.Weights = &weights
+		}
+		result[i] = o
+	}
+	return result
+}
+
+// overridesCouplingWeight reports whether a path override gives coupling degree weight.
+func overridesCouplingWeight(overrides []config.PathOverride) bool {
+	for _, o := range overrides {
+		if o.Weights != nil && o.Weights.Coupling != nil && *o.Weights.Coupling > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // filterByDiff filters scored items to only include files present in the diff result.
 func filterByDiff(items []scoring.FileRiskItem, diff *git.DiffResult) []scoring.FileRiskItem {
 	pathSet := make(map[string]struct{}, len(diff.ChangedFiles))
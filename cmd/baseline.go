@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/gate"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// BaselineCmd returns the baseline command.
+func BaselineCmd() *cli.Command {
+	flags := append(historyFlags(), fileScoringFlags()...)
+	flags = append(flags,
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Baseline file to write; suppressions in an existing file are kept",
+			Value:   gate.DefaultBaselinePath,
+		},
+		&cli.StringFlag{
+			Name:  "min-level",
+			Usage: "Lowest risk level recorded as an accepted hotspot (high, medium, all)",
+			Value: string(config.RiskLevelMedium),
+		},
+	)
+
+	return &cli.Command{
+		Name:   "baseline",
+		Usage:  "Snapshot current file hotspots as accepted, for analyze --baseline",
+		Flags:  flags,
+		Action: baselineAction,
+	}
+}
+
+func baselineAction(c *cli.Context) error {
+	minLevel, err := parseMinLevel(c.String("min-level"))
+	if err != nil {
+		return err
+	}
+	path := c.String("output")
+	var suppressions []gate.Suppression
+	previous, err := gate.LoadBaseline(path)
+	switch {
+	case err == nil:
+		suppressions = previous.Suppressions
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	return executeWithContext(c, git.ChangeDetailFull, func(ctx *CommandContext, c *cli.Context) error {
+		items, err := scoreFiles(ctx, c, false)
+		if err != nil {
+			return err
+		}
+
+		baseline := gate.NewBaseline(items, minLevel, time.Now())
+		baseline.Suppressions = suppressions
+		if err := baseline.WriteFile(path); err != nil {
+			return err
+		}
+		fmt.Printf("Recorded %d of %d files in %s (%d suppressions kept)\n",
+			len(baseline.Files), len(items), path, len(suppressions))
+		return nil
+	})
+}
+
+// parseMinLevel validates --min-level; "all" records every file.
+func parseMinLevel(s string) (config.RiskLevel, error) {
+	switch s {
+	case "high", "medium", "all":
+		return parseRiskLevel(s), nil
+	default:
+		return "", fmt.Errorf("invalid --min-level %q (expected high|medium|all)", s)
+	}
+}
@@ -19,16 +19,27 @@ func resolveBugPatterns(c *cli.Context, cfg *config.Config) []string {
 	return cfg.Bugfix.Patterns
 }
 
+// bugfixTypes returns the Conventional Commits types of bugfix commits, or nil when
+// commits are not classified by type.
+func bugfixTypes(cfg *config.Config) []string {
+	if !cfg.Conventional.Enabled {
+		return nil
+	}
+	return cfg.Conventional.BugfixTypes
+}
+
 func detectAndApplyBugfixes(
 	changeSets []git.CommitChangeSet,
 	metrics map[string]*aggregation.FileMetrics,
 	aggregator *aggregation.FileMetricsAggregator,
 	patterns []string,
+	conventionalTypes []string,
 ) (*bugfix.BugfixResult, error) {
 	detector, err := bugfix.NewDetector(patterns)
 	if err != nil {
 		return nil, fmt.Errorf("invalid bug pattern: %w", err)
 	}
+	detector.WithConventionalTypes(conventionalTypes)
 
 	result := detector.Detect(changeSets)
 	aggregation.ApplyBugfixCounts(metrics, aggregator, result.FileBugfixCounts)
@@ -52,11 +52,11 @@ func calibrateAction(c *cli.Context) error {
 		metrics := aggregator.Process(ctx.ChangeSets)
 
 		// Detect bugfix commits
-		bugPatterns := resolveBugPatterns(c, ctx.Config)
-		if len(bugPatterns) == 0 {
+		bugPatterns, types := resolveBugPatterns(c, ctx.Config), bugfixTypes(ctx.Config)
+		if len(bugPatterns) == 0 && len(types) == 0 {
 			return fmt.Errorf("no bugfix patterns configured; use --bug-patterns or configure in .bugspots.json")
 		}
-		result, err := detectAndApplyBugfixes(ctx.ChangeSets, metrics, aggregator, bugPatterns)
+		result, err := detectAndApplyBugfixes(ctx.ChangeSets, metrics, aggregator, bugPatterns, types)
 		if err != nil {
 			return err
 		}
@@ -71,6 +71,11 @@ func calibrateAction(c *cli.Context) error {
 		burstCalc := burst.NewCalculator(ctx.Config.Burst.WindowDays)
 		burstCalc.Compute(metrics)
 
+		// Coupling degree is always measured so calibration can recommend a weight for it
+		if err := applyCouplingDegrees(ctx, metrics); err != nil {
+			return fmt.Errorf("failed to compute coupling degree: %w", err)
+		}
+
 		// Build bugfix file set
 		bugfixFiles := make(map[string]struct{})
 		for path := range result.FileBugfixCounts {
@@ -131,6 +136,6 @@ func printCalibrationResult(result calibration.CalibrateResult, topPercent int)
 	}
 }
 
-func weightsToSlice(w config.WeightConfig) [7]float64 {
-	return [7]float64{w.Commit, w.Churn, w.Recency, w.Burst, w.Ownership, w.Bugfix, w.Complexity}
+func weightsToSlice(w config.WeightConfig) [8]float64 {
+	return [8]float64{w.Commit, w.Churn, w.Recency, w.Burst, w.Ownership, w.Bugfix, w.Complexity, w.Coupling}
 }
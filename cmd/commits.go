@@ -21,6 +21,10 @@ func CommitsCmd() *cli.Command {
 			Usage:   "Filter by minimum risk level (high, medium, all)",
 			Value:   "all",
 		},
+		&cli.BoolFlag{
+			Name:  "by-scope",
+			Usage: "Summarize Conventional Commits by scope",
+		},
 	)
 
 	return &cli.Command{
@@ -33,16 +37,26 @@ func CommitsCmd() *cli.Command {
 }
 
 func commitsAction(c *cli.Context) error {
+	if _, err := commitReportWriter(c); err != nil {
+		return err
+	}
+
 	return executeWithContext(c, git.ChangeDetailFull, func(ctx *CommandContext, c *cli.Context) error {
 		// Calculate commit metrics
 		calculator := aggregation.NewCommitMetricsCalculator()
 		metrics := calculator.CalculateAll(ctx.ChangeSets)
 
 		// Calculate risk scores
-		explain := c.Bool("explain")
+		explain := c.Bool("explain") || needsBreakdown(c)
 		scorer := scoring.NewCommitScorer(ctx.Config.CommitScoring)
 		items := scorer.ScoreAndRank(metrics, explain)
 
+		// Scopes summarize every analyzed commit, not only those shown
+		var scopes []scoring.ScopeGroup
+		if c.Bool("by-scope") {
+			scopes = scoring.GroupByScope(items)
+		}
+
 		// Filter by risk level
 		riskLevel := parseRiskLevel(c.String("risk-level"))
 		if riskLevel != "" {
@@ -56,6 +70,9 @@ func commitsAction(c *cli.Context) error {
 			Until:       ctx.Until,
 			GeneratedAt: time.Now(),
 			Items:       items,
+			Thresholds:  ctx.Config.CommitScoring.Thresholds,
+			Scopes:      scopes,
+			ChangeSets:  ctx.ChangeSets,
 		}
 
 		// Output results
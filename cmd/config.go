@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// ConfigCmd returns the config command.
+func ConfigCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Validate and inspect configuration files",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "validate",
+				Usage:     "Check a configuration file, the files it extends and its profiles for syntax errors, unknown keys and invalid values",
+				ArgsUsage: "[path]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "strict",
+						Usage: "Treat warnings as errors",
+					},
+				},
+				Action: configValidateAction,
+			},
+			{
+				Name:      "show",
+				Usage:     "Print the configuration from the file, profile and BUGSPOTS_* environment variables, with where each value came from",
+				ArgsUsage: "[path]",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "effective",
+						Usage: "Include values left at their defaults",
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Aliases: []string{"f"},
+						Usage:   "Output format: text, json",
+						Value:   "text",
+					},
+				},
+				Action: configShowAction,
+			},
+			{
+				Name:   "schema",
+				Usage:  "Print the JSON Schema for configuration files",
+				Action: configSchemaAction,
+			},
+		},
+	}
+}
+
+// configValidateAction validates the file named by the argument, --config, or the
+// first default configuration file found, with --profile or else every profile.
+func configValidateAction(c *cli.Context) error {
+	path := c.Args().First()
+	if path == "" {
+		path = c.String("config")
+	}
+	if path == "" {
+		path = config.FindConfigFile()
+	}
+	if path == "" {
+		return errors.New("no configuration file found; pass a path or --config")
+	}
+
+	doc, err := config.ReadDocument(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	issues, err := validateProfiles(doc, c.String("profile"))
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+
+	errs := len(config.Errors(issues))
+	warnings := len(issues) - errs
+	if errs == 0 && warnings == 0 {
+		fmt.Printf("%s: valid\n", path)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d errors, %d warnings\n", path, errs, warnings)
+	if errs > 0 || c.Bool("strict") {
+		return fmt.Errorf("%s failed validation", path)
+	}
+	return nil
+}
+
+// validateProfiles resolves the document with the given profile, or without one and
+// with each of its profiles. Issues shared by several profiles are reported once.
+func validateProfiles(doc *config.Document, profile string) ([]config.Issue, error) {
+	profiles := []string{profile}
+	if profile == "" {
+		profiles = append(profiles, doc.Profiles()...)
+	}
+	var issues []config.Issue
+	seen := map[config.Issue]bool{}
+	for _, p := range profiles {
+		_, found, err := doc.Resolve(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range found {
+			if !seen[issue] {
+				seen[issue] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, nil
+}
+
+// configShowAction prints the settings commands start from, before their own flags
+// are applied. Without a configuration file these are the defaults and environment.
+func configShowAction(c *cli.Context) error {
+	format := c.String("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q for config show (use text or json)", format)
+	}
+
+	path := c.Args().First()
+	if path == "" {
+		path = c.String("config")
+	}
+	if path == "" {
+		path = config.FindConfigFile()
+	}
+	doc := config.NewDocument()
+	if path != "" {
+		var err error
+		if doc, err = config.ReadDocument(path); err != nil {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	settings, issues, err := doc.Settings(c.String("profile"), os.Environ())
+	if err != nil {
+		return err
+	}
+	if errs := config.Errors(issues); len(errs) > 0 {
+		return &config.ValidationError{File: path, Issues: errs}
+	}
+	for _, issue := range issues {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+
+	if !c.Bool("effective") {
+		var set []config.Setting
+		for _, s := range settings {
+			if s.Source != "default" {
+				set = append(set, s)
+			}
+		}
+		settings = set
+	}
+
+	if format == "json" {
+		if settings == nil {
+			settings = []config.Setting{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, s := range settings {
+		value, err := json.Marshal(s.Value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s = %s\t# %s\n", s.Key, value, s.Source)
+	}
+	return w.Flush()
+}
+
+func configSchemaAction(c *cli.Context) error {
+	_, err := os.Stdout.Write(config.Schema)
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/conventional"
 	"github.com/masmgr/bugspots-go/internal/git"
 	"github.com/masmgr/bugspots-go/internal/output"
 )
@@ -42,6 +43,7 @@ type CommandContext struct {
 	Until      time.Time
 	Branch     string
 	ChangeSets []git.CommitChangeSet
+	Reader     *git.HistoryReader // Reader of ChangeSets, for commands that read more history
 	StartTime  time.Time
 }
 
@@ -107,6 +109,9 @@ func NewCommandContextWithGitDetail(c *cli.Context, detail git.ChangeDetailLevel
 	if err != nil {
 		return nil, fmt.Errorf("failed to read history: %w", err)
 	}
+	if cfg.Conventional.Enabled {
+		changeSets = conventional.FilterNoise(changeSets, cfg.Conventional.NoiseTypes)
+	}
 
 	return &CommandContext{
 		Config:     cfg,
@@ -115,6 +120,7 @@ func NewCommandContextWithGitDetail(c *cli.Context, detail git.ChangeDetailLevel
 		Until:      untilTime,
 		Branch:     branch,
 		ChangeSets: changeSets,
+		Reader:     reader,
 		StartTime:  start,
 	}, nil
 }
@@ -142,6 +148,12 @@ func (ctx *CommandContext) ApplyCLIOverrides(c *cli.Context) {
 	if c.IsSet("half-life") {
 		ctx.Config.Scoring.HalfLifeDays = c.Int("half-life")
 	}
+	if c.IsSet("min-commits") {
+		ctx.Config.RangeScoring.MinCommits = c.Int("min-commits")
+	}
+	if c.IsSet("coupling-weight") {
+		ctx.Config.Scoring.Weights.Coupling = c.Float64("coupling-weight")
+	}
 	if c.IsSet("window-days") {
 		ctx.Config.Burst.WindowDays = c.Int("window-days")
 	}
@@ -157,6 +169,24 @@ func (ctx *CommandContext) ApplyCLIOverrides(c *cli.Context) {
 	if c.IsSet("top-pairs") {
 		ctx.Config.Coupling.TopPairs = c.Int("top-pairs")
 	}
+	if c.IsSet("clusters") {
+		ctx.Config.Coupling.DetectClusters = c.Bool("clusters")
+	}
+	if c.IsSet("group-by") {
+		ctx.Config.Coupling.ChangeSets.Mode = config.ChangeSetMode(strings.ToLower(strings.TrimSpace(c.String("group-by"))))
+	}
+	if c.IsSet("group-window-hours") {
+		ctx.Config.Coupling.ChangeSets.WindowHours = c.Int("group-window-hours")
+	}
+	if c.IsSet("issue-pattern") {
+		ctx.Config.Coupling.ChangeSets.IssuePattern = c.String("issue-pattern")
+	}
+	if c.IsSet("cross-boundary") {
+		ctx.Config.Coupling.CrossBoundary = c.Bool("cross-boundary")
+	}
+	if c.IsSet("boundary-depth") {
+		ctx.Config.Coupling.Boundaries.Depth = c.Int("boundary-depth")
+	}
 }
 
 // HasCommits returns true if commits were found in the specified range.
@@ -177,9 +207,47 @@ func (ctx *CommandContext) LogCompletion() {
 // OutputOptions creates OutputOptions from CLI flags.
 func OutputOptions(c *cli.Context) output.OutputOptions {
 	return output.OutputOptions{
-		Format:     getOutputFormat(c.String("format")),
-		Top:        c.Int("top"),
-		OutputPath: c.String("output"),
-		Explain:    c.Bool("explain"),
+		Format:       outputFormat(c),
+		Top:          c.Int("top"),
+		OutputPath:   c.String("output"),
+		TemplatePath: c.String("template"),
+		Explain:      c.Bool("explain"),
+		Threshold:    c.Float64("ci-threshold"),
+		Graph: output.GraphOptions{
+			EdgeWeight:   output.GraphEdgeWeight(strings.ToLower(c.String("edge-weight"))),
+			NodeSize:     output.GraphNodeSize(strings.ToLower(c.String("node-size"))),
+			ClusterByDir: c.Bool("cluster-dirs"),
+		},
+	}
+}
+
+// outputFormat returns the selected output format; --template selects template output.
+func outputFormat(c *cli.Context) output.OutputFormat {
+	if c.String("template") != "" {
+		return output.FormatTemplate
+	}
+	return getOutputFormat(c.String("format"))
+}
+
+// needsBreakdown reports whether the selected output format uses the score breakdown
+// even without --explain (JUnit failure messages, HTML breakdown bars, OpenMetrics
+// component gauges and SQLite component columns include it; templates may).
+func needsBreakdown(c *cli.Context) bool {
+	switch outputFormat(c) {
+	case output.FormatJUnit, output.FormatHTML, output.FormatOpenMetrics, output.FormatSQLite, output.FormatTemplate:
+		return true
+	default:
+		return false
+	}
+}
+
+// needsFileSizes reports whether the selected output format draws a treemap sized by
+// line counts, which are otherwise only measured with --include-complexity.
+func needsFileSizes(c *cli.Context) bool {
+	switch outputFormat(c) {
+	case output.FormatHTML, output.FormatSVG:
+		return true
+	default:
+		return false
 	}
 }
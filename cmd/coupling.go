@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/burst"
 	"github.com/masmgr/bugspots-go/internal/coupling"
 	"github.com/masmgr/bugspots-go/internal/git"
 	"github.com/masmgr/bugspots-go/internal/output"
+	"github.com/masmgr/bugspots-go/internal/scoring"
 )
 
 // CouplingCmd returns the coupling command.
@@ -33,6 +40,47 @@ func CouplingCmd() *cli.Command {
 			Usage: "Number of top coupled pairs to report",
 			Value: 50,
 		},
+		&cli.BoolFlag{
+			Name:  "clusters",
+			Usage: "Detect clusters of files that change together (community detection)",
+		},
+		&cli.BoolFlag{
+			Name:  "cross-boundary",
+			Usage: "Report coupled pairs whose files belong to different modules",
+		},
+		&cli.IntFlag{
+			Name:  "boundary-depth",
+			Usage: "Number of leading directories that identify a module for --cross-boundary",
+			Value: 1,
+		},
+		&cli.StringFlag{
+			Name:  "group-by",
+			Usage: "Group commits into logical change sets: commit, author, issue, merge",
+			Value: "commit",
+		},
+		&cli.IntFlag{
+			Name:  "group-window-hours",
+			Usage: "Maximum gap between an author's commits in the same change set (--group-by author)",
+			Value: 4,
+		},
+		&cli.StringFlag{
+			Name:  "issue-pattern",
+			Usage: "Regex for issue keys in commit messages (--group-by issue)",
+		},
+		&cli.StringFlag{
+			Name:  "edge-weight",
+			Usage: "Edge weight for graph formats (jaccard, lift)",
+			Value: "jaccard",
+		},
+		&cli.StringFlag{
+			Name:  "node-size",
+			Usage: "Node size for graph formats (commits, hotspot)",
+			Value: "commits",
+		},
+		&cli.BoolFlag{
+			Name:  "cluster-dirs",
+			Usage: "Group graph nodes into clusters by directory",
+		},
 	)
 
 	return &cli.Command{
@@ -45,10 +93,46 @@ func CouplingCmd() *cli.Command {
 }
 
 func couplingAction(c *cli.Context) error {
-	return executeWithContext(c, git.ChangeDetailPathsOnly, func(ctx *CommandContext, c *cli.Context) error {
+	if _, err := couplingReportWriter(c); err != nil {
+		return err
+	}
+
+	graphOpts, err := parseGraphOptions(c)
+	if err != nil {
+		return err
+	}
+
+	// Hotspot node sizing needs line stats for file scoring, and the SQLite export
+	// records them in file_changes
+	detail := git.ChangeDetailPathsOnly
+	if graphOpts.NodeSize == output.NodeSizeHotspot || outputFormat(c) == output.FormatSQLite {
+		detail = git.ChangeDetailFull
+	}
+
+	return executeWithContext(c, detail, func(ctx *CommandContext, c *cli.Context) error {
+		if ctx.Config.Coupling.CrossBoundary {
+			if _, err := coupling.NewBoundaryResolver(ctx.Config.Coupling.Boundaries); err != nil {
+				return fmt.Errorf("invalid coupling boundaries: %w", err)
+			}
+		}
+
+		changeSets, err := logicalChangeSets(ctx)
+		if err != nil {
+			return err
+		}
+
 		// Analyze coupling
 		analyzer := coupling.NewAnalyzer(ctx.Config.Coupling)
-		result := analyzer.Analyze(ctx.ChangeSets)
+		result := analyzer.Analyze(changeSets)
+
+		var fileScores map[string]float64
+		// Hotspot scores size graph nodes, and color them in the HTML report
+		if graphOpts.NodeSize == output.NodeSizeHotspot || outputFormat(c) == output.FormatHTML {
+			fileScores, err = hotspotScoresByPath(ctx, c)
+			if err != nil {
+				return err
+			}
+		}
 
 		// Create report
 		report := &output.CouplingAnalysisReport{
@@ -57,6 +141,9 @@ func couplingAction(c *cli.Context) error {
 			Until:       ctx.Until,
 			GeneratedAt: time.Now(),
 			Result:      result,
+			FileScores:  fileScores,
+			Thresholds:  ctx.Config.FileScoring.Thresholds,
+			ChangeSets:  ctx.ChangeSets,
 		}
 
 		// Output results
@@ -67,3 +154,103 @@ func couplingAction(c *cli.Context) error {
 		return nil
 	})
 }
+
+// logicalChangeSets groups commits according to the configured change set mode.
+func logicalChangeSets(ctx *CommandContext) ([]git.CommitChangeSet, error) {
+	cfg := ctx.Config.Coupling.ChangeSets
+
+	var mergeOf map[string]string
+	if cfg.Mode == config.ChangeSetModeMerge {
+		reader, err := git.NewHistoryReader(git.ReadOptions{
+			RepoPath: ctx.RepoPath,
+			Branch:   ctx.Branch,
+			Since:    ctx.Since,
+			Until:    &ctx.Until,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to open repository: %w", err)
+		}
+		mergeOf, err = reader.ReadMergeGroups(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read merge history: %w", err)
+		}
+	}
+
+	changeSets, err := coupling.GroupChangeSets(ctx.ChangeSets, cfg, mergeOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group change sets: %w", err)
+	}
+	return changeSets, nil
+}
+
+// applyCouplingDegrees runs coupling analysis over the command's history and stores each
+// file's coupling degree in its metrics, for use as a hotspot scoring factor.
+func applyCouplingDegrees(ctx *CommandContext, metrics map[string]*aggregation.FileMetrics) error {
+	changeSets, err := logicalChangeSets(ctx)
+	if err != nil {
+		return err
+	}
+
+	degrees := coupling.NewAnalyzer(ctx.Config.Coupling).FileDegrees(changeSets)
+	for path, d := range degrees {
+		if fm, ok := metrics[path]; ok {
+			fm.CouplingPartners = d.Partners
+			fm.CouplingDegree = d.Strength
+		}
+	}
+	return nil
+}
+
+// parseGraphOptions validates the graph export flags.
+func parseGraphOptions(c *cli.Context) (output.GraphOptions, error) {
+	var opts output.GraphOptions
+
+	switch strings.ToLower(strings.TrimSpace(c.String("edge-weight"))) {
+	case "", "jaccard":
+		opts.EdgeWeight = output.EdgeWeightJaccard
+	case "lift":
+		opts.EdgeWeight = output.EdgeWeightLift
+	default:
+		return opts, fmt.Errorf("invalid --edge-weight %q (expected jaccard|lift)", c.String("edge-weight"))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(c.String("node-size"))) {
+	case "", "commits":
+		opts.NodeSize = output.NodeSizeCommits
+	case "hotspot":
+		opts.NodeSize = output.NodeSizeHotspot
+	default:
+		return opts, fmt.Errorf("invalid --node-size %q (expected commits|hotspot)", c.String("node-size"))
+	}
+
+	opts.ClusterByDir = c.Bool("cluster-dirs")
+	return opts, nil
+}
+
+// hotspotScoresByPath runs file hotspot scoring over the command's history and
+// returns risk scores keyed by canonical (post-rename) path, matching coupling output.
+func hotspotScoresByPath(ctx *CommandContext, c *cli.Context) (map[string]float64, error) {
+	aggregator := aggregation.NewFileMetricsAggregator()
+	metrics := aggregator.Process(ctx.ChangeSets)
+
+	bugPatterns, types := resolveBugPatterns(c, ctx.Config), bugfixTypes(ctx.Config)
+	if len(bugPatterns) > 0 || len(types) > 0 {
+		if _, err := detectAndApplyBugfixes(ctx.ChangeSets, metrics, aggregator, bugPatterns, types); err != nil {
+			return nil, err
+		}
+	}
+
+	burst.NewCalculator(ctx.Config.Burst.WindowDays).Compute(metrics)
+
+	// Complexity and coupling degree are not measured here, so their weights must not dilute other factors
+	scoringCfg := ctx.Config.Scoring
+	scoringCfg.Weights.Complexity = 0
+	scoringCfg.Weights.Coupling = 0
+
+	items := scoring.NewFileScorer(scoringCfg).ScoreAndRank(metrics, false, ctx.Until)
+	scores := make(map[string]float64, len(items))
+	for _, item := range items {
+		scores[item.Path] = item.RiskScore
+	}
+	return scores, nil
+}
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -81,7 +84,26 @@ func TestGetOutputFormat(t *testing.T) {
 		{input: "md", want: output.FormatMarkdown},
 		{input: "ci", want: output.FormatCI},
 		{input: "ndjson", want: output.FormatCI},
-		{input: "unknown", want: output.FormatConsole},
+		{input: "sarif", want: output.FormatSARIF},
+		{input: "gitlab", want: output.FormatGitLab},
+		{input: "codequality", want: output.FormatGitLab},
+		{input: "checkstyle", want: output.FormatCheckstyle},
+		{input: "junit", want: output.FormatJUnit},
+		{input: "html", want: output.FormatHTML},
+		{input: "svg", want: output.FormatSVG},
+		{input: "badge", want: output.FormatBadge},
+		{input: "openmetrics", want: output.FormatOpenMetrics},
+		{input: "prometheus", want: output.FormatOpenMetrics},
+		{input: "sqlite", want: output.FormatSQLite},
+		{input: "sqlite3", want: output.FormatSQLite},
+		{input: "template", want: output.FormatTemplate},
+		{input: "dot", want: output.FormatDOT},
+		{input: "graphml", want: output.FormatGraphML},
+		{input: "mermaid", want: output.FormatMermaid},
+		{input: "graph-json", want: output.FormatGraph},
+		{input: "console", want: output.FormatConsole},
+		{input: "", want: output.FormatConsole},
+		{input: "unknown", want: output.OutputFormat("unknown")},
 	}
 
 	for _, tt := range tests {
@@ -92,3 +114,84 @@ func TestGetOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestUnsupportedFormatFailsFast(t *testing.T) {
+	// The repository path does not exist: the format check must fail before it is opened.
+	missing := t.TempDir() + "/missing"
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "AnalyzeAsDOT", args: []string{"analyze", "--repo", missing, "--format", "dot"}},
+		{name: "CommitsAsBadge", args: []string{"commits", "--repo", missing, "--format", "badge"}},
+		{name: "CouplingAsSARIF", args: []string{"coupling", "--repo", missing, "--format", "sarif"}},
+		{name: "UnknownFormat", args: []string{"analyze", "--repo", missing, "--format", "yaml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := App().Run(append([]string{"bugspots"}, tt.args...))
+			if !errors.Is(err, output.ErrUnsupportedFormat) {
+				t.Fatalf("err = %v, want ErrUnsupportedFormat", err)
+			}
+		})
+	}
+}
+
+func TestTemplateFlagFailsFast(t *testing.T) {
+	dir := t.TempDir()
+	missing := dir + "/missing"
+	bad := dir + "/bad.tmpl"
+	good := dir + "/good.tmpl"
+	if err := os.WriteFile(bad, []byte("{{.Items"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(good, []byte("{{len .Items}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantMsg string
+	}{
+		{name: "ParseError", args: []string{"analyze", "--repo", missing, "--template", bad}, wantMsg: "failed to parse template"},
+		{name: "ConflictingFormat", args: []string{"commits", "--repo", missing, "--template", good, "--format", "json"}, wantMsg: "--template cannot be combined with --format json"},
+		{name: "FormatWithoutTemplate", args: []string{"coupling", "--repo", missing, "--format", "template"}, wantMsg: "requires --template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := App().Run(append([]string{"bugspots"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Fatalf("err = %v, want error containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestGateFlagsFailFast(t *testing.T) {
+	missing := t.TempDir() + "/missing"
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantMsg string
+	}{
+		{name: "NegativeMaxHighRisk", args: []string{"analyze", "--repo", missing, "--max-high-risk", "-1"}, wantMsg: "--max-high-risk must be zero or greater"},
+		{name: "MissingBaselineReport", args: []string{"analyze", "--repo", missing, "--baseline-report", missing + ".json"}, wantMsg: "failed to read baseline report"},
+		{name: "BaselineWithBaselineReport", args: []string{"analyze", "--repo", missing, "--baseline", missing + ".json", "--baseline-report", missing + ".json"}, wantMsg: "--baseline cannot be combined with --baseline-report"},
+		{name: "MissingBaseline", args: []string{"analyze", "--repo", missing, "--baseline", missing + ".json"}, wantMsg: "failed to read baseline"},
+		{name: "InvalidBaselineMinLevel", args: []string{"baseline", "--repo", missing, "--min-level", "hgih"}, wantMsg: "invalid --min-level"},
+		{name: "GateOutputWithoutGate", args: []string{"analyze", "--repo", missing, "--gate-output", missing + ".json"}, wantMsg: "--gate-output requires a gate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := App().Run(append([]string{"bugspots"}, tt.args...))
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Fatalf("err = %v, want error containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
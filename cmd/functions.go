@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/bugfix"
+	"github.com/masmgr/bugspots-go/internal/git"
+	"github.com/masmgr/bugspots-go/internal/output"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// FunctionsCmd returns the functions command.
+func FunctionsCmd() *cli.Command {
+	flags := append(commonFlags(),
+		&cli.IntFlag{
+			Name:  "half-life",
+			Usage: "Half-life in days for recency decay",
+			Value: 30,
+		},
+		&cli.StringSliceFlag{
+			Name:  "bug-patterns",
+			Usage: "Regex patterns for bugfix commit detection (can be specified multiple times)",
+		},
+	)
+
+	return &cli.Command{
+		Name:    "functions",
+		Aliases: []string{"fn"},
+		Usage:   "Rank Go functions and methods by change history",
+		Flags:   flags,
+		Action:  functionsAction,
+	}
+}
+
+func functionsAction(c *cli.Context) error {
+	if _, err := functionReportWriter(c); err != nil {
+		return err
+	}
+
+	return executeWithContext(c, git.ChangeDetailPathsOnly, func(ctx *CommandContext, c *cli.Context) error {
+		detector, err := bugfix.NewDetector(resolveBugPatterns(c, ctx.Config))
+		if err != nil {
+			return fmt.Errorf("invalid bug pattern: %w", err)
+		}
+		bugfixes := detector.WithConventionalTypes(bugfixTypes(ctx.Config)).Detect(ctx.ChangeSets)
+
+		// Read the changed line ranges of Go files
+		hunks, err := ctx.Reader.ReadHunks(context.Background(), "*.go")
+		if err != nil {
+			return fmt.Errorf("failed to read diffs: %w", err)
+		}
+
+		blobs, err := git.NewBlobReader(context.Background(), ctx.RepoPath)
+		if err != nil {
+			return err
+		}
+		defer blobs.Close()
+
+		// Map the hunks onto the functions of each revision
+		aggregator := aggregation.NewFunctionMetricsAggregator(blobs.Read)
+		if err := aggregator.Process(ctx.ChangeSets, hunks, bugfixes.BugfixCommits); err != nil {
+			return fmt.Errorf("failed to read sources: %w", err)
+		}
+		// Rank the functions declared at the branch tip as of --until
+		rev, err := ctx.Reader.ReadRevision(context.Background())
+		if err != nil {
+			return err
+		}
+		metrics, err := aggregator.Result(rev)
+		if err != nil {
+			return fmt.Errorf("failed to read sources: %w", err)
+		}
+
+		// Calculate risk scores
+		scorer := scoring.NewFunctionScorer(ctx.Config.FunctionScoring, ctx.Config.Scoring.HalfLifeDays)
+		items := scorer.ScoreAndRank(metrics, c.Bool("explain"), ctx.Until)
+
+		report := &output.FunctionAnalysisReport{
+			RepoPath:    ctx.RepoPath,
+			Since:       ctx.Since,
+			Until:       ctx.Until,
+			GeneratedAt: time.Now(),
+			Items:       items,
+		}
+		return writeFunctionReport(c, report)
+	})
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/bugfix"
+	"github.com/masmgr/bugspots-go/internal/git"
+	"github.com/masmgr/bugspots-go/internal/output"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// HunksCmd returns the hunks command.
+func HunksCmd() *cli.Command {
+	flags := append(commonFlags(),
+		&cli.IntFlag{
+			Name:  "min-commits",
+			Usage: "Commits that must have changed a line for it to be part of a hot range",
+			Value: 2,
+		},
+		&cli.IntFlag{
+			Name:  "half-life",
+			Usage: "Half-life in days for recency decay",
+			Value: 30,
+		},
+		&cli.StringSliceFlag{
+			Name:  "bug-patterns",
+			Usage: "Regex patterns for bugfix commit detection (can be specified multiple times)",
+		},
+	)
+
+	return &cli.Command{
+		Name:    "hunks",
+		Aliases: []string{"hk"},
+		Usage:   "Rank the line ranges of any text file changed most often",
+		Flags:   flags,
+		Action:  hunksAction,
+	}
+}
+
+func hunksAction(c *cli.Context) error {
+	if _, err := rangeReportWriter(c); err != nil {
+		return err
+	}
+
+	return executeWithContext(c, git.ChangeDetailPathsOnly, func(ctx *CommandContext, c *cli.Context) error {
+		detector, err := bugfix.NewDetector(resolveBugPatterns(c, ctx.Config))
+		if err != nil {
+			return fmt.Errorf("invalid bug pattern: %w", err)
+		}
+		bugfixes := detector.WithConventionalTypes(bugfixTypes(ctx.Config)).Detect(ctx.ChangeSets)
+
+		// Read the changed line ranges of the first-parent history, including commits
+		// not analyzed, and the commits each merge brought in
+		history, err := ctx.Reader.ReadHunkHistory(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to read diffs: %w", err)
+		}
+		mergeOf, err := ctx.Reader.ReadMergeGroups(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to read merge history: %w", err)
+		}
+
+		// Carry the changed lines forward to the newest revision
+		aggregator := aggregation.NewRangeMetricsAggregator(ctx.Config.RangeScoring.MinCommits)
+		aggregator.Process(history, ctx.ChangeSets, bugfixes.BugfixCommits, mergeOf)
+		metrics := aggregator.Result()
+
+		// Calculate risk scores
+		scorer := scoring.NewRangeScorer(ctx.Config.RangeScoring, ctx.Config.Scoring.HalfLifeDays)
+		items := scorer.ScoreAndRank(metrics, c.Bool("explain"), ctx.Until)
+
+		report := &output.RangeAnalysisReport{
+			RepoPath:    ctx.RepoPath,
+			Since:       ctx.Since,
+			Until:       ctx.Until,
+			GeneratedAt: time.Now(),
+			Items:       items,
+		}
+		return writeRangeReport(c, report)
+	})
+}
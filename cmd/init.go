@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/masmgr/bugspots-go/internal/bootstrap"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// InitCmd returns the init command.
+func InitCmd() *cli.Command {
+	return &cli.Command{
+		Name:  "init",
+		Usage: "Inspect a repository and write a starter .bugspots.json tailored to it",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "repo",
+				Aliases: []string{"r"},
+				Usage:   "Path to Git repository",
+				Value:   ".",
+			},
+			&cli.StringFlag{
+				Name:    "branch",
+				Aliases: []string{"b"},
+				Usage:   "Branch to inspect",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Inspect commits since this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Configuration file to write (default: .bugspots.json in the repository)",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Overwrite an existing configuration file",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the configuration instead of writing it",
+			},
+		},
+		Action: initAction,
+	}
+}
+
+func initAction(c *cli.Context) error {
+	repoPath := c.String("repo")
+	path := c.String("output")
+	if path == "" {
+		path = filepath.Join(repoPath, ".bugspots.json")
+	}
+	if filepath.Ext(path) != ".json" {
+		return fmt.Errorf("init writes JSON; use a .json output path instead of %s", path)
+	}
+	dryRun := c.Bool("dry-run")
+	if !dryRun && !c.Bool("force") {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	since, err := parseDateFlag(c.String("since"))
+	if err != nil {
+		return fmt.Errorf("invalid since date: %w", err)
+	}
+
+	// Existing filters would hide the paths init looks for, so the history is read whole
+	ctx := context.Background()
+	files, err := git.ListFiles(ctx, repoPath, c.String("branch"))
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	reader, err := git.NewHistoryReader(git.ReadOptions{
+		RepoPath:     repoPath,
+		Branch:       c.String("branch"),
+		Since:        since,
+		DetailLevel:  git.ChangeDetailPathsOnly,
+		RenameDetect: git.RenameDetectSimple,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+	changeSets, err := reader.ReadChanges(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	insp := bootstrap.Inspect(files, changeSets)
+	data, err := insp.Starter().JSON()
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+		printInspection(os.Stderr, insp)
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	printInspection(os.Stdout, insp)
+	fmt.Printf("\nWrote %s\n", path)
+	return nil
+}
+
+// printInspection summarizes what the starter configuration is based on, with the
+// .mailmap entries it cannot set itself.
+func printInspection(w io.Writer, insp *bootstrap.Inspection) {
+	fmt.Fprintf(w, "Inspected %d files and %d commits\n", insp.Files, insp.Commits)
+
+	if len(insp.Languages) > 0 {
+		names := make([]string, 0, 5)
+		for i, lang := range insp.Languages {
+			if i == 5 {
+				break
+			}
+			names = append(names, fmt.Sprintf("%s (%d)", lang.Name, lang.Files))
+		}
+		fmt.Fprintf(w, "Languages: %s\n", strings.Join(names, ", "))
+	}
+	if len(insp.Excludes) > 0 {
+		fmt.Fprintf(w, "Excluded: %s\n", strings.Join(insp.Excludes, ", "))
+	}
+
+	style := "free-form"
+	if insp.Conventional() {
+		style = "Conventional Commits, classified by type (fix and hotfix are bugfixes)"
+	}
+	fmt.Fprintf(w, "Commit messages: %s (%.0f%% conventional)\n", style, insp.ConventionalShare*100)
+	if insp.UsesIssueKeys() {
+		fmt.Fprintf(w, "Issue keys: %s in %.0f%% of commits; coupling groups commits by issue\n",
+			strings.Join(insp.IssueKeys, ", "), insp.IssueKeyShare*100)
+	}
+
+	if len(insp.Bots) > 0 {
+		names := make([]string, len(insp.Bots))
+		for i, bot := range insp.Bots {
+			names[i] = fmt.Sprintf("%s (%d commits)", bot.Name, bot.Commits)
+		}
+		fmt.Fprintf(w, "Bots: %s; files they maintain are excluded\n", strings.Join(names, ", "))
+	}
+
+	if len(insp.Identities) > 0 {
+		fmt.Fprintln(w, "\nSome people commit under several emails and count as several contributors.")
+		fmt.Fprintln(w, "Review these lines and add them to .mailmap:")
+		for _, id := range insp.Identities {
+			for _, line := range id.MailmapLines() {
+				fmt.Fprintf(w, "  %s\n", line)
+			}
+		}
+	}
+}
@@ -1,25 +1,109 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/masmgr/bugspots-go/internal/output"
 )
 
+// The report writer lookups are also called by each command before reading history,
+// so an unknown --format, one the command does not support, or a broken --template
+// fails immediately.
+
+func fileReportWriter(c *cli.Context) (output.FileReportWriter, error) {
+	format, err := resolveOutputFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewFileReportWriter(format)
+}
+
+func commitReportWriter(c *cli.Context) (output.CommitReportWriter, error) {
+	format, err := resolveOutputFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewCommitReportWriter(format)
+}
+
+func couplingReportWriter(c *cli.Context) (output.CouplingReportWriter, error) {
+	format, err := resolveOutputFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewCouplingReportWriter(format)
+}
+
+func functionReportWriter(c *cli.Context) (output.FunctionReportWriter, error) {
+	format, err := resolveOutputFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewFunctionReportWriter(format)
+}
+
+func rangeReportWriter(c *cli.Context) (output.RangeReportWriter, error) {
+	format, err := resolveOutputFormat(c)
+	if err != nil {
+		return nil, err
+	}
+	return output.NewRangeReportWriter(format)
+}
+
+// resolveOutputFormat returns the selected format, rejecting --template combined with
+// another explicit --format and templates that do not parse.
+func resolveOutputFormat(c *cli.Context) (output.OutputFormat, error) {
+	format := outputFormat(c)
+	if format != output.FormatTemplate {
+		return format, nil
+	}
+	if c.IsSet("format") && getOutputFormat(c.String("format")) != output.FormatTemplate {
+		return "", fmt.Errorf("--template cannot be combined with --format %s", c.String("format"))
+	}
+	if err := output.ValidateTemplate(c.String("template")); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
 func writeFileReport(c *cli.Context, report *output.FileAnalysisReport) error {
-	opts := OutputOptions(c)
-	writer := output.NewFileReportWriter(opts.Format)
-	return writer.Write(report, opts)
+	writer, err := fileReportWriter(c)
+	if err != nil {
+		return err
+	}
+	return writer.Write(report, OutputOptions(c))
 }
 
 func writeCommitReport(c *cli.Context, report *output.CommitAnalysisReport) error {
-	opts := OutputOptions(c)
-	writer := output.NewCommitReportWriter(opts.Format)
-	return writer.Write(report, opts)
+	writer, err := commitReportWriter(c)
+	if err != nil {
+		return err
+	}
+	return writer.Write(report, OutputOptions(c))
 }
 
 func writeCouplingReport(c *cli.Context, report *output.CouplingAnalysisReport) error {
-	opts := OutputOptions(c)
-	writer := output.NewCouplingReportWriter(opts.Format)
-	return writer.Write(report, opts)
+	writer, err := couplingReportWriter(c)
+	if err != nil {
+		return err
+	}
+	return writer.Write(report, OutputOptions(c))
+}
+
+func writeFunctionReport(c *cli.Context, report *output.FunctionAnalysisReport) error {
+	writer, err := functionReportWriter(c)
+	if err != nil {
+		return err
+	}
+	return writer.Write(report, OutputOptions(c))
+}
+
+func writeRangeReport(c *cli.Context, report *output.RangeAnalysisReport) error {
+	writer, err := rangeReportWriter(c)
+	if err != nil {
+		return err
+	}
+	return writer.Write(report, OutputOptions(c))
 }
@@ -19,15 +19,27 @@ func App() *cli.App {
 		Version: "2.0.0",
 		Commands: []*cli.Command{
 			AnalyzeCmd(),
+			BaselineCmd(),
 			CommitsCmd(),
 			CouplingCmd(),
+			FunctionsCmd(),
+			HunksCmd(),
 			CalibrateCmd(),
+			ConfigCmd(),
+			InitCmd(),
 		},
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "config",
 				Aliases: []string{"c"},
 				Usage:   "Path to configuration file",
+				EnvVars: []string{config.EnvConfig},
+			},
+			&cli.StringFlag{
+				Name:    "profile",
+				Aliases: []string{"p"},
+				Usage:   "Named profile from the configuration file to apply",
+				EnvVars: []string{config.EnvProfile},
 			},
 		},
 		Action: func(c *cli.Context) error {
@@ -38,6 +50,11 @@ func App() *cli.App {
 
 // Common flags shared across commands
 func commonFlags() []cli.Flag {
+	return append(historyFlags(), reportFlags()...)
+}
+
+// historyFlags select the repository history to analyze.
+func historyFlags() []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
 			Name:    "repo",
@@ -71,12 +88,26 @@ func commonFlags() []cli.Flag {
 			Name:  "exclude",
 			Usage: "Glob patterns to exclude (can be specified multiple times)",
 		},
+		&cli.BoolFlag{
+			Name:  "conventional",
+			Usage: "Classify commits by Conventional Commits type: bugfix types count as bugfixes, noise types are skipped",
+		},
+	}
+}
+
+// reportFlags control how the report is written.
+func reportFlags() []cli.Flag {
+	return []cli.Flag{
 		&cli.StringFlag{
 			Name:    "format",
 			Aliases: []string{"f"},
-			Usage:   "Output format (console, json, csv, markdown, ci)",
+			Usage:   "Output format (console, json, csv, markdown, ci, html, sqlite; analyze/commits also: sarif, junit, openmetrics; analyze also: gitlab, checkstyle, svg, badge; coupling also: dot, graphml, mermaid, graph-json; functions and hunks: console, json, csv, markdown only). Unsupported formats are an error",
 			Value:   "console",
 		},
+		&cli.StringFlag{
+			Name:  "template",
+			Usage: "Render the report through a Go template file (.html templates use html/template); implies --format template",
+		},
 		&cli.IntFlag{
 			Name:    "top",
 			Aliases: []string{"n"},
@@ -107,7 +138,7 @@ func parseDateFlag(s string) (*time.Time, error) {
 	return &t, nil
 }
 
-// getOutputFormat parses the output format flag.
+// getOutputFormat parses the output format flag, resolving aliases.
 func getOutputFormat(s string) output.OutputFormat {
 	switch s {
 	case "json":
@@ -118,17 +149,50 @@ func getOutputFormat(s string) output.OutputFormat {
 		return output.FormatMarkdown
 	case "ci", "ndjson":
 		return output.FormatCI
-	default:
+	case "dot", "graphviz":
+		return output.FormatDOT
+	case "graphml":
+		return output.FormatGraphML
+	case "mermaid":
+		return output.FormatMermaid
+	case "graph-json", "graphjson":
+		return output.FormatGraph
+	case "sarif":
+		return output.FormatSARIF
+	case "gitlab", "codequality", "code-quality":
+		return output.FormatGitLab
+	case "checkstyle":
+		return output.FormatCheckstyle
+	case "junit":
+		return output.FormatJUnit
+	case "html":
+		return output.FormatHTML
+	case "svg", "treemap":
+		return output.FormatSVG
+	case "badge":
+		return output.FormatBadge
+	case "openmetrics", "prometheus":
+		return output.FormatOpenMetrics
+	case "sqlite", "sqlite3":
+		return output.FormatSQLite
+	case "template":
+		return output.FormatTemplate
+	case "console", "":
 		return output.FormatConsole
+	default:
+		// Unknown names are passed through so the writer constructors reject them
+		return output.OutputFormat(s)
 	}
 }
 
-// loadConfig loads configuration from file or defaults.
+// loadConfig loads configuration from file or defaults, printing the file's warnings.
 func loadConfig(c *cli.Context) (*config.Config, error) {
-	configPath := c.String("config")
-	cfg, err := config.LoadConfig(configPath)
+	cfg, warnings, err := config.Load(c.String("config"), c.String("profile"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, err
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, w)
 	}
 
 	// Apply filter overrides from CLI
@@ -138,6 +202,9 @@ func loadConfig(c *cli.Context) (*config.Config, error) {
 	if excludes := c.StringSlice("exclude"); len(excludes) > 0 {
 		cfg.Filters.Exclude = excludes
 	}
+	if c.IsSet("conventional") {
+		cfg.Conventional.Enabled = c.Bool("conventional")
+	}
 
 	return cfg, nil
 }
@@ -2,18 +2,27 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 // Config is the root configuration structure.
 type Config struct {
-	Scoring       ScoringConfig       `json:"scoring"`
-	Burst         BurstConfig         `json:"burst"`
-	Bugfix        BugfixConfig        `json:"bugfix"`
-	CommitScoring CommitScoringConfig `json:"commitScoring"`
-	Coupling      CouplingConfig      `json:"coupling"`
-	Filters       FilterConfig        `json:"filters"`
+	Scoring         ScoringConfig         `json:"scoring"`
+	FileScoring     FileScoringConfig     `json:"fileScoring"`
+	Burst           BurstConfig           `json:"burst"`
+	Bugfix          BugfixConfig          `json:"bugfix"`
+	Conventional    ConventionalConfig    `json:"conventional"`
+	CommitScoring   CommitScoringConfig   `json:"commitScoring"`
+	FunctionScoring FunctionScoringConfig `json:"functionScoring"`
+	RangeScoring    RangeScoringConfig    `json:"rangeScoring"`
+	Coupling        CouplingConfig        `json:"coupling"`
+	Filters         FilterConfig          `json:"filters"`
 }
 
 // BugfixConfig holds bugfix detection configuration.
@@ -21,12 +30,70 @@ type BugfixConfig struct {
 	Patterns []string `json:"patterns"` // Regex patterns for bugfix commit detection
 }
 
+// ConventionalConfig classifies commits by their Conventional Commits type. Commits
+// whose messages don't follow the convention fall back to the bugfix patterns.
+type ConventionalConfig struct {
+	Enabled     bool     `json:"enabled"`
+	BugfixTypes []string `json:"bugfixTypes"` // Types of bugfix commits, instead of the patterns
+	NoiseTypes  []string `json:"noiseTypes"`  // Types of commits left out of every analysis
+}
+
 // ScoringConfig holds file hotspot scoring configuration.
 type ScoringConfig struct {
 	HalfLifeDays int          `json:"halfLifeDays"`
 	Weights      WeightConfig `json:"weights"`
 }
 
+// FileScoringConfig holds file hotspot classification options.
+type FileScoringConfig struct {
+	Thresholds RiskThresholds `json:"thresholds"` // Risk level thresholds for file scores
+	Overrides  []PathOverride `json:"overrides"`  // Scoring changes for matching paths
+}
+
+// PathOverride changes how files matching a glob are scored. Every matching override
+// applies in order: later weights, half-life and risk level win; score factors multiply.
+type PathOverride struct {
+	Path         string           `json:"path"`                   // Glob pattern (** matches directories)
+	ScoreFactor  *float64         `json:"scoreFactor,omitempty"`  // Multiplies the file's score
+	Weights      *WeightOverrides `json:"weights,omitempty"`      // Replaces some scoring weights
+	HalfLifeDays *int             `json:"halfLifeDays,omitempty"` // Replaces the recency half-life
+	RiskLevel    RiskLevel        `json:"riskLevel,omitempty"`    // Fixed level instead of the thresholds
+}
+
+// Matches reports whether the override applies to path.
+func (o PathOverride) Matches(path string) bool {
+	matched, _ := doublestar.Match(o.Path, path)
+	return matched
+}
+
+// WeightOverrides replaces the weights that are set; nil weights are kept.
+type WeightOverrides struct {
+	Commit     *float64 `json:"commit,omitempty"`
+	Churn      *float64 `json:"churn,omitempty"`
+	Recency    *float64 `json:"recency,omitempty"`
+	Burst      *float64 `json:"burst,omitempty"`
+	Ownership  *float64 `json:"ownership,omitempty"`
+	Bugfix     *float64 `json:"bugfix,omitempty"`
+	Complexity *float64 `json:"complexity,omitempty"`
+	Coupling   *float64 `json:"coupling,omitempty"`
+}
+
+// Apply returns w with the set weights replaced.
+func (o WeightOverrides) Apply(w WeightConfig) WeightConfig {
+	for _, f := range []struct {
+		override *float64
+		weight   *float64
+	}{
+		{o.Commit, &w.Commit}, {o.Churn, &w.Churn}, {o.Recency, &w.Recency}, {o.Burst, &w.Burst},
+		{o.Ownership, &w.Ownership}, {o.Bugfix, &w.Bugfix}, {o.Complexity, &w.Complexity}, {o.Coupling, &w.Coupling},
+	} {
+		if f.override != nil {
+			*f.weight = *f.override
+		}
+	}
+	return w
+}
+
 // WeightConfig holds weights for multi-factor scoring.
 type WeightConfig struct {
 	Commit     float64 `json:"commit"`
@@ -36,6 +103,7 @@ type WeightConfig struct {
 	Ownership  float64 `json:"ownership"`
 	Bugfix     float64 `json:"bugfix"`
 	Complexity float64 `json:"complexity"`
+	Coupling   float64 `json:"coupling"` // Optional; coupling degree is only computed when > 0
 }
 
 // BurstConfig holds burst calculation options.
@@ -54,14 +122,41 @@ type CommitWeightConfig struct {
 	Diffusion float64 `json:"diffusion"`
 	Size      float64 `json:"size"`
 	Entropy   float64 `json:"entropy"`
+	Breaking  float64 `json:"breaking"` // Optional; Conventional Commits breaking changes
 }
 
-// RiskThresholds for risk level classification.
+// FunctionScoringConfig holds Go function hotspot scoring configuration. Recency uses
+// the scoring.halfLifeDays half-life.
+type FunctionScoringConfig struct {
+	Weights    RegionWeightConfig `json:"weights"`
+	Thresholds RiskThresholds     `json:"thresholds"`
+}
+
+// RangeScoringConfig holds line range hotspot scoring configuration. Recency uses the
+// scoring.halfLifeDays half-life.
+type RangeScoringConfig struct {
+	MinCommits int                `json:"minCommits"` // Commits that must have changed a line for it to be in a range
+	Weights    RegionWeightConfig `json:"weights"`
+	Thresholds RiskThresholds     `json:"thresholds"`
+}
+
+// RegionWeightConfig holds weights for function and line range hotspot scoring.
+type RegionWeightConfig struct {
+	Commit  float64 `json:"commit"`
+	Churn   float64 `json:"churn"`
+	Bugfix  float64 `json:"bugfix"`
+	Recency float64 `json:"recency"`
+}
+
+// RiskThresholds for risk level classification. Scores at or above High are high
+// risk, scores at or above Medium are medium risk.
 type RiskThresholds struct {
 	High   float64 `json:"high"`
 	Medium float64 `json:"medium"`
 }
 
+// DefaultRiskThresholds returns the default thresholds shared by files, commits and
+// functions.
 func DefaultRiskThresholds() RiskThresholds {
 	return RiskThresholds{
 		High:   0.7,
@@ -91,10 +186,46 @@ const (
 
 // CouplingConfig holds coupling analysis options.
 type CouplingConfig struct {
-	MinCoCommits        int     `json:"minCoCommits"`
-	MinJaccardThreshold float64 `json:"minJaccardThreshold"`
-	MaxFilesPerCommit   int     `json:"maxFilesPerCommit"`
-	TopPairs            int     `json:"topPairs"`
+	MinCoCommits        int             `json:"minCoCommits"`
+	MinJaccardThreshold float64         `json:"minJaccardThreshold"`
+	MaxFilesPerCommit   int             `json:"maxFilesPerCommit"`
+	TopPairs            int             `json:"topPairs"`
+	DetectClusters      bool            `json:"detectClusters"` // Run community detection on the co-change graph
+	CrossBoundary       bool            `json:"crossBoundary"`  // Report couplings between different modules
+	Boundaries          BoundaryConfig  `json:"boundaries"`
+	ChangeSets          ChangeSetConfig `json:"changeSets"`
+}
+
+// ChangeSetMode selects how commits are grouped into logical change sets for coupling.
+type ChangeSetMode string
+
+const (
+	ChangeSetModeCommit ChangeSetMode = "commit" // Each commit is its own change set
+	ChangeSetModeAuthor ChangeSetMode = "author" // Consecutive commits by one author within a time window
+	ChangeSetModeIssue  ChangeSetMode = "issue"  // Commits referencing the same issue key
+	ChangeSetModeMerge  ChangeSetMode = "merge"  // Commits brought in by the same merge commit
+)
+
+// DefaultIssuePattern matches Jira-style keys (ABC-123) and GitHub-style references (#123).
+const DefaultIssuePattern = `\b[A-Z][A-Z0-9]+-\d+\b|#\d+\b`
+
+// ChangeSetConfig controls grouping of commits into logical change sets.
+type ChangeSetConfig struct {
+	Mode         ChangeSetMode `json:"mode"`
+	WindowHours  int           `json:"windowHours"`  // Maximum gap between consecutive commits in author mode
+	IssuePattern string        `json:"issuePattern"` // Regex for issue keys in issue mode
+}
+
+// BoundaryConfig defines how file paths map to modules for cross-boundary coupling.
+type BoundaryConfig struct {
+	Depth   int              `json:"depth"`   // Number of leading directories that identify a module
+	Modules []BoundaryModule `json:"modules"` // Explicit module mapping, checked before Depth
+}
+
+// BoundaryModule maps glob patterns to a named module. The first matching module wins.
+type BoundaryModule struct {
+	Name     string   `json:"name"`
+	Patterns []string `json:"patterns"`
 }
 
 // FilterConfig holds file path filtering options.
@@ -118,6 +249,9 @@ func DefaultConfig() *Config {
 				Complexity: 0.10,
 			},
 		},
+		FileScoring: FileScoringConfig{
+			Thresholds: DefaultRiskThresholds(),
+		},
 		Burst: BurstConfig{
 			WindowDays: 7,
 		},
@@ -129,6 +263,10 @@ func DefaultConfig() *Config {
 				`\bpatch\b`,
 			},
 		},
+		Conventional: ConventionalConfig{
+			BugfixTypes: []string{"fix"},
+			NoiseTypes:  []string{"chore", "style", "docs"},
+		},
 		CommitScoring: CommitScoringConfig{
 			Weights: CommitWeightConfig{
 				Diffusion: 0.35,
@@ -137,11 +275,38 @@ func DefaultConfig() *Config {
 			},
 			Thresholds: DefaultRiskThresholds(),
 		},
+		FunctionScoring: FunctionScoringConfig{
+			Weights: RegionWeightConfig{
+				Commit:  0.30,
+				Churn:   0.25,
+				Bugfix:  0.30,
+				Recency: 0.15,
+			},
+			Thresholds: DefaultRiskThresholds(),
+		},
+		RangeScoring: RangeScoringConfig{
+			MinCommits: 2,
+			Weights: RegionWeightConfig{
+				Commit:  0.30,
+				Churn:   0.25,
+				Bugfix:  0.30,
+				Recency: 0.15,
+			},
+			Thresholds: DefaultRiskThresholds(),
+		},
 		Coupling: CouplingConfig{
 			MinCoCommits:        3,
 			MinJaccardThreshold: 0.1,
 			MaxFilesPerCommit:   50,
 			TopPairs:            50,
+			Boundaries: BoundaryConfig{
+				Depth: 1,
+			},
+			ChangeSets: ChangeSetConfig{
+				Mode:         ChangeSetModeCommit,
+				WindowHours:  4,
+				IssuePattern: DefaultIssuePattern,
+			},
 		},
 		Filters: FilterConfig{
 			Include: []string{},
@@ -150,43 +315,67 @@ func DefaultConfig() *Config {
 	}
 }
 
-// LoadConfig loads configuration from a file, merging with defaults.
-func LoadConfig(path string) (*Config, error) {
-	cfg := DefaultConfig()
+// configFileNames are the configuration files looked up when no path is given, in order.
+var configFileNames = []string{".bugspots.json", ".bugspots.yaml", ".bugspots.yml", ".bugspots.toml"}
 
-	if path == "" {
-		// Try default locations
-		candidates := []string{".bugspots.json"}
-		if home, err := os.UserHomeDir(); err == nil && home != "" {
-			candidates = append(candidates, filepath.Join(home, ".bugspots.json"))
-		} else if envHome := os.Getenv("HOME"); envHome != "" {
-			candidates = append(candidates, filepath.Join(envHome, ".bugspots.json"))
-		}
-		for _, p := range candidates {
+// FindConfigFile returns the first configuration file found in the working directory,
+// then in the home directory, or "" when there is none.
+func FindConfigFile() string {
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		dirs = append(dirs, home)
+	} else if envHome := os.Getenv("HOME"); envHome != "" {
+		dirs = append(dirs, envHome)
+	}
+	for _, dir := range dirs {
+		for _, name := range configFileNames {
+			p := filepath.Join(dir, name)
+			if dir == "." {
+				p = name
+			}
 			if _, err := os.Stat(p); err == nil {
-				path = p
-				break
+				return p
 			}
 		}
 	}
+	return ""
+}
 
+// Load loads configuration from a JSON, YAML or TOML file (by extension) with the
+// files it extends, merged over the defaults, applies the named profile and the
+// BUGSPOTS_* environment variables, and returns the warnings. An empty path searches
+// the default locations; a missing file yields the defaults unless a profile is
+// requested. Errors are rejected with a *ValidationError.
+func Load(path, profile string) (*Config, []Issue, error) {
 	if path == "" {
-		return cfg, nil
+		path = FindConfigFile()
 	}
-
-	data, err := os.ReadFile(path)
+	doc, err := ReadDocument(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return cfg, nil
+		if path != "" && !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, err
 		}
-		return nil, err
+		if profile != "" {
+			return nil, nil, fmt.Errorf("profile %q requested but no configuration file found", profile)
+		}
+		doc = NewDocument()
 	}
 
-	if err := json.Unmarshal(data, cfg); err != nil {
-		return nil, err
+	cfg, issues, err := doc.Resolve(profile, os.Environ())
+	if err != nil {
+		return nil, nil, err
 	}
+	if errs := Errors(issues); len(errs) > 0 {
+		return nil, nil, &ValidationError{File: path, Issues: errs}
+	}
+	return cfg, issues, nil
+}
 
-	return cfg, nil
+// LoadConfig loads configuration from a file, merging with defaults. Warnings are
+// discarded; use Load to report them.
+func LoadConfig(path string) (*Config, error) {
+	cfg, _, err := Load(path, "")
+	return cfg, err
 }
 
 // SaveConfig saves configuration to a file.
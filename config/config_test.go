@@ -73,6 +73,9 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.CommitScoring.Thresholds.Medium != 0.4 {
 		t.Errorf("Thresholds.Medium = %f, expected 0.4", cfg.CommitScoring.Thresholds.Medium)
 	}
+	if cfg.FileScoring.Thresholds != DefaultRiskThresholds() {
+		t.Errorf("FileScoring.Thresholds = %+v, expected defaults", cfg.FileScoring.Thresholds)
+	}
 	if cfg.CommitScoring.Weights.Diffusion != 0.35 {
 		t.Errorf("CommitScoring.Weights.Diffusion = %f, expected 0.35", cfg.CommitScoring.Weights.Diffusion)
 	}
@@ -115,3 +118,18 @@ func TestDefaultConfig_WeightsSum(t *testing.T) {
 		t.Errorf("Commit scoring weights sum = %f, expected 1.0", commitWeightsSum)
 	}
 }
+
+func TestWeightOverrides_Apply(t *testing.T) {
+	churn, coupling := 0.4, 0.1
+	base := DefaultConfig().Scoring.Weights
+	got := WeightOverrides{Churn: &churn, Coupling: &coupling}.Apply(base)
+
+	want := base
+	want.Churn, want.Coupling = churn, coupling
+	if got != want {
+		t.Errorf("Apply() = %+v, want %+v", got, want)
+	}
+	if base.Churn == churn {
+		t.Error("Apply() modified the base weights")
+	}
+}
@@ -0,0 +1,333 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Keys that control how files combine rather than configure analysis.
+const (
+	extendsKey  = "extends"  // Path or list of paths of base files, relative to the file
+	profilesKey = "profiles" // Named overlays selected with --profile
+)
+
+// inheritMarker in an array stands for the inherited items, e.g. ["...", "gen/**"]
+// appends to the base list. Without it an array replaces the inherited one.
+const inheritMarker = "..."
+
+// Document is a configuration file layered over the files it extends and the defaults.
+// Layers merge in order (defaults, bases depth-first in listed order, the file itself),
+// then Resolve applies a profile on top.
+type Document struct {
+	Path          string
+	root          *node // nil when a file could not be parsed
+	profiles      map[string][]*node
+	profileNames  []string
+	issues        []Issue
+	profileIssues map[string][]Issue
+}
+
+// ReadDocument reads the configuration file at path and the files it extends. Problems
+// in the files are reported by Resolve; the error is only set when path cannot be read.
+func ReadDocument(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseDocument(path, data), nil
+}
+
+// NewDocument returns a document without a file, holding only the defaults.
+func NewDocument() *Document {
+	return parseDocument("", nil)
+}
+
+func parseDocument(path string, data []byte) *Document {
+	doc := &Document{Path: path, profiles: map[string][]*node{}, profileIssues: map[string][]Issue{}}
+	layers, ok := doc.readLayers(path, data, nil)
+	if !ok {
+		return doc
+	}
+	doc.root = defaultsNode()
+	for _, layer := range layers {
+		doc.root = mergeNodes(doc.root, layer)
+	}
+	return doc
+}
+
+// readLayers parses one file and returns its layers, bases first. Its own profiles are
+// set aside on the document. ok is false when any file fails to parse.
+func (d *Document) readLayers(path string, data []byte, chain []string) (layers []*node, ok bool) {
+	var issues issueList
+	defer func() { d.issues = append(d.issues, issues...) }()
+
+	root, err := parseConfig(data, FormatFromPath(path))
+	if err != nil {
+		issue := Issue{File: path, Severity: SeverityError, Message: err.Error()}
+		var se *syntaxError
+		if errors.As(err, &se) {
+			issue.Position, issue.Message = se.pos, "syntax error: "+se.msg
+		}
+		issues = append(issues, issue)
+		return nil, false
+	}
+	root.setFile(path)
+	if root.kind != nodeObject {
+		issues.add(SeverityError, root, "", "expected an object at the top level, got %s", root.describe())
+		return nil, false
+	}
+	root.removeField(schemaKey)
+
+	ok = true
+	if extends, found := root.fields[extendsKey]; found {
+		root.removeField(extendsKey)
+		abs, _ := filepath.Abs(path)
+		chain = append(chain, abs)
+		for _, ref := range extendsRefs(extends, &issues) {
+			base, baseOK := d.readBase(path, ref, chain, &issues)
+			layers = append(layers, base...)
+			ok = ok && baseOK
+		}
+	}
+
+	if profiles, found := root.fields[profilesKey]; found {
+		root.removeField(profilesKey)
+		d.addProfiles(profiles, &issues)
+	}
+
+	checkNode(root, reflect.TypeOf(Config{}), "", &issues)
+	return append(layers, root), ok
+}
+
+// extendsRefs returns the path nodes of an extends value: a string or an array of
+// strings.
+func extendsRefs(n *node, issues *issueList) []*node {
+	if n.kind == nodeString {
+		return []*node{n}
+	}
+	if n.kind != nodeArray {
+		issues.add(SeverityError, n, extendsKey, "expected a path or an array of paths, got %s", n.describe())
+		return nil
+	}
+	var refs []*node
+	for i, item := range n.items {
+		if item.kind != nodeString {
+			issues.add(SeverityError, item, fmt.Sprintf("%s[%d]", extendsKey, i), "expected a path, got %s", item.describe())
+			continue
+		}
+		refs = append(refs, item)
+	}
+	return refs
+}
+
+// readBase reads a file named by extends, resolved relative to the extending file.
+func (d *Document) readBase(from string, ref *node, chain []string, issues *issueList) ([]*node, bool) {
+	path := ref.value.(string)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+	abs, _ := filepath.Abs(path)
+	for i, seen := range chain {
+		if seen == abs {
+			cycle := append(append([]string{}, chain[i:]...), abs)
+			issues.add(SeverityError, ref, extendsKey, "extends cycle: %s", strings.Join(cycle, " -> "))
+			return nil, false
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		issues.add(SeverityError, ref, extendsKey, "cannot read %s: %v", path, errors.Unwrap(err))
+		return nil, false
+	}
+	return d.readLayers(path, data, chain)
+}
+
+// addProfiles sets aside a file's profiles. A profile defined in several files merges
+// in file order, like the files themselves.
+func (d *Document) addProfiles(n *node, issues *issueList) {
+	if n.kind != nodeObject {
+		issues.add(SeverityError, n, profilesKey, "expected an object of named profiles, got %s", n.describe())
+		return
+	}
+	for _, name := range n.keys {
+		profile := n.fields[name]
+		key := profilesKey + "." + name
+		if profile.kind != nodeObject {
+			issues.add(SeverityError, profile, key, "expected an object, got %s", profile.describe())
+			continue
+		}
+		var profileIssues issueList
+		checkNode(profile, reflect.TypeOf(Config{}), key, &profileIssues)
+		d.profileIssues[name] = append(d.profileIssues[name], profileIssues...)
+		if _, seen := d.profiles[name]; !seen {
+			d.profileNames = append(d.profileNames, name)
+		}
+		d.profiles[name] = append(d.profiles[name], profile)
+	}
+}
+
+// Profiles returns the names of the profiles defined by the file and its bases.
+func (d *Document) Profiles() []string {
+	names := append([]string(nil), d.profileNames...)
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the configuration with the named profile and then the BUGSPOTS_*
+// variables in environ applied; an empty name applies no profile and a nil environ no
+// variables. The returned config is nil when there are errors. The error is only set
+// when the profile does not exist.
+func (d *Document) Resolve(profile string, environ []string) (*Config, []Issue, error) {
+	root, issues, err := d.merge(profile, environ)
+	if err != nil || root == nil {
+		return nil, issues, err
+	}
+
+	// Unknown keys are ignored when decoding, so range checks still run; values of the
+	// wrong type stop here.
+	cfg := &Config{}
+	encoded, err := json.Marshal(root.plain())
+	if err == nil {
+		err = json.Unmarshal(encoded, cfg)
+	}
+	if err != nil {
+		if len(Errors(issues)) == 0 {
+			issues.add(SeverityError, root, "", "%s", err)
+		}
+		return nil, issues, nil
+	}
+
+	for _, issue := range cfg.Validate() {
+		issue.locate(root.lookup(issue.Key))
+		issues = append(issues, issue)
+	}
+	if len(Errors(issues)) > 0 {
+		return nil, issues, nil
+	}
+	return cfg, issues, nil
+}
+
+// Setting is one resolved configuration value and where it came from.
+type Setting struct {
+	Key    string      `json:"key"`
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"` // "default", file:line:column, or "env NAME"
+}
+
+// Settings resolves the document like Resolve and lists every value in Config field
+// order. Arrays are single values. The settings are nil when there are errors.
+func (d *Document) Settings(profile string, environ []string) ([]Setting, []Issue, error) {
+	_, issues, err := d.Resolve(profile, environ)
+	if err != nil || len(Errors(issues)) > 0 {
+		return nil, issues, err
+	}
+	root, _, err := d.merge(profile, environ)
+	if err != nil {
+		return nil, nil, err
+	}
+	var settings []Setting
+	collectSettings(root, reflect.TypeOf(Config{}), "", &settings)
+	return settings, issues, nil
+}
+
+func collectSettings(n *node, t reflect.Type, key string, settings *[]Setting) {
+	if t.Kind() != reflect.Struct {
+		*settings = append(*settings, Setting{Key: key, Value: n.plain(), Source: n.origin()})
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if child, ok := n.fields[name]; ok && name != "" && name != "-" {
+			collectSettings(child, f.Type, joinKey(key, name), settings)
+		}
+	}
+}
+
+// merge layers the profile and the environment over the document. The tree is nil
+// when a file could not be parsed.
+func (d *Document) merge(profile string, environ []string) (*node, issueList, error) {
+	root := d.root
+	issues := issueList(append([]Issue(nil), d.issues...))
+	if profile != "" {
+		overlays, ok := d.profiles[profile]
+		if !ok {
+			available := "none defined"
+			if names := d.Profiles(); len(names) > 0 {
+				available = "available: " + strings.Join(names, ", ")
+			}
+			return nil, nil, fmt.Errorf("unknown profile %q in %s (%s)", profile, d.Path, available)
+		}
+		issues = append(issues, d.profileIssues[profile]...)
+		for _, overlay := range overlays {
+			if root != nil {
+				root = mergeNodes(root, overlay)
+			}
+		}
+	}
+
+	env, envIssues := envNode(environ)
+	issues = append(issues, envIssues...)
+	if root != nil {
+		root = mergeNodes(root, env)
+	}
+	return root, issues, nil
+}
+
+// defaultsNode is DefaultConfig as the bottom layer, so "..." can extend default lists.
+func defaultsNode() *node {
+	encoded, err := json.Marshal(DefaultConfig())
+	if err != nil {
+		panic(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(encoded, &v); err != nil {
+		panic(err)
+	}
+	return valueNode(v, "", nil)
+}
+
+// mergeNodes overlays over on base. Objects merge key by key; an array replaces the
+// inherited array, with each "..." item replaced by the inherited items; any other
+// value replaces the inherited one.
+func mergeNodes(base, over *node) *node {
+	switch over.kind {
+	case nodeObject:
+		// The environment layer's root only holds values and keeps the inherited location
+		loc := over
+		if base != nil && over.pos.Line == 0 && over.file == "" && over.env == "" {
+			loc = base
+		}
+		merged := newObjectNode(loc.pos)
+		merged.file, merged.env = loc.file, loc.env
+		if base != nil && base.kind == nodeObject {
+			for _, k := range base.keys {
+				merged.setField(k, base.fields[k])
+			}
+		}
+		for _, k := range over.keys {
+			merged.setField(k, mergeNodes(merged.fields[k], over.fields[k]))
+		}
+		return merged
+	case nodeArray:
+		merged := &node{kind: nodeArray, pos: over.pos, file: over.file, env: over.env}
+		for _, item := range over.items {
+			if item.kind == nodeString && item.value == inheritMarker {
+				if base != nil && base.kind == nodeArray {
+					merged.items = append(merged.items, base.items...)
+				}
+				continue
+			}
+			merged.items = append(merged.items, item)
+		}
+		return merged
+	default:
+		return over
+	}
+}
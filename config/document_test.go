@@ -0,0 +1,324 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files relative to a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDocument_EmptyFileMatchesDefaults(t *testing.T) {
+	cfg, issues, err := parseDocument("config.json", []byte("{}")).Resolve("", nil)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("Resolve() = %v, %v", issues, err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultConfig()) {
+		t.Errorf("Resolve() of an empty file = %+v, expected the defaults", cfg)
+	}
+}
+
+func TestDocument_Extends(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"org/base.yaml": "scoring:\n  halfLifeDays: 60\n  weights:\n    commit: 0.3\n    churn: 0.1\nfilters:\n  exclude: [\"**/vendor/**\"]\n",
+		"org/ci.toml":   "extends = \"base.yaml\"\n\n[burst]\nwindowDays = 3\n",
+		"repo/.bugspots.json": `{
+  "extends": ["../org/ci.toml"],
+  "scoring": {"halfLifeDays": 14},
+  "filters": {"exclude": ["...", "**/gen/**"]}
+}`,
+	})
+
+	cfg, issues, err := ValidateFile(filepath.Join(dir, "repo/.bugspots.json"), "")
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("ValidateFile() = %v, %v", issues, err)
+	}
+	if cfg.Scoring.HalfLifeDays != 14 {
+		t.Errorf("HalfLifeDays = %d, expected 14 from the repo file", cfg.Scoring.HalfLifeDays)
+	}
+	if cfg.Scoring.Weights.Commit != 0.3 || cfg.Scoring.Weights.Churn != 0.1 {
+		t.Errorf("Weights = %+v, expected commit and churn from the org base", cfg.Scoring.Weights)
+	}
+	if cfg.Scoring.Weights.Recency != 0.15 {
+		t.Errorf("Weights.Recency = %f, expected the default 0.15", cfg.Scoring.Weights.Recency)
+	}
+	if cfg.Burst.WindowDays != 3 {
+		t.Errorf("WindowDays = %d, expected 3 from the nested base", cfg.Burst.WindowDays)
+	}
+	if want := []string{"**/vendor/**", "**/gen/**"}; !reflect.DeepEqual(cfg.Filters.Exclude, want) {
+		t.Errorf("Filters.Exclude = %v, expected %v", cfg.Filters.Exclude, want)
+	}
+}
+
+func TestMergeNodes_Arrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		over     string
+		expected []string
+	}{
+		{name: "Replace", base: `["a", "b"]`, over: `["c"]`, expected: []string{"c"}},
+		{name: "Append", base: `["a", "b"]`, over: `["...", "c"]`, expected: []string{"a", "b", "c"}},
+		{name: "Prepend", base: `["a", "b"]`, over: `["c", "..."]`, expected: []string{"c", "a", "b"}},
+		{name: "Clear", base: `["a", "b"]`, over: `[]`, expected: []string{}},
+		{name: "Marker without base", base: `null`, over: `["...", "c"]`, expected: []string{"c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, err := parseJSON([]byte(tt.base))
+			if err != nil {
+				t.Fatal(err)
+			}
+			over, err := parseJSON([]byte(tt.over))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, item := range mergeNodes(base, over).items {
+				got = append(got, item.value.(string))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("merged = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDocument_MarkerExtendsDefaults(t *testing.T) {
+	cfg, issues, _ := parseDocument("config.yaml", []byte("bugfix:\n  patterns: [\"...\", \"\\\\bregression\\\\b\"]\n")).Resolve("", nil)
+	if len(issues) != 0 {
+		t.Fatalf("Resolve() issues = %v", issues)
+	}
+	defaults := DefaultConfig().Bugfix.Patterns
+	if len(cfg.Bugfix.Patterns) != len(defaults)+1 || cfg.Bugfix.Patterns[len(defaults)] != `\bregression\b` {
+		t.Errorf("Bugfix.Patterns = %v, expected the defaults followed by the new pattern", cfg.Bugfix.Patterns)
+	}
+}
+
+func TestDocument_Profiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.yaml": "profiles:\n  strict-ci:\n    fileScoring:\n      thresholds: {high: 0.6}\n    burst: {windowDays: 2}\n",
+		".bugspots.yaml": `extends: base.yaml
+scoring:
+  halfLifeDays: 14
+filters:
+  exclude: ["**/vendor/**"]
+profiles:
+  strict-ci:
+    burst:
+      windowDays: 5
+    filters:
+      exclude: ["...", "**/docs/**"]
+  weekly-report:
+    scoring:
+      halfLifeDays: 90
+`,
+	})
+	doc, err := ReadDocument(filepath.Join(dir, ".bugspots.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"strict-ci", "weekly-report"}; !reflect.DeepEqual(doc.Profiles(), want) {
+		t.Errorf("Profiles() = %v, expected %v", doc.Profiles(), want)
+	}
+
+	cfg, _, err := doc.Resolve("", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Burst.WindowDays != 7 || cfg.FileScoring.Thresholds.High != 0.7 {
+		t.Errorf("without a profile: WindowDays = %d, High = %g, expected the defaults", cfg.Burst.WindowDays, cfg.FileScoring.Thresholds.High)
+	}
+
+	cfg, _, err = doc.Resolve("strict-ci", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Burst.WindowDays != 5 {
+		t.Errorf("WindowDays = %d, expected 5 from the file's profile over the base's", cfg.Burst.WindowDays)
+	}
+	if cfg.FileScoring.Thresholds.High != 0.6 {
+		t.Errorf("Thresholds.High = %g, expected 0.6 from the base's profile", cfg.FileScoring.Thresholds.High)
+	}
+	if cfg.Scoring.HalfLifeDays != 14 {
+		t.Errorf("HalfLifeDays = %d, expected 14 from the file", cfg.Scoring.HalfLifeDays)
+	}
+	if want := []string{"**/vendor/**", "**/docs/**"}; !reflect.DeepEqual(cfg.Filters.Exclude, want) {
+		t.Errorf("Filters.Exclude = %v, expected %v", cfg.Filters.Exclude, want)
+	}
+
+	cfg, _, err = doc.Resolve("weekly-report", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Scoring.HalfLifeDays != 90 {
+		t.Errorf("HalfLifeDays = %d, expected 90", cfg.Scoring.HalfLifeDays)
+	}
+
+	_, _, err = doc.Resolve("nightly", nil)
+	if err == nil || !strings.Contains(err.Error(), "available: strict-ci, weekly-report") {
+		t.Errorf("Resolve(unknown) error = %v, expected the available profiles", err)
+	}
+}
+
+func TestDocument_Issues(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		profile     string
+		wantFile    string
+		wantLine    int
+		wantMessage string
+	}{
+		{
+			name: "Error in base file",
+			files: map[string]string{
+				"base.json":   "{\n  \"scoring\": {\n    \"halfLifeDays\": 0\n  }\n}",
+				"config.json": `{"extends": "base.json"}`,
+			},
+			wantFile:    "base.json",
+			wantLine:    3,
+			wantMessage: "must be at least 1",
+		},
+		{
+			name: "Missing base file",
+			files: map[string]string{
+				"config.yaml": "extends:\n  - missing.yaml\n",
+			},
+			wantFile:    "config.yaml",
+			wantLine:    2,
+			wantMessage: "cannot read",
+		},
+		{
+			name: "Cycle",
+			files: map[string]string{
+				"a.yaml":      "extends: b.yaml\n",
+				"b.yaml":      "extends: a.yaml\n",
+				"config.yaml": "extends: a.yaml\n",
+			},
+			wantFile:    "b.yaml",
+			wantLine:    1,
+			wantMessage: "extends cycle",
+		},
+		{
+			name: "Profile error only when selected",
+			files: map[string]string{
+				"config.yaml": "profiles:\n  ci:\n    burst:\n      windowDay: 3\n",
+			},
+			profile:     "ci",
+			wantFile:    "config.yaml",
+			wantLine:    4,
+			wantMessage: `unknown key (did you mean "windowDays"?)`,
+		},
+		{
+			name: "Range error from profile",
+			files: map[string]string{
+				"config.yaml": "profiles:\n  ci:\n    fileScoring:\n      thresholds:\n        high: 0.2\n",
+			},
+			profile:     "ci",
+			wantFile:    "config.yaml",
+			wantLine:    4,
+			wantMessage: "high (0.2) must not be below medium (0.4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			var configName string
+			for name := range tt.files {
+				if strings.HasPrefix(name, "config.") {
+					configName = name
+				}
+			}
+			doc, err := ReadDocument(filepath.Join(dir, configName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.profile != "" {
+				if _, issues, _ := doc.Resolve("", nil); len(issues) != 0 {
+					t.Errorf("Resolve(\"\") issues = %v, expected none without the profile", issues)
+				}
+			}
+			cfg, issues, err := doc.Resolve(tt.profile, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg != nil || len(issues) == 0 {
+				t.Fatalf("Resolve() = %v, %v, expected errors", cfg, issues)
+			}
+			issue := issues[0]
+			if filepath.Base(issue.File) != tt.wantFile || issue.Position.Line != tt.wantLine {
+				t.Errorf("issue at %s:%d, expected %s:%d (%s)", issue.File, issue.Position.Line, tt.wantFile, tt.wantLine, issue)
+			}
+			if !strings.Contains(issue.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, expected it to contain %q", issue.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestLoad_Profile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"config.json": `{"profiles": {"ci": {"burst": {"windowDays": 2}}}}`,
+	})
+	cfg, _, err := Load(filepath.Join(dir, "config.json"), "ci")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Burst.WindowDays != 2 {
+		t.Errorf("WindowDays = %d, expected 2", cfg.Burst.WindowDays)
+	}
+
+	if _, _, err := Load(filepath.Join(dir, "missing.json"), "ci"); err == nil {
+		t.Error("Load() with a profile and no configuration file should fail")
+	}
+}
+
+func TestDocument_Settings(t *testing.T) {
+	doc := parseDocument("config.yaml", []byte("scoring:\n  halfLifeDays: 14\nprofiles:\n  ci:\n    burst:\n      windowDays: 2\n"))
+	settings, _, err := doc.Settings("ci", []string{"BUGSPOTS_FILTERS_EXCLUDE=gen/**"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sources := map[string]string{}
+	for _, s := range settings {
+		sources[s.Key] = s.Source
+	}
+	expected := map[string]string{
+		"scoring.halfLifeDays":   "config.yaml:2:3",
+		"scoring.weights.commit": "default",
+		"burst.windowDays":       "config.yaml:6:7",
+		"filters.exclude":        "env BUGSPOTS_FILTERS_EXCLUDE",
+	}
+	for key, want := range expected {
+		if sources[key] != want {
+			t.Errorf("source of %s = %q, expected %q", key, sources[key], want)
+		}
+	}
+	if settings[0].Key != "scoring.halfLifeDays" {
+		t.Errorf("first setting = %s, expected Config field order", settings[0].Key)
+	}
+
+	if settings, issues, _ := doc.Settings("", []string{"BUGSPOTS_BURST_WINDOW_DAYS=0"}); settings != nil || len(Errors(issues)) != 1 {
+		t.Errorf("Settings() with an invalid value = %v, %v, expected one error and no settings", settings, issues)
+	}
+}
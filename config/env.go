@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix starts the environment variables that override configuration values, e.g.
+// BUGSPOTS_SCORING_WEIGHTS_CHURN for scoring.weights.churn.
+const EnvPrefix = "BUGSPOTS_"
+
+// Environment variables for the global flags, which are not configuration values.
+const (
+	EnvConfig  = EnvPrefix + "CONFIG"
+	EnvProfile = EnvPrefix + "PROFILE"
+)
+
+// EnvVar returns the environment variable for a dotted key path:
+// coupling.changeSets.windowHours is BUGSPOTS_COUPLING_CHANGE_SETS_WINDOW_HOURS.
+func EnvVar(key string) string {
+	var b strings.Builder
+	b.WriteString(EnvPrefix)
+	for i, part := range strings.Split(key, ".") {
+		if i > 0 {
+			b.WriteByte('_')
+		}
+		runes := []rune(part)
+		for j, r := range runes {
+			if j > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[j-1]) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+type envKey struct {
+	path string
+	typ  reflect.Type
+}
+
+// envKeys maps the environment variable of every configuration value to its key path
+// and type. Nested objects are reached through their fields; arrays are values.
+func envKeys(t reflect.Type, prefix string, keys map[string]envKey) {
+	for name, field := range jsonFields(t) {
+		key := joinKey(prefix, name)
+		if field.Kind() == reflect.Struct {
+			envKeys(field, key, keys)
+			continue
+		}
+		keys[EnvVar(key)] = envKey{path: key, typ: field}
+	}
+}
+
+// envNode builds the layer of values set by BUGSPOTS_* variables in environ, a list of
+// NAME=value entries as returned by os.Environ. Empty variables are ignored. Values are
+// type checked like file values; unknown variables only warn.
+func envNode(environ []string) (*node, []Issue) {
+	keys := map[string]envKey{}
+	envKeys(reflect.TypeOf(Config{}), "", keys)
+
+	root := newObjectNode(Position{})
+	var issues issueList
+	var names []string
+	values := map[string]string{}
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(name, EnvPrefix) || value == "" {
+			continue
+		}
+		names = append(names, name)
+		values[name] = value
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == EnvConfig || name == EnvProfile {
+			continue
+		}
+		key, ok := keys[name]
+		if !ok {
+			issues.add(SeverityWarning, &node{env: name}, "", "unknown configuration variable%s", suggestEnvVar(name, keys))
+			continue
+		}
+		n, err := envValue(values[name], key.typ)
+		if err != nil {
+			issues.add(SeverityError, &node{env: name}, key.path, "%s", err)
+			continue
+		}
+		n.setEnv(name)
+
+		parent := root
+		parts := strings.Split(key.path, ".")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := parent.fields[part]
+			if !ok {
+				// Checks on a whole object, like the weight sum, point at the first
+				// variable that changed it
+				child = newObjectNode(Position{})
+				child.env = name
+				parent.setField(part, child)
+			}
+			parent = child
+		}
+		parent.setField(parts[len(parts)-1], n)
+	}
+
+	checkNode(root, reflect.TypeOf(Config{}), "", &issues)
+	return root, issues
+}
+
+// envValue converts a variable to a node of the field's type. Lists are comma
+// separated, or a JSON array when the items may contain commas; lists of objects are
+// JSON. Values that don't convert stay strings so the type check reports them.
+func envValue(value string, typ reflect.Type) (*node, error) {
+	switch typ.Kind() {
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(value), "[") || typ.Elem().Kind() != reflect.String {
+			n, err := parseJSON([]byte(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+			return n, nil
+		}
+		n := &node{kind: nodeArray}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				n.items = append(n.items, &node{kind: nodeString, value: item})
+			}
+		}
+		return n, nil
+	case reflect.Int, reflect.Float64:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return &node{kind: nodeNumber, value: f}, nil
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return &node{kind: nodeBool, value: b}, nil
+		}
+	}
+	return &node{kind: nodeString, value: value}, nil
+}
+
+// setEnv records the variable a tree was read from; positions within it are dropped.
+func (n *node) setEnv(name string) {
+	n.env, n.pos = name, Position{}
+	for _, child := range n.fields {
+		child.setEnv(name)
+	}
+	for _, item := range n.items {
+		item.setEnv(name)
+	}
+}
+
+// suggestEnvVar returns a "did you mean" hint for a misspelled variable, or "".
+func suggestEnvVar(name string, keys map[string]envKey) string {
+	best, bestDist := "", 4
+	for known := range keys {
+		if d := editDistance(name, known); d < bestDist || (d == bestDist && known < best) {
+			best, bestDist = known, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", best)
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvVar(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "scoring.weights.churn", expected: "BUGSPOTS_SCORING_WEIGHTS_CHURN"},
+		{key: "scoring.halfLifeDays", expected: "BUGSPOTS_SCORING_HALF_LIFE_DAYS"},
+		{key: "fileScoring.thresholds.high", expected: "BUGSPOTS_FILE_SCORING_THRESHOLDS_HIGH"},
+		{key: "coupling.changeSets.windowHours", expected: "BUGSPOTS_COUPLING_CHANGE_SETS_WINDOW_HOURS"},
+		{key: "filters.exclude", expected: "BUGSPOTS_FILTERS_EXCLUDE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := EnvVar(tt.key); got != tt.expected {
+				t.Errorf("EnvVar(%q) = %q, expected %q", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEnvKeys_Unique(t *testing.T) {
+	keys := map[string]envKey{}
+	envKeys(reflect.TypeOf(Config{}), "", keys)
+
+	var settings []Setting
+	collectSettings(defaultsNode(), reflect.TypeOf(Config{}), "", &settings)
+	if len(keys) != len(settings) {
+		t.Errorf("%d environment variables for %d configuration values", len(keys), len(settings))
+	}
+	for _, s := range settings {
+		if key, ok := keys[EnvVar(s.Key)]; !ok || key.path != s.Key {
+			t.Errorf("%s: no environment variable maps to it", s.Key)
+		}
+	}
+}
+
+func TestResolve_Environment(t *testing.T) {
+	doc := parseDocument("config.yaml", []byte("scoring:\n  halfLifeDays: 14\nfilters:\n  exclude: [\"**/vendor/**\"]\n"))
+	environ := []string{
+		"HOME=/root",
+		"BUGSPOTS_SCORING_HALF_LIFE_DAYS=21",
+		"BUGSPOTS_SCORING_WEIGHTS_CHURN=0.25",
+		"BUGSPOTS_SCORING_WEIGHTS_COMMIT=0.15",
+		"BUGSPOTS_COUPLING_DETECT_CLUSTERS=true",
+		"BUGSPOTS_COUPLING_CHANGE_SETS_MODE=author",
+		"BUGSPOTS_FILTERS_EXCLUDE=..., **/gen/** ,",
+		"BUGSPOTS_FILTERS_INCLUDE=",
+		`BUGSPOTS_BUGFIX_PATTERNS=["fix(es){1,2}"]`,
+		`BUGSPOTS_COUPLING_BOUNDARIES_MODULES=[{"name": "web", "patterns": ["web/**"]}]`,
+		"BUGSPOTS_CONFIG=other.yaml",
+	}
+
+	cfg, issues, err := doc.Resolve("", environ)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("Resolve() = %v, %v", issues, err)
+	}
+	if cfg.Scoring.HalfLifeDays != 21 {
+		t.Errorf("HalfLifeDays = %d, expected 21 from the environment over the file", cfg.Scoring.HalfLifeDays)
+	}
+	if cfg.Scoring.Weights.Churn != 0.25 || cfg.Scoring.Weights.Recency != 0.15 {
+		t.Errorf("Weights = %+v, expected churn from the environment and recency default", cfg.Scoring.Weights)
+	}
+	if !cfg.Coupling.DetectClusters || cfg.Coupling.ChangeSets.Mode != ChangeSetModeAuthor {
+		t.Errorf("Coupling = %+v, expected clusters and author mode", cfg.Coupling)
+	}
+	if want := []string{"**/vendor/**", "**/gen/**"}; !reflect.DeepEqual(cfg.Filters.Exclude, want) {
+		t.Errorf("Filters.Exclude = %v, expected %v", cfg.Filters.Exclude, want)
+	}
+	if len(cfg.Filters.Include) != 0 {
+		t.Errorf("Filters.Include = %v, expected an empty variable to be ignored", cfg.Filters.Include)
+	}
+	if want := []string{"fix(es){1,2}"}; !reflect.DeepEqual(cfg.Bugfix.Patterns, want) {
+		t.Errorf("Bugfix.Patterns = %v, expected %v", cfg.Bugfix.Patterns, want)
+	}
+	if len(cfg.Coupling.Boundaries.Modules) != 1 || cfg.Coupling.Boundaries.Modules[0].Name != "web" {
+		t.Errorf("Boundaries.Modules = %+v, expected the web module", cfg.Coupling.Boundaries.Modules)
+	}
+}
+
+func TestResolve_EnvironmentIssues(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		wantFile    string
+		wantSev     Severity
+		wantMessage string
+	}{
+		{
+			name:        "Not a number",
+			env:         "BUGSPOTS_BURST_WINDOW_DAYS=week",
+			wantFile:    "env BUGSPOTS_BURST_WINDOW_DAYS",
+			wantSev:     SeverityError,
+			wantMessage: `expected an integer, got string "week"`,
+		},
+		{
+			name:        "Out of range",
+			env:         "BUGSPOTS_COUPLING_MIN_JACCARD_THRESHOLD=2",
+			wantFile:    "env BUGSPOTS_COUPLING_MIN_JACCARD_THRESHOLD",
+			wantSev:     SeverityError,
+			wantMessage: "must be between 0 and 1, got 2",
+		},
+		{
+			name:        "Invalid JSON",
+			env:         "BUGSPOTS_COUPLING_BOUNDARIES_MODULES=web",
+			wantFile:    "env BUGSPOTS_COUPLING_BOUNDARIES_MODULES",
+			wantSev:     SeverityError,
+			wantMessage: "invalid JSON",
+		},
+		{
+			name:        "Weight sum",
+			env:         "BUGSPOTS_COMMIT_SCORING_WEIGHTS_SIZE=0.5",
+			wantFile:    "env BUGSPOTS_COMMIT_SCORING_WEIGHTS_SIZE",
+			wantSev:     SeverityWarning,
+			wantMessage: "weights sum to 1.15",
+		},
+		{
+			name:        "Unknown variable",
+			env:         "BUGSPOTS_SCORING_WEIGHT_CHURN=0.3",
+			wantFile:    "env BUGSPOTS_SCORING_WEIGHT_CHURN",
+			wantSev:     SeverityWarning,
+			wantMessage: "unknown configuration variable (did you mean BUGSPOTS_SCORING_WEIGHTS_CHURN?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, issues, err := NewDocument().Resolve("", []string{tt.env})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(issues) != 1 {
+				t.Fatalf("Resolve() issues = %v, expected one", issues)
+			}
+			issue := issues[0]
+			if issue.File != tt.wantFile || issue.Severity != tt.wantSev {
+				t.Errorf("issue = %s, expected a %s from %s", issue, tt.wantSev, tt.wantFile)
+			}
+			if !strings.Contains(issue.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, expected it to contain %q", issue.Message, tt.wantMessage)
+			}
+		})
+	}
+}
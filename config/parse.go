@@ -0,0 +1,535 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a configuration file syntax, selected by file extension.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// FormatFromPath returns the configuration format for a file name. Unknown extensions
+// are read as JSON.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// Position is a 1-based line and column in a configuration file. The zero value means
+// the location is unknown.
+type Position struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+type nodeKind int
+
+const (
+	nodeNull nodeKind = iota
+	nodeObject
+	nodeArray
+	nodeString
+	nodeNumber
+	nodeBool
+)
+
+// node is a parsed configuration value with its location. Values inside objects are
+// located at their key.
+type node struct {
+	kind   nodeKind
+	pos    Position
+	file   string   // File the value was read from; empty for defaults
+	env    string   // Environment variable the value was read from
+	keys   []string // Object keys in file order
+	fields map[string]*node
+	items  []*node
+	value  interface{} // string, float64 or bool for scalars
+}
+
+func newObjectNode(pos Position) *node {
+	return &node{kind: nodeObject, pos: pos, fields: map[string]*node{}}
+}
+
+func (n *node) setField(key string, child *node) {
+	if _, ok := n.fields[key]; !ok {
+		n.keys = append(n.keys, key)
+	}
+	n.fields[key] = child
+}
+
+func (n *node) removeField(key string) {
+	if _, ok := n.fields[key]; !ok {
+		return
+	}
+	delete(n.fields, key)
+	for i, k := range n.keys {
+		if k == key {
+			n.keys = append(n.keys[:i], n.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// describe names the node's type for error messages.
+func (n *node) describe() string {
+	switch n.kind {
+	case nodeObject:
+		return "an object"
+	case nodeArray:
+		return "an array"
+	case nodeString:
+		return fmt.Sprintf("string %q", n.value)
+	case nodeNumber:
+		return fmt.Sprintf("number %v", n.value)
+	case nodeBool:
+		return fmt.Sprintf("boolean %v", n.value)
+	default:
+		return "null"
+	}
+}
+
+// plain converts the node to values encoding/json can marshal.
+func (n *node) plain() interface{} {
+	switch n.kind {
+	case nodeObject:
+		m := make(map[string]interface{}, len(n.fields))
+		for k, v := range n.fields {
+			m[k] = v.plain()
+		}
+		return m
+	case nodeArray:
+		s := make([]interface{}, len(n.items))
+		for i, v := range n.items {
+			s[i] = v.plain()
+		}
+		return s
+	default:
+		return n.value
+	}
+}
+
+// lookup returns the node holding the value at a key path such as
+// coupling.boundaries.modules[0].name, or its deepest ancestor located in a file or
+// the environment.
+func (n *node) lookup(path string) *node {
+	located := n
+	cur := n
+	for _, part := range splitKeyPath(path) {
+		var next *node
+		if idx, err := strconv.Atoi(part); err == nil && cur.kind == nodeArray {
+			if idx >= 0 && idx < len(cur.items) {
+				next = cur.items[idx]
+			}
+		} else if cur.kind == nodeObject {
+			next = cur.fields[part]
+		}
+		if next == nil {
+			break
+		}
+		cur = next
+		if next.pos.Line > 0 || next.env != "" {
+			located = next
+		}
+	}
+	return located
+}
+
+// origin describes where the value came from: an environment variable,
+// file:line:column, or "default".
+func (n *node) origin() string {
+	switch {
+	case n.env != "":
+		return "env " + n.env
+	case n.file == "":
+		return "default"
+	case n.pos.Line == 0:
+		return n.file
+	case n.pos.Column == 0:
+		return fmt.Sprintf("%s:%d", n.file, n.pos.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", n.file, n.pos.Line, n.pos.Column)
+	}
+}
+
+// setFile records the file a parsed tree was read from.
+func (n *node) setFile(file string) {
+	n.file = file
+	for _, child := range n.fields {
+		child.setFile(file)
+	}
+	for _, item := range n.items {
+		item.setFile(file)
+	}
+}
+
+// splitKeyPath splits a.b[0].c into a, b, 0, c.
+func splitKeyPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	path = strings.ReplaceAll(path, "[", ".")
+	path = strings.ReplaceAll(path, "]", "")
+	return strings.Split(path, ".")
+}
+
+// syntaxError is a parse failure at a position in the file.
+type syntaxError struct {
+	pos Position
+	msg string
+}
+
+func (e *syntaxError) Error() string { return e.msg }
+
+// parseConfig parses data in the given format into a node tree. An empty document is
+// an empty object.
+func parseConfig(data []byte, format Format) (*node, error) {
+	switch format {
+	case FormatYAML:
+		return parseYAML(data)
+	case FormatTOML:
+		return parseTOML(data)
+	default:
+		return parseJSON(data)
+	}
+}
+
+// lineIndex converts byte offsets to positions.
+type lineIndex struct {
+	data   []byte
+	starts []int
+}
+
+func newLineIndex(data []byte) *lineIndex {
+	starts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{data: data, starts: starts}
+}
+
+func (l *lineIndex) position(offset int) Position {
+	offset = min(max(offset, 0), len(l.data))
+	line := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > offset }) - 1
+	return Position{Line: line + 1, Column: utf8.RuneCount(l.data[l.starts[line]:offset]) + 1}
+}
+
+// jsonParser builds a node tree from encoding/json tokens, recording where each
+// value starts.
+type jsonParser struct {
+	dec   *json.Decoder
+	lines *lineIndex
+}
+
+func parseJSON(data []byte) (*node, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return newObjectNode(Position{}), nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &jsonParser{dec: dec, lines: newLineIndex(data)}
+
+	root, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, &syntaxError{pos: p.next(), msg: "unexpected content after the configuration object"}
+	}
+	return root, nil
+}
+
+// next returns the position of the next token, skipping separators.
+func (p *jsonParser) next() Position {
+	data := p.lines.data
+	off := int(p.dec.InputOffset())
+	for off < len(data) && strings.IndexByte(" \t\r\n,:", data[off]) >= 0 {
+		off++
+	}
+	return p.lines.position(off)
+}
+
+func (p *jsonParser) token() (json.Token, error) {
+	tok, err := p.dec.Token()
+	if err == nil {
+		return tok, nil
+	}
+	var se *json.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		return nil, &syntaxError{pos: p.lines.position(int(se.Offset)), msg: se.Error()}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return nil, &syntaxError{pos: p.lines.position(len(p.lines.data)), msg: "unexpected end of file"}
+	default:
+		return nil, err
+	}
+}
+
+func (p *jsonParser) value() (*node, error) {
+	pos := p.next()
+	tok, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			n := &node{kind: nodeArray, pos: pos}
+			for p.dec.More() {
+				item, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, item)
+			}
+			_, err := p.token()
+			return n, err
+		}
+		n := newObjectNode(pos)
+		for p.dec.More() {
+			keyPos := p.next()
+			keyTok, err := p.token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyTok.(string)
+			if _, dup := n.fields[key]; dup {
+				return nil, &syntaxError{pos: keyPos, msg: fmt.Sprintf("duplicate key %q", key)}
+			}
+			child, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			child.pos = keyPos
+			n.setField(key, child)
+		}
+		_, err := p.token()
+		return n, err
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return nil, &syntaxError{pos: pos, msg: fmt.Sprintf("invalid number %s", t)}
+		}
+		return &node{kind: nodeNumber, pos: pos, value: f}, nil
+	case string:
+		return &node{kind: nodeString, pos: pos, value: t}, nil
+	case bool:
+		return &node{kind: nodeBool, pos: pos, value: t}, nil
+	default:
+		return &node{kind: nodeNull, pos: pos}, nil
+	}
+}
+
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+func parseYAML(data []byte) (*node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "yaml: ")
+		var pos Position
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			pos.Line, _ = strconv.Atoi(m[1])
+			msg = strings.TrimPrefix(err.Error(), m[0])
+		}
+		return nil, &syntaxError{pos: pos, msg: msg}
+	}
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return newObjectNode(Position{}), nil
+	}
+	return yamlNode(doc.Content[0])
+}
+
+func yamlNode(y *yaml.Node) (*node, error) {
+	pos := Position{Line: y.Line, Column: y.Column}
+	switch y.Kind {
+	case yaml.AliasNode:
+		n, err := yamlNode(y.Alias)
+		if err == nil {
+			n.pos = pos
+		}
+		return n, err
+	case yaml.MappingNode:
+		n := newObjectNode(pos)
+		for i := 0; i+1 < len(y.Content); i += 2 {
+			key, value := y.Content[i], y.Content[i+1]
+			child, err := yamlNode(value)
+			if err != nil {
+				return nil, err
+			}
+			child.pos = Position{Line: key.Line, Column: key.Column}
+			n.setField(key.Value, child)
+		}
+		return n, nil
+	case yaml.SequenceNode:
+		n := &node{kind: nodeArray, pos: pos}
+		for _, item := range y.Content {
+			child, err := yamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, child)
+		}
+		return n, nil
+	}
+
+	switch y.ShortTag() {
+	case "!!null":
+		return &node{kind: nodeNull, pos: pos}, nil
+	case "!!bool":
+		var b bool
+		if err := y.Decode(&b); err != nil {
+			return nil, &syntaxError{pos: pos, msg: err.Error()}
+		}
+		return &node{kind: nodeBool, pos: pos, value: b}, nil
+	case "!!int", "!!float":
+		var f float64
+		if err := y.Decode(&f); err != nil {
+			return nil, &syntaxError{pos: pos, msg: err.Error()}
+		}
+		return &node{kind: nodeNumber, pos: pos, value: f}, nil
+	default:
+		return &node{kind: nodeString, pos: pos, value: y.Value}, nil
+	}
+}
+
+func parseTOML(data []byte) (*node, error) {
+	var m map[string]interface{}
+	if _, err := toml.Decode(string(data), &m); err != nil {
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			return nil, &syntaxError{pos: Position{Line: pe.Position.Line, Column: pe.Position.Col}, msg: pe.Message}
+		}
+		return nil, &syntaxError{msg: err.Error()}
+	}
+	return valueNode(m, "", tomlKeyPositions(data)), nil
+}
+
+// valueNode builds a node tree from decoded TOML or JSON values, locating keys by
+// their dotted path in positions.
+func valueNode(v interface{}, path string, positions map[string]Position) *node {
+	pos := positions[path]
+	switch t := v.(type) {
+	case map[string]interface{}:
+		n := newObjectNode(pos)
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		// Keep file order where keys were located
+		sort.Slice(keys, func(i, j int) bool {
+			pi, pj := positions[joinKey(path, keys[i])], positions[joinKey(path, keys[j])]
+			if pi.Line != pj.Line {
+				return pi.Line < pj.Line
+			}
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			n.setField(k, valueNode(t[k], joinKey(path, k), positions))
+		}
+		return n
+	case []map[string]interface{}:
+		n := &node{kind: nodeArray, pos: pos}
+		for i, item := range t {
+			n.items = append(n.items, valueNode(item, fmt.Sprintf("%s[%d]", path, i), positions))
+		}
+		return n
+	case []interface{}:
+		n := &node{kind: nodeArray, pos: pos}
+		for i, item := range t {
+			n.items = append(n.items, valueNode(item, fmt.Sprintf("%s[%d]", path, i), positions))
+		}
+		return n
+	case int64:
+		return &node{kind: nodeNumber, pos: pos, value: float64(t)}
+	case float64:
+		return &node{kind: nodeNumber, pos: pos, value: t}
+	case bool:
+		return &node{kind: nodeBool, pos: pos, value: t}
+	case string:
+		return &node{kind: nodeString, pos: pos, value: t}
+	case nil:
+		return &node{kind: nodeNull, pos: pos}
+	default:
+		// Dates and times have no configuration meaning; keep them as text
+		return &node{kind: nodeString, pos: pos, value: fmt.Sprint(t)}
+	}
+}
+
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// tomlKeyPositions locates table headers and key/value lines, which the TOML decoder
+// does not report. Keys inside inline tables are located at their enclosing key.
+func tomlKeyPositions(data []byte) map[string]Position {
+	positions := map[string]Position{}
+	arrayCounts := map[string]int{}
+	table := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		column := utf8.RuneCountInString(line[:len(line)-len(strings.TrimLeft(line, " \t"))]) + 1
+		pos := Position{Line: i + 1, Column: column}
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "[["):
+			name := tomlDottedKey(strings.TrimSuffix(strings.TrimPrefix(trimmed[:strings.Index(trimmed, "]]")+2], "[["), "]]"))
+			table = fmt.Sprintf("%s[%d]", name, arrayCounts[name])
+			arrayCounts[name]++
+			if _, ok := positions[name]; !ok {
+				positions[name] = pos
+			}
+			positions[table] = pos
+		case strings.HasPrefix(trimmed, "[") && strings.Contains(trimmed, "]"):
+			table = tomlDottedKey(trimmed[1:strings.Index(trimmed, "]")])
+			positions[table] = pos
+		default:
+			if eq := strings.Index(trimmed, "="); eq > 0 {
+				key := joinKey(table, tomlDottedKey(trimmed[:eq]))
+				if _, ok := positions[key]; !ok {
+					positions[key] = pos
+				}
+			}
+		}
+	}
+	return positions
+}
+
+// tomlDottedKey normalizes a TOML key such as `a . "b"` to a.b.
+func tomlDottedKey(s string) string {
+	parts := strings.Split(s, ".")
+	for i, p := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(p), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected Format
+	}{
+		{path: ".bugspots.json", expected: FormatJSON},
+		{path: "conf/.bugspots.yaml", expected: FormatYAML},
+		{path: "bugspots.YML", expected: FormatYAML},
+		{path: "bugspots.toml", expected: FormatTOML},
+		{path: "bugspots.conf", expected: FormatJSON},
+		{path: "bugspots", expected: FormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := FormatFromPath(tt.path); got != tt.expected {
+				t.Errorf("FormatFromPath(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConfig_Positions(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   string
+		key    string
+		want   Position
+	}{
+		{
+			name:   "JSON nested key",
+			format: FormatJSON,
+			data:   "{\n  \"scoring\": {\n    \"halfLifeDays\": 14\n  }\n}\n",
+			key:    "scoring.halfLifeDays",
+			want:   Position{Line: 3, Column: 5},
+		},
+		{
+			name:   "JSON array element",
+			format: FormatJSON,
+			data:   "{\"filters\": {\"exclude\": [\"a\", \"b\"]}}",
+			key:    "filters.exclude[1]",
+			want:   Position{Line: 1, Column: 31},
+		},
+		{
+			name:   "YAML nested key",
+			format: FormatYAML,
+			data:   "scoring:\n  weights:\n    commit: 0.5\n",
+			key:    "scoring.weights.commit",
+			want:   Position{Line: 3, Column: 5},
+		},
+		{
+			name:   "YAML sequence of mappings",
+			format: FormatYAML,
+			data:   "coupling:\n  boundaries:\n    modules:\n      - name: api\n      - name: web\n",
+			key:    "coupling.boundaries.modules[1].name",
+			want:   Position{Line: 5, Column: 9},
+		},
+		{
+			name:   "TOML table key",
+			format: FormatTOML,
+			data:   "[scoring]\nhalfLifeDays = 14\n\n[scoring.weights]\ncommit = 0.5\n",
+			key:    "scoring.weights.commit",
+			want:   Position{Line: 5, Column: 1},
+		},
+		{
+			name:   "TOML array of tables",
+			format: FormatTOML,
+			data:   "[[coupling.boundaries.modules]]\nname = \"api\"\n\n[[coupling.boundaries.modules]]\nname = \"web\"\n",
+			key:    "coupling.boundaries.modules[1].name",
+			want:   Position{Line: 5, Column: 1},
+		},
+		{
+			name:   "Missing key falls back to ancestor",
+			format: FormatJSON,
+			data:   "{\n  \"scoring\": {}\n}",
+			key:    "scoring.weights.commit",
+			want:   Position{Line: 2, Column: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := parseConfig([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("parseConfig() error = %v", err)
+			}
+			if got := root.lookup(tt.key).pos; got != tt.want {
+				t.Errorf("lookup(%q) = %+v, expected %+v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfig_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   Format
+		data     string
+		wantLine int
+	}{
+		{name: "JSON trailing comma", format: FormatJSON, data: "{\n  \"a\": 1,\n}", wantLine: 2},
+		{name: "JSON duplicate key", format: FormatJSON, data: "{\n  \"a\": 1,\n  \"a\": 2\n}", wantLine: 3},
+		{name: "JSON trailing data", format: FormatJSON, data: "{}\n{}", wantLine: 2},
+		{name: "YAML bad indentation", format: FormatYAML, data: "scoring:\n  a: 1\n b: 2\n", wantLine: 2},
+		{name: "TOML missing value", format: FormatTOML, data: "[scoring]\nhalfLifeDays =\n", wantLine: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConfig([]byte(tt.data), tt.format)
+			var se *syntaxError
+			if !errors.As(err, &se) {
+				t.Fatalf("parseConfig() error = %v, expected a syntax error", err)
+			}
+			if se.pos.Line != tt.wantLine {
+				t.Errorf("syntax error line = %d, expected %d (%s)", se.pos.Line, tt.wantLine, se.msg)
+			}
+		})
+	}
+}
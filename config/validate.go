@@ -0,0 +1,420 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Schema is the JSON Schema describing configuration files.
+//
+//go:embed schema.json
+var Schema []byte
+
+// schemaKey may reference the JSON Schema from a configuration file.
+const schemaKey = "$schema"
+
+// weightSumTolerance is how far a weight group may sum from 1.0 before a warning.
+const weightSumTolerance = 0.02
+
+// commitTypePattern matches a Conventional Commits type.
+var commitTypePattern = regexp.MustCompile(`^[A-Za-z]+$`)
+
+// Severity classifies a configuration issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The file is rejected
+	SeverityWarning Severity = "warning" // The file is used, but the value is likely a mistake
+)
+
+// Issue is a problem found in a configuration file.
+type Issue struct {
+	File     string   `json:"file,omitempty"` // File, or "env NAME" for an environment variable
+	Position Position `json:"position"`
+	Severity Severity `json:"severity"`
+	Key      string   `json:"key,omitempty"` // Dotted key path, e.g. scoring.weights.commit
+	Message  string   `json:"message"`
+}
+
+// String formats the issue like a compiler diagnostic: file:line:column: severity: key: message.
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.File != "" {
+		b.WriteString(i.File)
+		if i.Position.Line > 0 {
+			fmt.Fprintf(&b, ":%d", i.Position.Line)
+			if i.Position.Column > 0 {
+				fmt.Fprintf(&b, ":%d", i.Position.Column)
+			}
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(string(i.Severity))
+	b.WriteString(": ")
+	if i.Key != "" {
+		b.WriteString(i.Key)
+		b.WriteString(": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// ValidationError rejects a configuration file with errors.
+type ValidationError struct {
+	File   string
+	Issues []Issue // Errors only
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Issues)+1)
+	lines = append(lines, fmt.Sprintf("invalid configuration (%d errors):", len(e.Issues)))
+	for _, issue := range e.Issues {
+		lines = append(lines, "  "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Errors returns the issues with error severity.
+func Errors(issues []Issue) []Issue {
+	var errs []Issue
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+// ValidateFile parses and validates the configuration file at path, with the files
+// it extends and the named profile applied but no environment variables. The returned
+// config is nil when there are errors; the error is only set when the file cannot be
+// read or the profile does not exist.
+func ValidateFile(path, profile string) (*Config, []Issue, error) {
+	doc, err := ReadDocument(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc.Resolve(profile, nil)
+}
+
+type issueList []Issue
+
+// add records an issue located at the node, which may be nil.
+func (l *issueList) add(severity Severity, at *node, key, format string, args ...interface{}) {
+	issue := Issue{Severity: severity, Key: key, Message: fmt.Sprintf(format, args...)}
+	issue.locate(at)
+	*l = append(*l, issue)
+}
+
+// locate points the issue at where the node's value came from.
+func (i *Issue) locate(at *node) {
+	switch {
+	case at == nil:
+	case at.env != "":
+		i.File, i.Position = "env "+at.env, Position{}
+	default:
+		i.File, i.Position = at.file, at.pos
+	}
+}
+
+func (l *issueList) errorf(key, format string, args ...interface{}) {
+	l.add(SeverityError, nil, key, format, args...)
+}
+
+func (l *issueList) warnf(key, format string, args ...interface{}) {
+	l.add(SeverityWarning, nil, key, format, args...)
+}
+
+// checkNode reports unknown keys and values of the wrong type, using the json tags of
+// the Config types as the key names.
+func checkNode(n *node, t reflect.Type, key string, issues *issueList) {
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.kind != nodeObject {
+			issues.add(SeverityError, n, key, "expected an object, got %s", n.describe())
+			return
+		}
+		fields := jsonFields(t)
+		for _, k := range n.keys {
+			child := n.fields[k]
+			field, ok := fields[k]
+			if !ok {
+				issues.add(SeverityError, child, joinKey(key, k), "unknown key%s", suggestKey(k, fields))
+				continue
+			}
+			checkNode(child, field, joinKey(key, k), issues)
+		}
+	case reflect.Slice:
+		if n.kind == nodeNull {
+			return
+		}
+		if n.kind != nodeArray {
+			issues.add(SeverityError, n, key, "expected an array, got %s", n.describe())
+			return
+		}
+		for i, item := range n.items {
+			checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i), issues)
+		}
+	case reflect.Ptr:
+		if n.kind != nodeNull {
+			checkNode(n, t.Elem(), key, issues)
+		}
+	case reflect.String:
+		if n.kind != nodeString {
+			issues.add(SeverityError, n, key, "expected a string, got %s", n.describe())
+		}
+	case reflect.Bool:
+		if n.kind != nodeBool {
+			issues.add(SeverityError, n, key, "expected true or false, got %s", n.describe())
+		}
+	case reflect.Int:
+		if n.kind != nodeNumber || n.value.(float64) != math.Trunc(n.value.(float64)) {
+			issues.add(SeverityError, n, key, "expected an integer, got %s", n.describe())
+		}
+	case reflect.Float64:
+		if n.kind != nodeNumber {
+			issues.add(SeverityError, n, key, "expected a number, got %s", n.describe())
+		}
+	}
+}
+
+// jsonFields maps the json names of a struct's fields to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// suggestKey returns a "did you mean" hint for a misspelled key, or "".
+func suggestKey(key string, fields map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// Validate checks value ranges and relationships. Issues carry key paths but no file
+// positions.
+func (c *Config) Validate() []Issue {
+	var issues issueList
+
+	if c.Scoring.HalfLifeDays < 1 {
+		issues.errorf("scoring.halfLifeDays", "must be at least 1, got %d", c.Scoring.HalfLifeDays)
+	}
+	w := c.Scoring.Weights
+	checkWeights(&issues, "scoring.weights", []namedWeight{
+		{"commit", w.Commit}, {"churn", w.Churn}, {"recency", w.Recency}, {"burst", w.Burst},
+		{"ownership", w.Ownership}, {"bugfix", w.Bugfix}, {"complexity", w.Complexity}, {"coupling", w.Coupling},
+	})
+	checkThresholds(&issues, "fileScoring.thresholds", c.FileScoring.Thresholds)
+	for i, o := range c.FileScoring.Overrides {
+		checkOverride(&issues, fmt.Sprintf("fileScoring.overrides[%d]", i), o)
+	}
+
+	if c.Burst.WindowDays < 1 {
+		issues.errorf("burst.windowDays", "must be at least 1, got %d", c.Burst.WindowDays)
+	}
+	for i, p := range c.Bugfix.Patterns {
+		checkRegexp(&issues, fmt.Sprintf("bugfix.patterns[%d]", i), p)
+	}
+	checkCommitTypes(&issues, "conventional.bugfixTypes", c.Conventional.BugfixTypes)
+	checkCommitTypes(&issues, "conventional.noiseTypes", c.Conventional.NoiseTypes)
+	for i, t := range c.Conventional.NoiseTypes {
+		for _, bugfixType := range c.Conventional.BugfixTypes {
+			if strings.EqualFold(t, bugfixType) {
+				issues.errorf(fmt.Sprintf("conventional.noiseTypes[%d]", i), "%q is also a bugfix type; its commits would be dropped before counting", t)
+			}
+		}
+	}
+
+	cw := c.CommitScoring.Weights
+	checkWeights(&issues, "commitScoring.weights", []namedWeight{
+		{"diffusion", cw.Diffusion}, {"size", cw.Size}, {"entropy", cw.Entropy}, {"breaking", cw.Breaking},
+	})
+	checkThresholds(&issues, "commitScoring.thresholds", c.CommitScoring.Thresholds)
+
+	fw := c.FunctionScoring.Weights
+	checkWeights(&issues, "functionScoring.weights", []namedWeight{
+		{"commit", fw.Commit}, {"churn", fw.Churn}, {"bugfix", fw.Bugfix}, {"recency", fw.Recency},
+	})
+	checkThresholds(&issues, "functionScoring.thresholds", c.FunctionScoring.Thresholds)
+
+	rs := c.RangeScoring
+	if rs.MinCommits < 1 {
+		issues.errorf("rangeScoring.minCommits", "must be at least 1, got %d", rs.MinCommits)
+	}
+	checkWeights(&issues, "rangeScoring.weights", []namedWeight{
+		{"commit", rs.Weights.Commit}, {"churn", rs.Weights.Churn}, {"bugfix", rs.Weights.Bugfix}, {"recency", rs.Weights.Recency},
+	})
+	checkThresholds(&issues, "rangeScoring.thresholds", rs.Thresholds)
+
+	cp := c.Coupling
+	if cp.MinCoCommits < 1 {
+		issues.errorf("coupling.minCoCommits", "must be at least 1, got %d", cp.MinCoCommits)
+	}
+	if cp.MinJaccardThreshold < 0 || cp.MinJaccardThreshold > 1 {
+		issues.errorf("coupling.minJaccardThreshold", "must be between 0 and 1, got %g", cp.MinJaccardThreshold)
+	}
+	if cp.MaxFilesPerCommit < 2 {
+		issues.errorf("coupling.maxFilesPerCommit", "must be at least 2, got %d", cp.MaxFilesPerCommit)
+	}
+	if cp.TopPairs < 1 {
+		issues.errorf("coupling.topPairs", "must be at least 1, got %d", cp.TopPairs)
+	}
+	if cp.Boundaries.Depth < 1 {
+		issues.errorf("coupling.boundaries.depth", "must be at least 1, got %d", cp.Boundaries.Depth)
+	}
+	for i, m := range cp.Boundaries.Modules {
+		key := fmt.Sprintf("coupling.boundaries.modules[%d]", i)
+		if strings.TrimSpace(m.Name) == "" {
+			issues.errorf(key+".name", "module name is required")
+		}
+		for j, p := range m.Patterns {
+			checkGlob(&issues, fmt.Sprintf("%s.patterns[%d]", key, j), p)
+		}
+	}
+	switch cp.ChangeSets.Mode {
+	case ChangeSetModeCommit, ChangeSetModeAuthor, ChangeSetModeIssue, ChangeSetModeMerge:
+	default:
+		issues.errorf("coupling.changeSets.mode", "must be one of commit, author, issue, merge; got %q", cp.ChangeSets.Mode)
+	}
+	if cp.ChangeSets.WindowHours < 1 {
+		issues.errorf("coupling.changeSets.windowHours", "must be at least 1, got %d", cp.ChangeSets.WindowHours)
+	}
+	checkRegexp(&issues, "coupling.changeSets.issuePattern", cp.ChangeSets.IssuePattern)
+
+	for i, p := range c.Filters.Include {
+		checkGlob(&issues, fmt.Sprintf("filters.include[%d]", i), p)
+	}
+	for i, p := range c.Filters.Exclude {
+		checkGlob(&issues, fmt.Sprintf("filters.exclude[%d]", i), p)
+	}
+	return issues
+}
+
+type namedWeight struct {
+	name  string
+	value float64
+}
+
+// checkWeights rejects negative weights and warns when a weight group does not sum to
+// about 1.0, since risk thresholds assume scores in [0, 1].
+func checkWeights(issues *issueList, key string, weights []namedWeight) {
+	sum := 0.0
+	for _, w := range weights {
+		if w.value < 0 {
+			issues.errorf(key+"."+w.name, "must not be negative, got %g", w.value)
+		}
+		sum += w.value
+	}
+	if math.Abs(sum-1) > weightSumTolerance {
+		issues.warnf(key, "weights sum to %.2f, expected about 1.0", sum)
+	}
+}
+
+func checkOverride(issues *issueList, key string, o PathOverride) {
+	if o.Path == "" {
+		issues.errorf(key+".path", "path is required")
+	} else {
+		checkGlob(issues, key+".path", o.Path)
+	}
+	if o.ScoreFactor != nil && *o.ScoreFactor < 0 {
+		issues.errorf(key+".scoreFactor", "must not be negative, got %g", *o.ScoreFactor)
+	}
+	if o.HalfLifeDays != nil && *o.HalfLifeDays < 1 {
+		issues.errorf(key+".halfLifeDays", "must be at least 1, got %d", *o.HalfLifeDays)
+	}
+	if o.Weights != nil {
+		w := o.Weights
+		for _, f := range []struct {
+			name  string
+			value *float64
+		}{
+			{"commit", w.Commit}, {"churn", w.Churn}, {"recency", w.Recency}, {"burst", w.Burst},
+			{"ownership", w.Ownership}, {"bugfix", w.Bugfix}, {"complexity", w.Complexity}, {"coupling", w.Coupling},
+		} {
+			if f.value != nil && *f.value < 0 {
+				issues.errorf(key+".weights."+f.name, "must not be negative, got %g", *f.value)
+			}
+		}
+	}
+	switch o.RiskLevel {
+	case "", RiskLevelHigh, RiskLevelMedium, RiskLevelLow:
+	default:
+		issues.errorf(key+".riskLevel", "must be one of high, medium, low; got %q", o.RiskLevel)
+	}
+	if o.ScoreFactor == nil && o.Weights == nil && o.HalfLifeDays == nil && o.RiskLevel == "" {
+		issues.warnf(key, "override has no effect; set scoreFactor, weights, halfLifeDays or riskLevel")
+	}
+}
+
+func checkCommitTypes(issues *issueList, key string, types []string) {
+	for i, t := range types {
+		if !commitTypePattern.MatchString(t) {
+			issues.errorf(fmt.Sprintf("%s[%d]", key, i), "must be a commit type of letters like \"fix\", got %q", t)
+		}
+	}
+}
+
+func checkThresholds(issues *issueList, key string, t RiskThresholds) {
+	if t.High < 0 || t.High > 1 {
+		issues.errorf(key+".high", "must be between 0 and 1, got %g", t.High)
+	}
+	if t.Medium < 0 || t.Medium > 1 {
+		issues.errorf(key+".medium", "must be between 0 and 1, got %g", t.Medium)
+	}
+	if t.High < t.Medium {
+		issues.errorf(key, "high (%g) must not be below medium (%g)", t.High, t.Medium)
+	}
+}
+
+func checkRegexp(issues *issueList, key, pattern string) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		issues.errorf(key, "invalid regular expression: %v", err)
+	}
+}
+
+func checkGlob(issues *issueList, key, pattern string) {
+	if !doublestar.ValidatePattern(pattern) {
+		issues.errorf(key, "invalid glob pattern %q", pattern)
+	}
+}
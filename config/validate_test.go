@@ -0,0 +1,392 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDefaultConfig_Validate(t *testing.T) {
+	if issues := DefaultConfig().Validate(); len(issues) != 0 {
+		t.Errorf("DefaultConfig().Validate() = %v, expected no issues", issues)
+	}
+}
+
+func TestValidateData_Issues(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		data        string
+		wantKey     string
+		wantMessage string
+		wantLine    int
+		wantSev     Severity
+	}{
+		{
+			name:        "Unknown key with suggestion",
+			format:      FormatYAML,
+			data:        "scoring:\n  halfLifeDay: 14\n",
+			wantKey:     "scoring.halfLifeDay",
+			wantMessage: `unknown key (did you mean "halfLifeDays"?)`,
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Wrong type",
+			format:      FormatJSON,
+			data:        "{\n  \"burst\": {\"windowDays\": \"7\"}\n}",
+			wantKey:     "burst.windowDays",
+			wantMessage: `expected an integer, got string "7"`,
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Fractional integer",
+			format:      FormatTOML,
+			data:        "[coupling]\ntopPairs = 2.5\n",
+			wantKey:     "coupling.topPairs",
+			wantMessage: "expected an integer",
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Negative weight",
+			format:      FormatYAML,
+			data:        "scoring:\n  weights:\n    churn: -0.2\n",
+			wantKey:     "scoring.weights.churn",
+			wantMessage: "must not be negative, got -0.2",
+			wantLine:    3,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "High below medium",
+			format:      FormatJSON,
+			data:        "{\"fileScoring\": {\"thresholds\": {\"high\": 0.3, \"medium\": 0.5}}}",
+			wantKey:     "fileScoring.thresholds",
+			wantMessage: "high (0.3) must not be below medium (0.5)",
+			wantLine:    1,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Range minimum commits",
+			format:      FormatYAML,
+			data:        "rangeScoring:\n  minCommits: 0\n",
+			wantKey:     "rangeScoring.minCommits",
+			wantMessage: "must be at least 1, got 0",
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Threshold out of range",
+			format:      FormatJSON,
+			data:        "{\"commitScoring\": {\"thresholds\": {\"high\": 1.5}}}",
+			wantKey:     "commitScoring.thresholds.high",
+			wantMessage: "must be between 0 and 1, got 1.5",
+			wantLine:    1,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Zero half-life",
+			format:      FormatTOML,
+			data:        "[scoring]\nhalfLifeDays = 0\n",
+			wantKey:     "scoring.halfLifeDays",
+			wantMessage: "must be at least 1, got 0",
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Invalid regular expression",
+			format:      FormatYAML,
+			data:        "bugfix:\n  patterns:\n    - fix\n    - \"(bug\"\n",
+			wantKey:     "bugfix.patterns[1]",
+			wantMessage: "invalid regular expression",
+			wantLine:    4,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Invalid glob",
+			format:      FormatJSON,
+			data:        "{\"filters\": {\"exclude\": [\"vendor/[\"]}}",
+			wantKey:     "filters.exclude[0]",
+			wantMessage: `invalid glob pattern "vendor/["`,
+			wantLine:    1,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Unknown change set mode",
+			format:      FormatYAML,
+			data:        "coupling:\n  changeSets:\n    mode: sprint\n",
+			wantKey:     "coupling.changeSets.mode",
+			wantMessage: `must be one of commit, author, issue, merge; got "sprint"`,
+			wantLine:    3,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Module without name",
+			format:      FormatTOML,
+			data:        "[[coupling.boundaries.modules]]\npatterns = [\"api/**\"]\n",
+			wantKey:     "coupling.boundaries.modules[0].name",
+			wantMessage: "module name is required",
+			wantLine:    1,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Weights do not sum to one",
+			format:      FormatYAML,
+			data:        "commitScoring:\n  weights:\n    diffusion: 0.5\n",
+			wantKey:     "commitScoring.weights",
+			wantMessage: "weights sum to 1.15, expected about 1.0",
+			wantLine:    2,
+			wantSev:     SeverityWarning,
+		},
+		{
+			name:        "Override without path",
+			format:      FormatYAML,
+			data:        "fileScoring:\n  overrides:\n    - scoreFactor: 0.5\n",
+			wantKey:     "fileScoring.overrides[0].path",
+			wantMessage: "path is required",
+			wantLine:    3,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Override negative weight",
+			format:      FormatYAML,
+			data:        "fileScoring:\n  overrides:\n    - path: vendor/**\n      weights:\n        churn: -1\n",
+			wantKey:     "fileScoring.overrides[0].weights.churn",
+			wantMessage: "must not be negative, got -1",
+			wantLine:    5,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Override unknown risk level",
+			format:      FormatTOML,
+			data:        "[[fileScoring.overrides]]\npath = \"gen/**\"\nriskLevel = \"none\"\n",
+			wantKey:     "fileScoring.overrides[0].riskLevel",
+			wantMessage: "must be one of high, medium, low",
+			wantLine:    3,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Override without effect",
+			format:      FormatJSON,
+			data:        "{\"fileScoring\": {\"overrides\": [\n  {\"path\": \"docs/**\"}\n]}}",
+			wantKey:     "fileScoring.overrides[0]",
+			wantMessage: "override has no effect",
+			wantLine:    2,
+			wantSev:     SeverityWarning,
+		},
+		{
+			name:        "Conventional type with scope",
+			format:      FormatYAML,
+			data:        "conventional:\n  bugfixTypes: [\"fix(api)\"]\n",
+			wantKey:     "conventional.bugfixTypes[0]",
+			wantMessage: "must be a commit type",
+			wantLine:    2,
+			wantSev:     SeverityError,
+		},
+		{
+			name:        "Noise type is a bugfix type",
+			format:      FormatYAML,
+			data:        "conventional:\n  noiseTypes:\n    - fix\n",
+			wantKey:     "conventional.noiseTypes[0]",
+			wantMessage: "also a bugfix type",
+			wantLine:    3,
+			wantSev:     SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, issues := validateData([]byte(tt.data), tt.format)
+			var found *Issue
+			for i := range issues {
+				if issues[i].Key == tt.wantKey {
+					found = &issues[i]
+					break
+				}
+			}
+			if found == nil {
+				t.Fatalf("no issue for %s in %v", tt.wantKey, issues)
+			}
+			if found.Severity != tt.wantSev {
+				t.Errorf("Severity = %q, expected %q", found.Severity, tt.wantSev)
+			}
+			if !strings.Contains(found.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, expected it to contain %q", found.Message, tt.wantMessage)
+			}
+			if found.Position.Line != tt.wantLine {
+				t.Errorf("Position.Line = %d, expected %d", found.Position.Line, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestValidateData_MergesOverDefaults(t *testing.T) {
+	data := "$schema: ./schema.json\nscoring:\n  halfLifeDays: 14\nfilters:\n  exclude: [\"vendor/**\"]\n"
+	cfg, issues := validateData([]byte(data), FormatYAML)
+	if len(issues) != 0 {
+		t.Fatalf("validateData() issues = %v, expected none", issues)
+	}
+	if cfg.Scoring.HalfLifeDays != 14 {
+		t.Errorf("HalfLifeDays = %d, expected 14", cfg.Scoring.HalfLifeDays)
+	}
+	if cfg.Scoring.Weights.Commit != 0.20 {
+		t.Errorf("Weights.Commit = %f, expected default 0.20", cfg.Scoring.Weights.Commit)
+	}
+	if !reflect.DeepEqual(cfg.Filters.Exclude, []string{"vendor/**"}) {
+		t.Errorf("Filters.Exclude = %v, expected [vendor/**]", cfg.Filters.Exclude)
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{
+		File:     ".bugspots.yaml",
+		Position: Position{Line: 3, Column: 5},
+		Severity: SeverityError,
+		Key:      "scoring.weights.churn",
+		Message:  "must not be negative, got -0.2",
+	}
+	expected := ".bugspots.yaml:3:5: error: scoring.weights.churn: must not be negative, got -0.2"
+	if got := issue.String(); got != expected {
+		t.Errorf("String() = %q, expected %q", got, expected)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		cfg, issues, err := Load(write("a.yaml", "burst:\n  windowDays: 3\n"), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("Load() issues = %v, expected none", issues)
+		}
+		if cfg.Burst.WindowDays != 3 {
+			t.Errorf("WindowDays = %d, expected 3", cfg.Burst.WindowDays)
+		}
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		cfg, _, err := Load(write("b.toml", "[coupling]\ndetectClusters = true\n"), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if !cfg.Coupling.DetectClusters {
+			t.Error("DetectClusters = false, expected true")
+		}
+	})
+
+	t.Run("Warnings", func(t *testing.T) {
+		cfg, issues, err := Load(write("c.json", `{"scoring": {"weights": {"commit": 0.5}}}`), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg == nil || len(issues) != 1 || issues[0].Severity != SeverityWarning {
+			t.Errorf("Load() = %v, %v, expected config with one warning", cfg, issues)
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		cfg, _, err := Load(filepath.Join(dir, "missing.json"), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if !reflect.DeepEqual(cfg, DefaultConfig()) {
+			t.Error("Load() of a missing file should return the defaults")
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := write("d.yaml", "scoring:\n  halfLifeDays: 0\n  weight: {}\n")
+		_, _, err := Load(path, "")
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("Load() error = %v, expected *ValidationError", err)
+		}
+		if len(verr.Issues) != 2 {
+			t.Errorf("ValidationError.Issues = %v, expected 2 errors", verr.Issues)
+		}
+		if !strings.Contains(err.Error(), path+":3:3: error: scoring.weight: unknown key") {
+			t.Errorf("Error() = %q, expected the unknown key with its position", err.Error())
+		}
+	})
+}
+
+// TestSchema_MatchesConfig keeps schema.json in sync with the Config json tags.
+func TestSchema_MatchesConfig(t *testing.T) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("schema.json is not valid JSON: %v", err)
+	}
+	defs, _ := schema["$defs"].(map[string]interface{})
+
+	var compare func(path string, s map[string]interface{}, typ reflect.Type)
+	compare = func(path string, s map[string]interface{}, typ reflect.Type) {
+		if ref, ok := s["$ref"].(string); ok {
+			s, _ = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+		}
+		switch typ.Kind() {
+		case reflect.Struct:
+			// The top-level settings are closed by unevaluatedProperties where they are used
+			if path != "" && s["additionalProperties"] != false {
+				t.Errorf("%s: additionalProperties should be false", path)
+			}
+			props, _ := s["properties"].(map[string]interface{})
+			fields := jsonFields(typ)
+			var schemaKeys, fieldKeys []string
+			for k := range props {
+				schemaKeys = append(schemaKeys, k)
+			}
+			for k := range fields {
+				fieldKeys = append(fieldKeys, k)
+			}
+			sort.Strings(schemaKeys)
+			sort.Strings(fieldKeys)
+			if !reflect.DeepEqual(schemaKeys, fieldKeys) {
+				t.Errorf("%s: schema properties %v, Config fields %v", path, schemaKeys, fieldKeys)
+				return
+			}
+			for k, f := range fields {
+				sub, _ := props[k].(map[string]interface{})
+				compare(joinKey(path, k), sub, f)
+			}
+		case reflect.Slice:
+			items, _ := s["items"].(map[string]interface{})
+			compare(path+"[]", items, typ.Elem())
+		case reflect.Ptr:
+			compare(path, s, typ.Elem())
+		}
+	}
+	compare("", schema, reflect.TypeOf(Config{}))
+
+	props, _ := schema["properties"].(map[string]interface{})
+	for _, key := range []string{schemaKey, extendsKey, profilesKey} {
+		if _, ok := props[key]; !ok {
+			t.Errorf("schema is missing the top-level %q property", key)
+		}
+	}
+	if schema["unevaluatedProperties"] != false {
+		t.Error("top level: unevaluatedProperties should be false")
+	}
+}
+
+// validateData resolves an in-memory file in the given format.
+func validateData(data []byte, format Format) (*Config, []Issue) {
+	cfg, issues, _ := parseDocument("config."+string(format), data).Resolve("", nil)
+	return cfg, issues
+}
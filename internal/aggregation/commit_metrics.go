@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/masmgr/bugspots-go/internal/conventional"
 	"github.com/masmgr/bugspots-go/internal/entropy"
 	"github.com/masmgr/bugspots-go/internal/git"
 )
@@ -14,12 +15,18 @@ type CommitMetrics struct {
 	When           time.Time
 	Author         git.AuthorInfo
 	Message        string
-	FileCount      int     // NF: Number of files
-	DirectoryCount int     // ND: Number of directories
-	SubsystemCount int     // NS: Number of subsystems (top-level directories)
-	LinesAdded     int     // LA
-	LinesDeleted   int     // LD
-	ChangeEntropy  float64 // Normalized Shannon entropy
+	FileCount      int      // NF: Number of files
+	DirectoryCount int      // ND: Number of directories
+	SubsystemCount int      // NS: Number of subsystems (top-level directories)
+	LinesAdded     int      // LA
+	LinesDeleted   int      // LD
+	ChangeEntropy  float64  // Normalized Shannon entropy
+	Paths          []string // Paths of the changed files
+
+	// Set when the message is a Conventional Commit
+	Type     string // e.g. "fix"
+	Scope    string
+	Breaking bool
 }
 
 // TotalChurn returns the total lines changed (added + deleted).
@@ -52,8 +59,11 @@ func (c *CommitMetricsCalculator) Calculate(changeSet git.CommitChangeSet) Commi
 	// Size metrics
 	linesAdded := 0
 	linesDeleted := 0
+	paths := make([]string, 0, len(changes))
 
 	for _, change := range changes {
+		paths = append(paths, change.Path)
+
 		// Accumulate size metrics
 		linesAdded += change.LinesAdded
 		linesDeleted += change.LinesDeleted
@@ -76,6 +86,8 @@ func (c *CommitMetricsCalculator) Calculate(changeSet git.CommitChangeSet) Commi
 		subsystemCount = 1
 	}
 
+	cc, _ := conventional.ParseCommit(commit)
+
 	return CommitMetrics{
 		SHA:            commit.SHA,
 		When:           commit.When,
@@ -87,6 +99,10 @@ func (c *CommitMetricsCalculator) Calculate(changeSet git.CommitChangeSet) Commi
 		LinesAdded:     linesAdded,
 		LinesDeleted:   linesDeleted,
 		ChangeEntropy:  entropyValue,
+		Paths:          paths,
+		Type:           cc.Type,
+		Scope:          cc.Scope,
+		Breaking:       cc.Breaking,
 	}
 }
 
@@ -121,16 +137,49 @@ func extractPathComponents(path string) (directory, subsystem string) {
 	directory = normalizedPath[:lastSlash]
 
 	// Subsystem is the first directory component
-	firstSlash := strings.Index(normalizedPath, "/")
-	if firstSlash > 0 {
-		subsystem = normalizedPath[:firstSlash]
-	} else {
-		subsystem = directory
-	}
+	subsystem = Subsystem(normalizedPath, 1)
 
 	return directory, subsystem
 }
 
+// Subsystem returns the first depth directory components of a file path
+// (e.g. depth 1: "src/api/h.go" -> "src", depth 2: -> "src/api").
+// Files with fewer directory levels map to their full directory; files in the
+// repository root map to "". A depth below 1 is treated as 1.
+func Subsystem(path string, depth int) string {
+	if depth < 1 {
+		depth = 1
+	}
+
+	normalizedPath := path
+	if strings.Contains(path, "\\") {
+		normalizedPath = strings.ReplaceAll(path, "\\", "/")
+	}
+
+	lastSlash := strings.LastIndex(normalizedPath, "/")
+	if lastSlash <= 0 {
+		return ""
+	}
+	directory := normalizedPath[:lastSlash]
+
+	end := 0
+	for i := 0; i < depth; i++ {
+		next := strings.Index(directory[end:], "/")
+		if next < 0 {
+			return directory
+		}
+		end += next
+		if i < depth-1 {
+			end++
+		}
+	}
+	if end == 0 {
+		// Leading separator: no named first component
+		return directory
+	}
+	return directory[:end]
+}
+
 // truncateMessage truncates commit message to first line, max 100 chars.
 func truncateMessage(message string) string {
 	if message == "" {
@@ -3,6 +3,8 @@ package aggregation
 import (
 	"strings"
 	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/git"
 )
 
 func TestCommitMetrics_TotalChurn(t *testing.T) {
@@ -79,3 +81,59 @@ func TestTruncateMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSubsystem(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		depth    int
+		expected string
+	}{
+		{name: "Root file", path: "main.go", depth: 1, expected: ""},
+		{name: "Depth 1", path: "src/api/handler.go", depth: 1, expected: "src"},
+		{name: "Depth 2", path: "src/api/handler.go", depth: 2, expected: "src/api"},
+		{name: "Depth exceeds levels", path: "src/api/handler.go", depth: 5, expected: "src/api"},
+		{name: "Depth below 1", path: "src/api/handler.go", depth: 0, expected: "src"},
+		{name: "Windows separators", path: "src\\api\\handler.go", depth: 2, expected: "src/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Subsystem(tt.path, tt.depth)
+			if result != tt.expected {
+				t.Errorf("Subsystem(%q, %d) = %q, expected %q", tt.path, tt.depth, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommitMetricsCalculator_Calculate_Conventional(t *testing.T) {
+	tests := []struct {
+		name         string
+		subject      string
+		body         string
+		wantType     string
+		wantScope    string
+		wantBreaking bool
+	}{
+		{name: "Type and scope", subject: "fix(parser): handle tabs", wantType: "fix", wantScope: "parser"},
+		{name: "Breaking marker", subject: "feat!: drop v1 API", wantType: "feat", wantBreaking: true},
+		{name: "Breaking footer", subject: "refactor(db): rename tables", body: "BREAKING CHANGE: migrations required",
+			wantType: "refactor", wantScope: "db", wantBreaking: true},
+		{name: "Free-form message", subject: "Fix crash on startup"},
+	}
+
+	calculator := NewCommitMetricsCalculator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := calculator.Calculate(git.CommitChangeSet{
+				Commit:  git.CommitInfo{SHA: "abc", Message: tt.subject, Body: tt.body},
+				Changes: []git.FileChange{{Path: "src/a.go", LinesAdded: 1}},
+			})
+			if cm.Type != tt.wantType || cm.Scope != tt.wantScope || cm.Breaking != tt.wantBreaking {
+				t.Errorf("Type, Scope, Breaking = %q, %q, %v, expected %q, %q, %v",
+					cm.Type, cm.Scope, cm.Breaking, tt.wantType, tt.wantScope, tt.wantBreaking)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ type FileMetrics struct {
 	BurstScore              float64
 	BugfixCount             int      // Number of bugfix commits touching this file
 	FileSize                int      // Number of lines in the file (0 if not measured)
+	CouplingPartners        int      // Number of files this file is coupled to (0 if not measured)
+	CouplingDegree          float64  // Sum of Jaccard coefficients with coupled files (0 if not measured)
 	cachedOwnershipRatio    *float64 // Cached ownership ratio to avoid repeated calculation
 }
 
@@ -96,7 +98,7 @@ func (f *FileMetrics) AddCommit(commit git.CommitInfo, change git.FileChange, co
 type FileMetricsAggregator struct {
 	metrics            map[string]*FileMetrics
 	collectCommitTimes bool // Whether to collect commit times for burst calculation
-	pathAliases        map[string]string
+	pathAliases        *PathAliases
 }
 
 // NewFileMetricsAggregator creates a new aggregator.
@@ -105,7 +107,7 @@ func NewFileMetricsAggregator() *FileMetricsAggregator {
 	return &FileMetricsAggregator{
 		metrics:            make(map[string]*FileMetrics),
 		collectCommitTimes: true,
-		pathAliases:        make(map[string]string),
+		pathAliases:        NewPathAliases(),
 	}
 }
 
@@ -114,7 +116,7 @@ func NewFileMetricsAggregatorWithOptions(collectCommitTimes bool) *FileMetricsAg
 	return &FileMetricsAggregator{
 		metrics:            make(map[string]*FileMetrics),
 		collectCommitTimes: collectCommitTimes,
-		pathAliases:        make(map[string]string),
+		pathAliases:        NewPathAliases(),
 	}
 }
 
@@ -153,26 +155,12 @@ func (a *FileMetricsAggregator) processChangeSet(cs git.CommitChangeSet) {
 }
 
 func (a *FileMetricsAggregator) canonicalPath(path string) string {
-	if path == "" {
-		return ""
-	}
-
-	// Follow alias chain with a small cap to avoid loops.
-	for i := 0; i < 16; i++ {
-		next, ok := a.pathAliases[path]
-		if !ok || next == "" || next == path {
-			return path
-		}
-		path = next
-	}
-
-	return path
+	return a.pathAliases.Canonical(path)
 }
 
 func (a *FileMetricsAggregator) applyRename(oldPath, newPath string) {
-	oldCanon := a.canonicalPath(oldPath)
-	newCanon := a.canonicalPath(newPath)
-	if oldCanon == "" || newCanon == "" || oldCanon == newCanon {
+	oldCanon, newCanon, ok := a.pathAliases.Rename(oldPath, newPath)
+	if !ok {
 		return
 	}
 
@@ -186,12 +174,6 @@ func (a *FileMetricsAggregator) applyRename(oldPath, newPath string) {
 		a.mergeMetrics(a.metrics[newCanon], oldMetrics)
 		delete(a.metrics, oldCanon)
 	}
-
-	// Alias old -> new so older commits contribute to the canonical path.
-	a.pathAliases[oldCanon] = newCanon
-	if oldPath != oldCanon {
-		a.pathAliases[oldPath] = newCanon
-	}
 }
 
 // mergeMetrics merges source metrics into target.
@@ -220,6 +202,12 @@ func (a *FileMetricsAggregator) mergeMetrics(target, source *FileMetrics) {
 		target.FileSize = source.FileSize
 	}
 
+	// Coupling degree is computed on canonical paths; keep whichever side has it
+	if source.CouplingDegree > target.CouplingDegree {
+		target.CouplingDegree = source.CouplingDegree
+		target.CouplingPartners = source.CouplingPartners
+	}
+
 	// Invalidate cache due to merged contributor counts / commit count changes.
 	target.cachedOwnershipRatio = nil
 }
@@ -0,0 +1,207 @@
+package aggregation
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+	"github.com/masmgr/bugspots-go/internal/gofunc"
+)
+
+// FunctionMetrics holds the change history of one Go function or method.
+type FunctionMetrics struct {
+	Path           string // Canonical (post-rename) path of the file
+	Name           string // "Name" for functions, "Type.Name" for methods
+	StartLine      int    // Position at the analyzed revision
+	EndLine        int
+	CommitCount    int
+	AddedLines     int
+	DeletedLines   int
+	BugfixCount    int
+	LastModifiedAt time.Time
+}
+
+// ChurnTotal returns the total lines changed in the function.
+func (f *FunctionMetrics) ChurnTotal() int {
+	return f.AddedLines + f.DeletedLines
+}
+
+// SourceReader returns the contents of path at rev. ok is false when the file does
+// not exist there.
+type SourceReader func(rev, path string) (src []byte, ok bool, err error)
+
+// FunctionMetricsAggregator maps the changed line ranges of Go files onto the
+// functions declared in each file right after the commit.
+type FunctionMetricsAggregator struct {
+	read        SourceReader
+	pathAliases *PathAliases
+	metrics     map[functionKey]*FunctionMetrics
+}
+
+type functionKey struct {
+	path string
+	name string
+}
+
+// lineCounts are the lines one commit added to and deleted from one function.
+type lineCounts struct {
+	added   int
+	deleted int
+}
+
+// NewFunctionMetricsAggregator creates an aggregator reading file revisions with read.
+func NewFunctionMetricsAggregator(read SourceReader) *FunctionMetricsAggregator {
+	return &FunctionMetricsAggregator{
+		read:        read,
+		pathAliases: NewPathAliases(),
+		metrics:     make(map[functionKey]*FunctionMetrics),
+	}
+}
+
+// Process attributes the hunks of each commit (keyed by SHA, as returned by
+// git.HistoryReader.ReadHunks) to functions. Only commits in changeSets are counted,
+// and those in bugfixes count as bugfixes of the functions they change. Revisions
+// that do not parse as Go are skipped.
+func (a *FunctionMetricsAggregator) Process(
+	changeSets []git.CommitChangeSet,
+	hunks map[string][]git.FileHunks,
+	bugfixes map[string]struct{},
+) error {
+	for _, cs := range changeSets {
+		for _, change := range cs.Changes {
+			if change.Kind == git.ChangeKindRenamed && change.OldPath != "" {
+				a.pathAliases.Rename(change.OldPath, change.Path)
+			}
+		}
+	}
+
+	for _, cs := range changeSets {
+		_, isBugfix := bugfixes[cs.Commit.SHA]
+		for _, fh := range hunks[cs.Commit.SHA] {
+			if fh.Deleted || len(fh.Hunks) == 0 || !strings.HasSuffix(fh.Path, ".go") {
+				continue
+			}
+			src, ok, err := a.read(cs.Commit.SHA, fh.Path)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			funcs, err := gofunc.Parse(fh.Path, src)
+			if err != nil {
+				continue
+			}
+
+			path := a.pathAliases.Canonical(fh.Path)
+			for i, counts := range attributeHunks(funcs, fh.Hunks) {
+				m := a.get(path, funcs[i].Name)
+				m.CommitCount++
+				m.AddedLines += counts.added
+				m.DeletedLines += counts.deleted
+				if isBugfix {
+					m.BugfixCount++
+				}
+				if cs.Commit.When.After(m.LastModifiedAt) {
+					m.LastModifiedAt = cs.Commit.When
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (a *FunctionMetricsAggregator) get(path, name string) *FunctionMetrics {
+	key := functionKey{path: path, name: name}
+	m, ok := a.metrics[key]
+	if !ok {
+		m = &FunctionMetrics{Path: path, Name: name}
+		a.metrics[key] = m
+	}
+	return m
+}
+
+// attributeHunks counts the added lines of each hunk toward the functions they fall
+// in, and its deleted lines toward the function at the hunk's position. A pure
+// deletion counts only when it falls inside a function, so removing a whole
+// function is not attributed to its neighbor.
+func attributeHunks(funcs []gofunc.Func, hunks []git.Hunk) map[int]*lineCounts {
+	result := make(map[int]*lineCounts)
+	counts := func(i int) *lineCounts {
+		c, ok := result[i]
+		if !ok {
+			c = &lineCounts{}
+			result[i] = c
+		}
+		return c
+	}
+
+	for _, h := range hunks {
+		if h.NewLines == 0 {
+			// The lines were deleted between NewStart and NewStart+1
+			if i := gofunc.At(funcs, h.NewStart); i >= 0 && i == gofunc.At(funcs, h.NewStart+1) {
+				counts(i).deleted += h.OldLines
+			}
+			continue
+		}
+		for line := h.NewStart; line < h.NewStart+h.NewLines; line++ {
+			if i := gofunc.At(funcs, line); i >= 0 {
+				counts(i).added++
+			}
+		}
+		if h.OldLines > 0 {
+			if i := gofunc.At(funcs, h.NewStart); i >= 0 {
+				counts(i).deleted += h.OldLines
+			}
+		}
+	}
+	return result
+}
+
+// Result returns the metrics of the changed functions still declared in their file
+// at rev (the analyzed revision), positioned there, ordered by path and name.
+// Functions removed or renamed since are left out.
+func (a *FunctionMetricsAggregator) Result(rev string) ([]*FunctionMetrics, error) {
+	paths := make(map[string]struct{})
+	for key := range a.metrics {
+		paths[key.path] = struct{}{}
+	}
+
+	var result []*FunctionMetrics
+	for path := range paths {
+		src, ok, err := a.read(rev, path)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		funcs, err := gofunc.Parse(path, src)
+		if err != nil {
+			continue
+		}
+		seen := make(map[string]struct{}, len(funcs))
+		for _, fn := range funcs {
+			// Repeated names (init) share their history; the first declaration places it
+			if _, dup := seen[fn.Name]; dup {
+				continue
+			}
+			seen[fn.Name] = struct{}{}
+			m, ok := a.metrics[functionKey{path: path, name: fn.Name}]
+			if !ok {
+				continue
+			}
+			m.StartLine, m.EndLine = fn.StartLine, fn.EndLine
+			result = append(result, m)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package aggregation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+	"github.com/masmgr/bugspots-go/internal/gofunc"
+)
+
+func TestAttributeHunks(t *testing.T) {
+	funcs := []gofunc.Func{{Name: "a", StartLine: 3, EndLine: 10}, {Name: "b", StartLine: 12, EndLine: 20}}
+	tests := []struct {
+		name  string
+		hunks []git.Hunk
+		want  map[int]lineCounts
+	}{
+		{
+			name:  "Modification inside one function",
+			hunks: []git.Hunk{{OldStart: 5, OldLines: 2, NewStart: 5, NewLines: 3}},
+			want:  map[int]lineCounts{0: {added: 3, deleted: 2}},
+		},
+		{
+			name:  "Addition spanning two functions",
+			hunks: []git.Hunk{{OldStart: 9, OldLines: 0, NewStart: 9, NewLines: 5}},
+			want:  map[int]lineCounts{0: {added: 2}, 1: {added: 2}},
+		},
+		{
+			name:  "Deletion inside a function",
+			hunks: []git.Hunk{{OldStart: 15, OldLines: 4, NewStart: 14, NewLines: 0}},
+			want:  map[int]lineCounts{1: {deleted: 4}},
+		},
+		{
+			name:  "Deletion of a whole function",
+			hunks: []git.Hunk{{OldStart: 11, OldLines: 6, NewStart: 10, NewLines: 0}},
+			want:  map[int]lineCounts{},
+		},
+		{
+			name:  "Change outside functions",
+			hunks: []git.Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1}},
+			want:  map[int]lineCounts{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[int]lineCounts)
+			for i, c := range attributeHunks(funcs, tt.hunks) {
+				got[i] = *c
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attributeHunks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFunctionMetricsAggregator(t *testing.T) {
+	v1 := "package p\n\nfunc A() {\n\ta := 1\n\t_ = a\n}\n\nfunc B() {\n}\n"
+	v2 := "package p\n\nfunc A() {\n\ta := 2\n\t_ = a\n}\n\nfunc B() {\n\tprintln()\n}\n"
+	v3 := "package p\n\nfunc A() {\n\ta := 3\n\t_ = a\n}\n\nfunc C() {\n\tprintln()\n}\n"
+	sources := map[string]string{
+		"c1:old.go":  v1,
+		"c2:p.go":    v2,
+		"c3:p.go":    v3,
+		"HEAD:p.go":  v3,
+		"c4:gone.go": "package p\n\nfunc D() {\n}\n",
+	}
+	read := func(rev, path string) ([]byte, bool, error) {
+		src, ok := sources[rev+":"+path]
+		return []byte(src), ok, nil
+	}
+
+	when := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	changeSets := []git.CommitChangeSet{
+		{Commit: git.CommitInfo{SHA: "c4", When: when.Add(4 * time.Hour)}, Changes: []git.FileChange{{Path: "gone.go"}}},
+		{Commit: git.CommitInfo{SHA: "c3", When: when.Add(3 * time.Hour)}, Changes: []git.FileChange{{Path: "p.go"}}},
+		{Commit: git.CommitInfo{SHA: "c2", When: when.Add(2 * time.Hour)},
+			Changes: []git.FileChange{{Path: "p.go", OldPath: "old.go", Kind: git.ChangeKindRenamed}}},
+		{Commit: git.CommitInfo{SHA: "c1", When: when.Add(1 * time.Hour)}, Changes: []git.FileChange{{Path: "old.go"}}},
+	}
+	hunks := map[string][]git.FileHunks{
+		"c1": {{Path: "old.go", Hunks: []git.Hunk{{OldStart: 4, OldLines: 1, NewStart: 4, NewLines: 1}}}},
+		"c2": {{Path: "p.go", OldPath: "old.go", Hunks: []git.Hunk{
+			{OldStart: 4, OldLines: 1, NewStart: 4, NewLines: 1},
+			{OldStart: 8, OldLines: 0, NewStart: 9, NewLines: 1},
+		}}},
+		"c3": {{Path: "p.go", Hunks: []git.Hunk{
+			{OldStart: 4, OldLines: 1, NewStart: 4, NewLines: 1},
+			{OldStart: 8, OldLines: 1, NewStart: 8, NewLines: 1},
+		}}},
+		"c4": {{Path: "gone.go", Hunks: []git.Hunk{{OldStart: 3, OldLines: 0, NewStart: 3, NewLines: 2}}}},
+	}
+	bugfixes := map[string]struct{}{"c3": {}, "c1": {}}
+
+	aggregator := NewFunctionMetricsAggregator(read)
+	if err := aggregator.Process(changeSets, hunks, bugfixes); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	result, err := aggregator.Result("HEAD")
+	if err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+
+	want := []*FunctionMetrics{
+		{Path: "p.go", Name: "A", StartLine: 3, EndLine: 6, CommitCount: 3, AddedLines: 3, DeletedLines: 3,
+			BugfixCount: 2, LastModifiedAt: when.Add(3 * time.Hour)},
+		{Path: "p.go", Name: "C", StartLine: 8, EndLine: 10, CommitCount: 1, AddedLines: 1, DeletedLines: 1,
+			BugfixCount: 1, LastModifiedAt: when.Add(3 * time.Hour)},
+	}
+	if !reflect.DeepEqual(result, want) {
+		for _, m := range result {
+			t.Logf("%+v", *m)
+		}
+		t.Errorf("Result() differs from the expected metrics")
+	}
+}
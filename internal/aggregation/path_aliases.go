@@ -0,0 +1,48 @@
+package aggregation
+
+// PathAliases tracks file renames so that changes to an old path can be attributed
+// to the file's current (canonical) path. History may be fed in any order: a rename
+// seen before older changes to the old path still redirects them.
+type PathAliases struct {
+	aliases map[string]string
+}
+
+// NewPathAliases creates an empty alias table.
+func NewPathAliases() *PathAliases {
+	return &PathAliases{aliases: make(map[string]string)}
+}
+
+// Canonical returns the canonical (post-rename) path for a given path.
+func (p *PathAliases) Canonical(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	// Follow alias chain with a small cap to avoid loops.
+	for i := 0; i < 16; i++ {
+		next, ok := p.aliases[path]
+		if !ok || next == "" || next == path {
+			return path
+		}
+		path = next
+	}
+
+	return path
+}
+
+// Rename records that oldPath was renamed to newPath. It returns the canonical paths
+// before the alias was added, and false if the rename does not change anything.
+func (p *PathAliases) Rename(oldPath, newPath string) (oldCanon, newCanon string, ok bool) {
+	oldCanon = p.Canonical(oldPath)
+	newCanon = p.Canonical(newPath)
+	if oldCanon == "" || newCanon == "" || oldCanon == newCanon {
+		return oldCanon, newCanon, false
+	}
+
+	// Alias old -> new so older commits contribute to the canonical path.
+	p.aliases[oldCanon] = newCanon
+	if oldPath != oldCanon {
+		p.aliases[oldPath] = newCanon
+	}
+	return oldCanon, newCanon, true
+}
@@ -0,0 +1,58 @@
+package aggregation
+
+import "testing"
+
+func TestPathAliases_Canonical(t *testing.T) {
+	aliases := NewPathAliases()
+	aliases.Rename("a.go", "b.go")
+	aliases.Rename("b.go", "c.go")
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"a.go", "c.go"},
+		{"b.go", "c.go"},
+		{"c.go", "c.go"},
+		{"other.go", "other.go"},
+		{"A.go", "A.go"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := aliases.Canonical(tt.path); got != tt.expected {
+				t.Errorf("Canonical(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPathAliases_Rename(t *testing.T) {
+	aliases := NewPathAliases()
+
+	oldCanon, newCanon, ok := aliases.Rename("old.go", "new.go")
+	if !ok || oldCanon != "old.go" || newCanon != "new.go" {
+		t.Errorf("Rename() = (%q, %q, %v), expected (old.go, new.go, true)", oldCanon, newCanon, ok)
+	}
+
+	if _, _, ok := aliases.Rename("old.go", "new.go"); ok {
+		t.Error("repeated rename should be a no-op")
+	}
+	if _, _, ok := aliases.Rename("", "x.go"); ok {
+		t.Error("rename from empty path should be a no-op")
+	}
+}
+
+func TestPathAliases_Cycle(t *testing.T) {
+	aliases := NewPathAliases()
+	aliases.Rename("a.go", "b.go")
+	// Renaming back resolves to the same canonical path and must not create a loop.
+	if _, _, ok := aliases.Rename("b.go", "a.go"); ok {
+		t.Error("rename back to an aliased path should be a no-op")
+	}
+
+	if got := aliases.Canonical("a.go"); got != "b.go" {
+		t.Errorf("Canonical(a.go) = %q, expected b.go", got)
+	}
+}
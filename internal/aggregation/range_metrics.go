@@ -0,0 +1,318 @@
+package aggregation
+
+import (
+	"sort"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// RangeMetrics holds the change history of a block of lines in one file.
+type RangeMetrics struct {
+	Path           string // Path at the newest analyzed revision
+	StartLine      int    // Position at the newest analyzed revision
+	EndLine        int
+	CommitCount    int
+	AddedLines     int
+	DeletedLines   int
+	BugfixCount    int
+	LastModifiedAt time.Time
+}
+
+// ChurnTotal returns the lines added and deleted by the hunks that changed the range.
+func (r *RangeMetrics) ChurnTotal() int {
+	return r.AddedLines + r.DeletedLines
+}
+
+// LineCount returns the number of lines in the range.
+func (r *RangeMetrics) LineCount() int {
+	return r.EndLine - r.StartLine + 1
+}
+
+// RangeMetricsAggregator tracks the lines each commit changed in any text file and
+// carries them forward through later diffs to the newest analyzed revision.
+type RangeMetricsAggregator struct {
+	minCommits int
+	files      map[string][]lineRegion
+	commits    map[string]rangeCommit
+}
+
+// lineRegion is the current position of the lines one hunk wrote, with the commits
+// it counts for: the commit itself, or the commits a merge brought in.
+type lineRegion struct {
+	start   int
+	end     int
+	shas    []string
+	added   int
+	deleted int
+}
+
+type rangeCommit struct {
+	when   time.Time
+	bugfix bool
+	paths  map[string]struct{} // Files the commit changed, under both names for renames
+}
+
+// NewRangeMetricsAggregator creates an aggregator whose ranges are the runs of lines
+// changed by at least minCommits commits.
+func NewRangeMetricsAggregator(minCommits int) *RangeMetricsAggregator {
+	return &RangeMetricsAggregator{
+		minCommits: max(minCommits, 1),
+		files:      make(map[string][]lineRegion),
+		commits:    make(map[string]rangeCommit),
+	}
+}
+
+// Process replays history (the first-parent history returned by
+// git.HistoryReader.ReadHunkHistory, newest first) oldest first. Every commit moves
+// the tracked lines, but only those in changeSets add lines of their own, and those
+// in bugfixes count as bugfixes. A merge's hunks count for each commit it brought in
+// (mergeOf, as returned by git.HistoryReader.ReadMergeGroups) that changed the file.
+// Lines written when a file was created are not tracked, so a file's first version
+// does not count as a change to every line.
+func (a *RangeMetricsAggregator) Process(
+	history []git.CommitHunks,
+	changeSets []git.CommitChangeSet,
+	bugfixes map[string]struct{},
+	mergeOf map[string]string,
+) {
+	merged := make(map[string][]string)
+	for _, cs := range changeSets {
+		_, isBugfix := bugfixes[cs.Commit.SHA]
+		paths := make(map[string]struct{}, len(cs.Changes))
+		for _, fc := range cs.Changes {
+			paths[fc.Path] = struct{}{}
+			if fc.OldPath != "" {
+				paths[fc.OldPath] = struct{}{}
+			}
+		}
+		a.commits[cs.Commit.SHA] = rangeCommit{when: cs.Commit.When, bugfix: isBugfix, paths: paths}
+		if merge, ok := mergeOf[cs.Commit.SHA]; ok {
+			merged[merge] = append(merged[merge], cs.Commit.SHA)
+		}
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		ch := history[i]
+		for _, fh := range ch.Files {
+			if fh.Deleted || fh.Added {
+				delete(a.files, fh.Path)
+				continue
+			}
+
+			regions := a.files[fh.Path]
+			if fh.OldPath != "" && fh.OldPath != fh.Path {
+				regions = a.files[fh.OldPath]
+				delete(a.files, fh.OldPath)
+			}
+			regions = moveRegions(regions, fh.Hunks)
+			if shas := a.hunkCommits(ch.SHA, merged[ch.SHA], fh); len(shas) > 0 {
+				for _, h := range fh.Hunks {
+					if h.NewLines == 0 {
+						continue
+					}
+					regions = append(regions, lineRegion{
+						start:   h.NewStart,
+						end:     h.NewStart + h.NewLines - 1,
+						shas:    shas,
+						added:   h.NewLines,
+						deleted: h.OldLines,
+					})
+				}
+			}
+			if len(regions) == 0 {
+				delete(a.files, fh.Path)
+				continue
+			}
+			a.files[fh.Path] = regions
+		}
+	}
+}
+
+// hunkCommits returns the analyzed commits the hunks of fh in commit sha count for:
+// the commit itself, or for a merge the commits it brought in that changed the file.
+func (a *RangeMetricsAggregator) hunkCommits(sha string, merged []string, fh git.FileHunks) []string {
+	if _, ok := a.commits[sha]; ok {
+		return []string{sha}
+	}
+	var shas []string
+	for _, m := range merged {
+		paths := a.commits[m].paths
+		_, changedPath := paths[fh.Path]
+		_, changedOldPath := paths[fh.OldPath]
+		if changedPath || (fh.OldPath != "" && changedOldPath) {
+			shas = append(shas, m)
+		}
+	}
+	return shas
+}
+
+// moveRegions maps regions through a later diff of their file. Lines the diff
+// rewrote map onto the lines that replaced them, so a block keeps its history when
+// it is edited again; regions whose lines were all deleted are dropped.
+func moveRegions(regions []lineRegion, hunks []git.Hunk) []lineRegion {
+	if len(hunks) == 0 {
+		return regions
+	}
+	moved := regions[:0]
+	for _, r := range regions {
+		start, end, ok := mapRange(hunks, r.start, r.end)
+		if !ok {
+			continue
+		}
+		r.start, r.end = start, end
+		moved = append(moved, r)
+	}
+	return moved
+}
+
+// mapRange returns the new position of the old lines start..end, with ok false when
+// all of them were deleted. Hunks are ordered and do not overlap, as in a diff.
+func mapRange(hunks []git.Hunk, start, end int) (newStart, newEnd int, ok bool) {
+	line := start
+	for line <= end {
+		h, replaced := replacingHunk(hunks, line)
+		if !replaced {
+			newStart = line + lineShift(hunks, line)
+			break
+		}
+		if h.NewLines > 0 {
+			newStart = h.NewStart
+			break
+		}
+		line = h.OldStart + h.OldLines
+	}
+	if line > end {
+		return 0, 0, false
+	}
+
+	line = end
+	for {
+		h, replaced := replacingHunk(hunks, line)
+		if !replaced {
+			newEnd = line + lineShift(hunks, line)
+			break
+		}
+		if h.NewLines > 0 {
+			newEnd = h.NewStart + h.NewLines - 1
+			break
+		}
+		line = h.OldStart - 1
+	}
+	return newStart, newEnd, true
+}
+
+// replacingHunk returns the hunk that deleted or rewrote old line n.
+func replacingHunk(hunks []git.Hunk, n int) (git.Hunk, bool) {
+	for _, h := range hunks {
+		if h.OldLines > 0 && h.OldStart <= n && n < h.OldStart+h.OldLines {
+			return h, true
+		}
+	}
+	return git.Hunk{}, false
+}
+
+// lineShift returns how far the hunks before old line n move it. A pure insertion
+// after line n does not move it.
+func lineShift(hunks []git.Hunk, n int) int {
+	shift := 0
+	for _, h := range hunks {
+		if (h.OldLines == 0 && h.OldStart >= n) || (h.OldLines > 0 && h.OldStart+h.OldLines > n) {
+			break
+		}
+		shift += h.NewLines - h.OldLines
+	}
+	return shift
+}
+
+// Result returns the hot ranges of every file ordered by path and start line: the
+// runs of lines changed by at least the minimum number of commits, with the commits
+// and hunks that changed any of their lines.
+func (a *RangeMetricsAggregator) Result() []*RangeMetrics {
+	var result []*RangeMetrics
+	for path, regions := range a.files {
+		for _, span := range hotSpans(regions, a.minCommits) {
+			result = append(result, a.rangeMetrics(path, span[0], span[1], regions))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Path != result[j].Path {
+			return result[i].Path < result[j].Path
+		}
+		return result[i].StartLine < result[j].StartLine
+	})
+	return result
+}
+
+// hotSpans returns the runs of lines covered by regions of at least minCommits
+// distinct commits.
+func hotSpans(regions []lineRegion, minCommits int) [][2]int {
+	// Regions of one commit can overlap once mapped onto the same rewrite
+	bySHA := make(map[string][]lineRegion)
+	maxLine := 0
+	for _, r := range regions {
+		for _, sha := range r.shas {
+			bySHA[sha] = append(bySHA[sha], r)
+		}
+		maxLine = max(maxLine, r.end)
+	}
+	heat := make([]int, maxLine+2)
+	for _, rs := range bySHA {
+		sort.Slice(rs, func(i, j int) bool { return rs[i].start < rs[j].start })
+		start, end := rs[0].start, rs[0].end
+		for _, r := range rs[1:] {
+			if r.start <= end+1 {
+				end = max(end, r.end)
+				continue
+			}
+			heat[start]++
+			heat[end+1]--
+			start, end = r.start, r.end
+		}
+		heat[start]++
+		heat[end+1]--
+	}
+
+	var spans [][2]int
+	count, spanStart := 0, 0
+	for line := 1; line < len(heat); line++ {
+		count += heat[line]
+		switch {
+		case count >= minCommits && spanStart == 0:
+			spanStart = line
+		case count < minCommits && spanStart != 0:
+			spans = append(spans, [2]int{spanStart, line - 1})
+			spanStart = 0
+		}
+	}
+	return spans
+}
+
+func (a *RangeMetricsAggregator) rangeMetrics(path string, start, end int, regions []lineRegion) *RangeMetrics {
+	m := &RangeMetrics{Path: path, StartLine: start, EndLine: end}
+	seen := make(map[string]struct{})
+	for _, r := range regions {
+		if r.end < start || r.start > end {
+			continue
+		}
+		m.AddedLines += r.added
+		m.DeletedLines += r.deleted
+		for _, sha := range r.shas {
+			if _, ok := seen[sha]; ok {
+				continue
+			}
+			seen[sha] = struct{}{}
+
+			commit := a.commits[sha]
+			m.CommitCount++
+			if commit.bugfix {
+				m.BugfixCount++
+			}
+			if commit.when.After(m.LastModifiedAt) {
+				m.LastModifiedAt = commit.when
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,142 @@
+package aggregation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func TestMapRange(t *testing.T) {
+	insertion := []git.Hunk{{OldStart: 2, OldLines: 0, NewStart: 3, NewLines: 2}}
+	rewrite := []git.Hunk{{OldStart: 5, OldLines: 2, NewStart: 5, NewLines: 3}}
+	deletion := []git.Hunk{{OldStart: 4, OldLines: 3, NewStart: 3, NewLines: 0}}
+
+	tests := []struct {
+		name       string
+		hunks      []git.Hunk
+		start, end int
+		wantStart  int
+		wantEnd    int
+		wantOK     bool
+	}{
+		{name: "Below an insertion", hunks: insertion, start: 5, end: 7, wantStart: 7, wantEnd: 9, wantOK: true},
+		{name: "Above an insertion", hunks: insertion, start: 1, end: 2, wantStart: 1, wantEnd: 2, wantOK: true},
+		{name: "Around an insertion", hunks: insertion, start: 2, end: 4, wantStart: 2, wantEnd: 6, wantOK: true},
+		{name: "Partly rewritten", hunks: rewrite, start: 6, end: 8, wantStart: 5, wantEnd: 9, wantOK: true},
+		{name: "Fully rewritten", hunks: rewrite, start: 5, end: 6, wantStart: 5, wantEnd: 7, wantOK: true},
+		{name: "Fully deleted", hunks: deletion, start: 4, end: 6, wantOK: false},
+		{name: "Partly deleted", hunks: deletion, start: 5, end: 8, wantStart: 4, wantEnd: 5, wantOK: true},
+		{name: "Around a deletion", hunks: deletion, start: 1, end: 10, wantStart: 1, wantEnd: 7, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := mapRange(tt.hunks, tt.start, tt.end)
+			if ok != tt.wantOK || (ok && (start != tt.wantStart || end != tt.wantEnd)) {
+				t.Errorf("mapRange(%d, %d) = %d, %d, %v, want %d, %d, %v",
+					tt.start, tt.end, start, end, ok, tt.wantStart, tt.wantEnd, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRangeMetricsAggregator(t *testing.T) {
+	when := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// c2 is not analyzed (e.g. a noise commit) but still moves the lines
+	changeSets := []git.CommitChangeSet{
+		{Commit: git.CommitInfo{SHA: "c4", When: when.Add(4 * time.Hour)}},
+		{Commit: git.CommitInfo{SHA: "c3", When: when.Add(3 * time.Hour)}},
+		{Commit: git.CommitInfo{SHA: "c1", When: when.Add(1 * time.Hour)}},
+	}
+	history := []git.CommitHunks{
+		{SHA: "c4", Files: []git.FileHunks{{Path: "g.txt", Hunks: []git.Hunk{
+			{OldStart: 6, OldLines: 0, NewStart: 7, NewLines: 1},
+			{OldStart: 10, OldLines: 1, NewStart: 11, NewLines: 1},
+		}}}},
+		{SHA: "c3", Files: []git.FileHunks{
+			{Path: "g.txt", OldPath: "f.txt", Hunks: []git.Hunk{{OldStart: 5, OldLines: 1, NewStart: 5, NewLines: 2}}},
+			{Path: "old.txt", Deleted: true, Hunks: []git.Hunk{{OldStart: 1, OldLines: 1, NewStart: 0, NewLines: 0}}},
+		}},
+		{SHA: "c2", Files: []git.FileHunks{{Path: "f.txt", Hunks: []git.Hunk{{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2}}}}},
+		{SHA: "c1", Files: []git.FileHunks{
+			{Path: "f.txt", Hunks: []git.Hunk{{OldStart: 3, OldLines: 1, NewStart: 3, NewLines: 1}}},
+			{Path: "new.txt", Added: true, Hunks: []git.Hunk{{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 9}}},
+			{Path: "old.txt", Hunks: []git.Hunk{{OldStart: 1, OldLines: 1, NewStart: 1, NewLines: 1}}},
+		}},
+	}
+	bugfixes := map[string]struct{}{"c1": {}, "c4": {}}
+
+	tests := []struct {
+		name       string
+		minCommits int
+		want       []*RangeMetrics
+	}{
+		{
+			name:       "Lines changed twice",
+			minCommits: 2,
+			want: []*RangeMetrics{
+				{Path: "g.txt", StartLine: 5, EndLine: 6, CommitCount: 2, AddedLines: 3, DeletedLines: 2,
+					BugfixCount: 1, LastModifiedAt: when.Add(3 * time.Hour)},
+			},
+		},
+		{
+			name:       "Every changed line",
+			minCommits: 1,
+			want: []*RangeMetrics{
+				{Path: "g.txt", StartLine: 5, EndLine: 7, CommitCount: 3, AddedLines: 4, DeletedLines: 2,
+					BugfixCount: 2, LastModifiedAt: when.Add(4 * time.Hour)},
+				{Path: "g.txt", StartLine: 11, EndLine: 11, CommitCount: 1, AddedLines: 1, DeletedLines: 1,
+					BugfixCount: 1, LastModifiedAt: when.Add(4 * time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregator := NewRangeMetricsAggregator(tt.minCommits)
+			aggregator.Process(history, changeSets, bugfixes, nil)
+			result := aggregator.Result()
+			if !reflect.DeepEqual(result, tt.want) {
+				for _, m := range result {
+					t.Logf("%+v", *m)
+				}
+				t.Errorf("Result() differs from the expected metrics")
+			}
+		})
+	}
+}
+
+func TestRangeMetricsAggregator_Merge(t *testing.T) {
+	when := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// s1 and s2 edited line 25 of f.txt on a branch, s3 only other.txt; m merged them
+	// after the mainline commit c1 inserted five lines at the top of f.txt
+	changeSets := []git.CommitChangeSet{
+		{Commit: git.CommitInfo{SHA: "c1", When: when.Add(4 * time.Hour)}, Changes: []git.FileChange{{Path: "f.txt"}}},
+		{Commit: git.CommitInfo{SHA: "s3", When: when.Add(3 * time.Hour)}, Changes: []git.FileChange{{Path: "other.txt"}}},
+		{Commit: git.CommitInfo{SHA: "s2", When: when.Add(2 * time.Hour)}, Changes: []git.FileChange{{Path: "f.txt"}}},
+		{Commit: git.CommitInfo{SHA: "s1", When: when.Add(1 * time.Hour)}, Changes: []git.FileChange{{Path: "f.txt"}}},
+	}
+	history := []git.CommitHunks{
+		{SHA: "m", Files: []git.FileHunks{
+			{Path: "f.txt", Hunks: []git.Hunk{{OldStart: 30, OldLines: 1, NewStart: 30, NewLines: 1}}},
+			{Path: "other.txt", Hunks: []git.Hunk{{OldStart: 2, OldLines: 1, NewStart: 2, NewLines: 1}}},
+		}},
+		{SHA: "c1", Files: []git.FileHunks{{Path: "f.txt", Hunks: []git.Hunk{{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 5}}}}},
+	}
+	mergeOf := map[string]string{"s1": "m", "s2": "m", "s3": "m"}
+
+	aggregator := NewRangeMetricsAggregator(2)
+	aggregator.Process(history, changeSets, map[string]struct{}{"s2": {}}, mergeOf)
+	want := []*RangeMetrics{
+		{Path: "f.txt", StartLine: 30, EndLine: 30, CommitCount: 2, AddedLines: 1, DeletedLines: 1,
+			BugfixCount: 1, LastModifiedAt: when.Add(2 * time.Hour)},
+	}
+	if result := aggregator.Result(); !reflect.DeepEqual(result, want) {
+		for _, m := range result {
+			t.Logf("%+v", *m)
+		}
+		t.Errorf("Result() differs from the expected metrics")
+	}
+}
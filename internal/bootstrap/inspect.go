@@ -0,0 +1,332 @@
+package bootstrap
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// Inspection is what init learned about a repository from its tracked files and history.
+type Inspection struct {
+	Files     int        // Tracked files
+	Commits   int        // Commits read
+	Languages []Language // By file count, most used first
+	Excludes  []string   // Vendored, dependency, generated and bot-maintained paths
+
+	ConventionalShare float64  // Share of commits following Conventional Commits
+	IssueKeys         []string // Project keys like PROJ in PROJ-123, most used first
+	IssueKeyShare     float64  // Share of commits referencing one of IssueKeys
+
+	Bots       []Author   // Bot accounts, most commits first
+	Identities []Identity // People committing under several emails
+}
+
+// Language is a programming language and how many tracked files use it.
+type Language struct {
+	Name  string
+	Files int
+}
+
+// Author is a commit author and their number of commits.
+type Author struct {
+	Name    string
+	Email   string
+	Commits int
+}
+
+// Identity is one person seen under several emails. Contributors are counted by email,
+// so each alias splits their ownership until .mailmap maps it to Email.
+type Identity struct {
+	Name    string
+	Email   string   // The most used email
+	Aliases []string // The other emails
+}
+
+// MailmapLines returns the .mailmap entries that map the aliases to Email.
+func (id Identity) MailmapLines() []string {
+	lines := make([]string, len(id.Aliases))
+	for i, alias := range id.Aliases {
+		lines[i] = id.Name + " <" + id.Email + "> <" + alias + ">"
+	}
+	return lines
+}
+
+// Shares of commits at which a convention is considered the repository's own.
+const (
+	conventionalThreshold = 0.5
+	issueKeyThreshold     = 0.3
+)
+
+// botMaintainedShare is the share of a file's commits made by bots above which the file
+// is treated as maintained by bots, like a lock file or changelog.
+const botMaintainedShare = 0.5
+
+// vendorDirs are directory names holding third-party or build output.
+var vendorDirs = []string{
+	"vendor", "node_modules", "bower_components", "third_party", "Pods",
+	"dist", "generated", "__generated__",
+}
+
+// generatedFiles are file patterns of generated code and dependency lock files.
+var generatedFiles = []string{
+	"**/*.pb.go", "**/*_generated.go", "**/zz_generated*.go", "**/*.gen.go",
+	"**/*_pb2.py", "**/*_pb2_grpc.py",
+	"**/*.min.js", "**/*.min.css", "**/*.snap",
+	"**/*.g.dart", "**/*.freezed.dart",
+	"**/*.Designer.cs", "**/*.designer.cs",
+	"**/go.sum", "**/package-lock.json", "**/yarn.lock", "**/pnpm-lock.yaml",
+	"**/Cargo.lock", "**/poetry.lock", "**/Pipfile.lock", "**/uv.lock",
+	"**/composer.lock", "**/Gemfile.lock",
+}
+
+// languages maps file extensions to language names.
+var languages = map[string]string{
+	".go": "Go", ".py": "Python", ".rb": "Ruby", ".rs": "Rust", ".java": "Java",
+	".kt": "Kotlin", ".kts": "Kotlin", ".scala": "Scala", ".swift": "Swift",
+	".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".vue": "Vue", ".svelte": "Svelte",
+	".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++", ".cxx": "C++", ".hpp": "C++",
+	".cs": "C#", ".fs": "F#", ".php": "PHP", ".m": "Objective-C", ".mm": "Objective-C",
+	".dart": "Dart", ".ex": "Elixir", ".exs": "Elixir", ".erl": "Erlang", ".hs": "Haskell",
+	".clj": "Clojure", ".lua": "Lua", ".r": "R", ".sh": "Shell", ".sql": "SQL",
+}
+
+var (
+	conventionalPattern = regexp.MustCompile(`^[a-z]+(\([^)]*\))?!?: \S`)
+	issueKeyPattern     = regexp.MustCompile(`\b([A-Z][A-Z0-9]+)-[0-9]+\b`)
+	botPattern          = regexp.MustCompile(`(?i)\[bot\]|\bbot\b|-bot\b|^(dependabot|renovate|greenkeeper|github-actions|mergify|pre-commit-ci|snyk)`)
+)
+
+// notIssueKeys are uppercase prefixes of version and standard names, not issue trackers.
+var notIssueKeys = map[string]bool{"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true, "HTTP": true, "TLS": true}
+
+// Inspect examines the tracked files and the commit history of a repository.
+func Inspect(files []string, changeSets []git.CommitChangeSet) *Inspection {
+	insp := &Inspection{Files: len(files), Commits: len(changeSets)}
+	insp.Excludes = excludePatterns(files)
+	insp.Languages = countLanguages(files, insp.Excludes)
+	insp.inspectMessages(changeSets)
+	insp.inspectAuthors(changeSets)
+	insp.Excludes = append(insp.Excludes, botMaintained(changeSets, insp.Excludes)...)
+	return insp
+}
+
+// Conventional reports whether the history follows Conventional Commits.
+func (i *Inspection) Conventional() bool {
+	return i.ConventionalShare >= conventionalThreshold
+}
+
+// UsesIssueKeys reports whether commits commonly reference issue keys.
+func (i *Inspection) UsesIssueKeys() bool {
+	return len(i.IssueKeys) > 0 && i.IssueKeyShare >= issueKeyThreshold
+}
+
+// excludePatterns returns the vendor directories and generated file patterns that match
+// tracked files. A file pattern is only listed for files outside excluded directories.
+func excludePatterns(files []string) []string {
+	var patterns []string
+	for _, dir := range vendorDirs {
+		if pattern := "**/" + dir + "/**"; matchesAny(files, pattern, patterns) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	for _, pattern := range generatedFiles {
+		if matchesAny(files, pattern, patterns) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// matchesAny reports whether pattern matches a file not already matched by excluded.
+func matchesAny(files []string, pattern string, excluded []string) bool {
+	for _, file := range files {
+		if match(pattern, file) && !matchesOne(excluded, file) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesOne(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		if match(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
+
+func match(pattern, file string) bool {
+	matched, _ := doublestar.Match(pattern, file)
+	return matched
+}
+
+func countLanguages(files, excluded []string) []Language {
+	counts := map[string]int{}
+	for _, file := range files {
+		if name, ok := languages[strings.ToLower(path.Ext(file))]; ok && !matchesOne(excluded, file) {
+			counts[name]++
+		}
+	}
+	result := make([]Language, 0, len(counts))
+	for name, n := range counts {
+		result = append(result, Language{Name: name, Files: n})
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Files != result[b].Files {
+			return result[a].Files > result[b].Files
+		}
+		return result[a].Name < result[b].Name
+	})
+	return result
+}
+
+// inspectMessages measures the commit message conventions.
+func (i *Inspection) inspectMessages(changeSets []git.CommitChangeSet) {
+	if len(changeSets) == 0 {
+		return
+	}
+	conventional := 0
+	keyCommits := map[string]int{}
+	for _, cs := range changeSets {
+		msg := cs.Commit.Message
+		if conventionalPattern.MatchString(msg) {
+			conventional++
+		}
+		seen := map[string]bool{}
+		for _, m := range issueKeyPattern.FindAllStringSubmatch(msg, -1) {
+			if key := m[1]; !notIssueKeys[key] && !seen[key] {
+				seen[key] = true
+				keyCommits[key]++
+			}
+		}
+	}
+	i.ConventionalShare = float64(conventional) / float64(len(changeSets))
+
+	// A key seen once is more likely a name than a project
+	for key, n := range keyCommits {
+		if n >= 2 {
+			i.IssueKeys = append(i.IssueKeys, key)
+		}
+	}
+	sort.Slice(i.IssueKeys, func(a, b int) bool {
+		ka, kb := i.IssueKeys[a], i.IssueKeys[b]
+		if keyCommits[ka] != keyCommits[kb] {
+			return keyCommits[ka] > keyCommits[kb]
+		}
+		return ka < kb
+	})
+
+	keys := map[string]bool{}
+	for _, key := range i.IssueKeys {
+		keys[key] = true
+	}
+	withKey := 0
+	for _, cs := range changeSets {
+		for _, m := range issueKeyPattern.FindAllStringSubmatch(cs.Commit.Message, -1) {
+			if keys[m[1]] {
+				withKey++
+				break
+			}
+		}
+	}
+	i.IssueKeyShare = float64(withKey) / float64(len(changeSets))
+}
+
+// inspectAuthors finds bot accounts and people committing under several emails.
+func (i *Inspection) inspectAuthors(changeSets []git.CommitChangeSet) {
+	type emailCount struct {
+		name    string
+		commits int
+	}
+	bots := map[string]*Author{}
+	people := map[string]map[string]*emailCount{} // Normalized name -> email -> commits
+	for _, cs := range changeSets {
+		a := cs.Commit.Author
+		email := strings.ToLower(a.Email)
+		if isBot(a) {
+			if bots[email] == nil {
+				bots[email] = &Author{Name: a.Name, Email: a.Email}
+			}
+			bots[email].Commits++
+			continue
+		}
+		name := strings.Join(strings.Fields(strings.ToLower(a.Name)), " ")
+		if name == "" || email == "" {
+			continue
+		}
+		if people[name] == nil {
+			people[name] = map[string]*emailCount{}
+		}
+		if people[name][email] == nil {
+			people[name][email] = &emailCount{name: a.Name}
+		}
+		people[name][email].commits++
+	}
+
+	for _, bot := range bots {
+		i.Bots = append(i.Bots, *bot)
+	}
+	sort.Slice(i.Bots, func(a, b int) bool {
+		if i.Bots[a].Commits != i.Bots[b].Commits {
+			return i.Bots[a].Commits > i.Bots[b].Commits
+		}
+		return i.Bots[a].Email < i.Bots[b].Email
+	})
+
+	for _, emails := range people {
+		if len(emails) < 2 {
+			continue
+		}
+		var id Identity
+		best := -1
+		for email, c := range emails {
+			if c.commits > best || (c.commits == best && email < id.Email) {
+				id.Name, id.Email, best = c.name, email, c.commits
+			}
+		}
+		for email := range emails {
+			if email != id.Email {
+				id.Aliases = append(id.Aliases, email)
+			}
+		}
+		sort.Strings(id.Aliases)
+		i.Identities = append(i.Identities, id)
+	}
+	sort.Slice(i.Identities, func(a, b int) bool { return i.Identities[a].Email < i.Identities[b].Email })
+}
+
+func isBot(a git.AuthorInfo) bool {
+	local, _, _ := strings.Cut(a.Email, "@")
+	return botPattern.MatchString(a.Name) || botPattern.MatchString(local)
+}
+
+// botMaintained returns the files, outside excluded paths, that changed at least twice
+// and mostly in bot commits.
+func botMaintained(changeSets []git.CommitChangeSet, excluded []string) []string {
+	total := map[string]int{}
+	byBots := map[string]int{}
+	for _, cs := range changeSets {
+		bot := isBot(cs.Commit.Author)
+		for _, ch := range cs.Changes {
+			total[ch.Path]++
+			if bot {
+				byBots[ch.Path]++
+			}
+		}
+	}
+	var paths []string
+	for file, n := range byBots {
+		if n >= 2 && float64(n)/float64(total[file]) > botMaintainedShare && !matchesOne(excluded, file) {
+			paths = append(paths, file)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
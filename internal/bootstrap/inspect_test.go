@@ -0,0 +1,142 @@
+package bootstrap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func commit(name, email, message string, paths ...string) git.CommitChangeSet {
+	cs := git.CommitChangeSet{Commit: git.CommitInfo{
+		Author:  git.AuthorInfo{Name: name, Email: email},
+		Message: message,
+	}}
+	for _, p := range paths {
+		cs.Changes = append(cs.Changes, git.FileChange{Path: p, Kind: git.ChangeKindModified})
+	}
+	return cs
+}
+
+func TestInspect_Excludes(t *testing.T) {
+	files := []string{
+		"main.go", "api/api.pb.go", "vendor/lib/lib.go", "vendor/lib/lib.pb.go",
+		"web/app.ts", "web/node_modules/x/index.js", "web/package-lock.json", "CHANGELOG.md",
+	}
+	bot := "dependabot[bot]"
+	changeSets := []git.CommitChangeSet{
+		commit(bot, "bot@users.noreply.github.com", "Bump x", "CHANGELOG.md", "web/package-lock.json"),
+		commit(bot, "bot@users.noreply.github.com", "Bump y", "CHANGELOG.md"),
+		commit("Ann", "ann@example.com", "Release notes", "CHANGELOG.md", "main.go"),
+		commit("Ann", "ann@example.com", "Tweak", "main.go"),
+		commit("Ann", "ann@example.com", "Tweak", "main.go"),
+	}
+
+	insp := Inspect(files, changeSets)
+	want := []string{"**/vendor/**", "**/node_modules/**", "**/*.pb.go", "**/package-lock.json", "CHANGELOG.md"}
+	if !reflect.DeepEqual(insp.Excludes, want) {
+		t.Errorf("Excludes = %v, want %v", insp.Excludes, want)
+	}
+	wantLangs := []Language{{Name: "Go", Files: 1}, {Name: "TypeScript", Files: 1}}
+	if !reflect.DeepEqual(insp.Languages, wantLangs) {
+		t.Errorf("Languages = %v, want %v", insp.Languages, wantLangs)
+	}
+	if len(insp.Bots) != 1 || insp.Bots[0].Name != bot || insp.Bots[0].Commits != 2 {
+		t.Errorf("Bots = %+v, want %s with 2 commits", insp.Bots, bot)
+	}
+}
+
+func TestInspect_Messages(t *testing.T) {
+	tests := []struct {
+		name             string
+		messages         []string
+		wantConventional bool
+		wantKeys         []string
+		wantIssues       bool
+	}{
+		{
+			name:     "Free-form",
+			messages: []string{"Fix crash", "Add parser", "Update UTF-8 handling", "Support SHA-256"},
+		},
+		{
+			name:             "Conventional Commits",
+			messages:         []string{"fix(parser): crash", "feat!: new API", "docs: typo", "Merge branch x"},
+			wantConventional: true,
+		},
+		{
+			name:       "Issue keys",
+			messages:   []string{"CORE-12 fix crash", "WEB-3 layout", "CORE-13 parser", "WEB-4 and CORE-14", "tidy", "OPS-1 once"},
+			wantKeys:   []string{"CORE", "WEB"},
+			wantIssues: true,
+		},
+		{
+			name:     "Rare issue keys",
+			messages: []string{"CORE-12 fix crash", "CORE-13 parser", "tidy", "tidy", "tidy", "tidy", "tidy"},
+			wantKeys: []string{"CORE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changeSets []git.CommitChangeSet
+			for _, msg := range tt.messages {
+				changeSets = append(changeSets, commit("Ann", "ann@example.com", msg))
+			}
+			insp := Inspect(nil, changeSets)
+			if insp.Conventional() != tt.wantConventional {
+				t.Errorf("Conventional() = %v (share %.2f), want %v", insp.Conventional(), insp.ConventionalShare, tt.wantConventional)
+			}
+			if !reflect.DeepEqual(insp.IssueKeys, tt.wantKeys) {
+				t.Errorf("IssueKeys = %v, want %v", insp.IssueKeys, tt.wantKeys)
+			}
+			if insp.UsesIssueKeys() != tt.wantIssues {
+				t.Errorf("UsesIssueKeys() = %v (share %.2f), want %v", insp.UsesIssueKeys(), insp.IssueKeyShare, tt.wantIssues)
+			}
+		})
+	}
+}
+
+func TestInspect_Identities(t *testing.T) {
+	changeSets := []git.CommitChangeSet{
+		commit("Jane Doe", "jane@corp.example", "a"),
+		commit("Jane Doe", "jane@corp.example", "b"),
+		commit("jane  doe", "JDoe@home.example", "c"),
+		commit("Jane Doe", "jane@old.example", "d"),
+		commit("Ann", "ann@example.com", "e"),
+		commit("renovate[bot]", "renovate@example.com", "f"),
+		commit("renovate[bot]", "bot@renovateapp.com", "g"),
+	}
+
+	insp := Inspect(nil, changeSets)
+	want := []Identity{{Name: "Jane Doe", Email: "jane@corp.example", Aliases: []string{"jane@old.example", "jdoe@home.example"}}}
+	if !reflect.DeepEqual(insp.Identities, want) {
+		t.Fatalf("Identities = %+v, want %+v", insp.Identities, want)
+	}
+	wantLines := []string{
+		"Jane Doe <jane@corp.example> <jane@old.example>",
+		"Jane Doe <jane@corp.example> <jdoe@home.example>",
+	}
+	if got := insp.Identities[0].MailmapLines(); !reflect.DeepEqual(got, wantLines) {
+		t.Errorf("MailmapLines() = %v, want %v", got, wantLines)
+	}
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		author git.AuthorInfo
+		want   bool
+	}{
+		{git.AuthorInfo{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"}, true},
+		{git.AuthorInfo{Name: "Renovate Bot", Email: "bot@renovateapp.com"}, true},
+		{git.AuthorInfo{Name: "github-actions", Email: "actions@github.com"}, true},
+		{git.AuthorInfo{Name: "CI", Email: "release-bot@example.com"}, true},
+		{git.AuthorInfo{Name: "Abbott Robotham", Email: "abbott@example.com"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.author.Name, func(t *testing.T) {
+			if got := isBot(tt.author); got != tt.want {
+				t.Errorf("isBot(%+v) = %v, want %v", tt.author, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// conventionalBugfixTypes are the Conventional Commits types counted as bugfixes, so
+// that "feat: fix typo" is not one.
+var conventionalBugfixTypes = []string{"fix", "hotfix"}
+
+// Starter is the configuration init writes: only the tailored settings, leaving the
+// rest to the defaults.
+type Starter struct {
+	Bugfix       config.BugfixConfig        `json:"bugfix"`
+	Conventional *config.ConventionalConfig `json:"conventional,omitempty"`
+	Coupling     *StarterCoupling           `json:"coupling,omitempty"`
+	Filters      config.FilterConfig        `json:"filters"`
+}
+
+// StarterCoupling groups coupling by issue key when the history references issues.
+type StarterCoupling struct {
+	ChangeSets config.ChangeSetConfig `json:"changeSets"`
+}
+
+// Starter returns the configuration suited to the inspected repository.
+func (i *Inspection) Starter() *Starter {
+	defaults := config.DefaultConfig()
+	s := &Starter{
+		Bugfix:  defaults.Bugfix,
+		Filters: config.FilterConfig{Include: []string{}, Exclude: append([]string{}, i.Excludes...)},
+	}
+	if i.Conventional() {
+		// Commits are classified by type; the patterns still apply to free-form messages
+		conv := defaults.Conventional
+		conv.Enabled = true
+		conv.BugfixTypes = append([]string{}, conventionalBugfixTypes...)
+		s.Conventional = &conv
+	}
+	if i.UsesIssueKeys() {
+		changeSets := defaults.Coupling.ChangeSets
+		changeSets.Mode = config.ChangeSetModeIssue
+		changeSets.IssuePattern = `\b(` + strings.Join(i.IssueKeys, "|") + `)-\d+\b`
+		s.Coupling = &StarterCoupling{ChangeSets: changeSets}
+	}
+	return s
+}
+
+// JSON returns the starter configuration as an indented JSON file.
+func (s *Starter) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
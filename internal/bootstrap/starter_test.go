@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/bugfix"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func TestInspection_Starter(t *testing.T) {
+	tests := []struct {
+		name         string
+		insp         Inspection
+		wantPatterns []string
+		wantTypes    []string
+		wantMode     config.ChangeSetMode
+		wantIssue    string
+	}{
+		{
+			name:         "Defaults",
+			insp:         Inspection{Excludes: []string{"**/vendor/**"}},
+			wantPatterns: config.DefaultConfig().Bugfix.Patterns,
+			wantMode:     config.ChangeSetModeCommit,
+			wantIssue:    config.DefaultIssuePattern,
+		},
+		{
+			name:         "Conventional with issue keys",
+			insp:         Inspection{ConventionalShare: 0.8, IssueKeys: []string{"CORE", "WEB"}, IssueKeyShare: 0.6},
+			wantPatterns: config.DefaultConfig().Bugfix.Patterns,
+			wantTypes:    []string{"fix", "hotfix"},
+			wantMode:     config.ChangeSetModeIssue,
+			wantIssue:    `\b(CORE|WEB)-\d+\b`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.insp.Starter().JSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			path := filepath.Join(t.TempDir(), ".bugspots.json")
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			// The starter file must load cleanly over the defaults
+			cfg, issues, err := config.Load(path, "")
+			if err != nil || len(issues) != 0 {
+				t.Fatalf("Load() = %v, %v\n%s", issues, err, data)
+			}
+			if !reflect.DeepEqual(cfg.Bugfix.Patterns, tt.wantPatterns) {
+				t.Errorf("Bugfix.Patterns = %v, want %v", cfg.Bugfix.Patterns, tt.wantPatterns)
+			}
+			if cfg.Conventional.Enabled != (tt.wantTypes != nil) {
+				t.Errorf("Conventional.Enabled = %v, want %v", cfg.Conventional.Enabled, tt.wantTypes != nil)
+			}
+			if tt.wantTypes != nil && !reflect.DeepEqual(cfg.Conventional.BugfixTypes, tt.wantTypes) {
+				t.Errorf("Conventional.BugfixTypes = %v, want %v", cfg.Conventional.BugfixTypes, tt.wantTypes)
+			}
+			if cfg.Coupling.ChangeSets.Mode != tt.wantMode || cfg.Coupling.ChangeSets.IssuePattern != tt.wantIssue {
+				t.Errorf("ChangeSets = %+v, want mode %s with pattern %s", cfg.Coupling.ChangeSets, tt.wantMode, tt.wantIssue)
+			}
+			if !reflect.DeepEqual(cfg.Filters.Exclude, append([]string{}, tt.insp.Excludes...)) {
+				t.Errorf("Filters.Exclude = %v, want %v", cfg.Filters.Exclude, tt.insp.Excludes)
+			}
+		})
+	}
+}
+
+func TestStarter_ConventionalBugfixes(t *testing.T) {
+	insp := Inspection{ConventionalShare: 0.9}
+	s := insp.Starter()
+	detector, err := bugfix.NewDetector(s.Bugfix.Patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detector.WithConventionalTypes(s.Conventional.BugfixTypes)
+	for msg, want := range map[string]bool{
+		"fix: crash":            true,
+		"fix(parser)!: crash":   true,
+		"hotfix(api): timeout":  true,
+		"feat: fix command":     false,
+		"chore(deps): fix lint": false,
+		"Fix crash on startup":  true, // free-form messages fall back to the patterns
+	} {
+		if got := detector.IsBugfixCommit(git.CommitInfo{Message: msg}); got != want {
+			t.Errorf("%q is bugfix = %v, want %v", msg, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/masmgr/bugspots-go/internal/conventional"
 	"github.com/masmgr/bugspots-go/internal/git"
 )
 
@@ -19,7 +20,8 @@ type BugfixResult struct {
 
 // Detector detects bugfix commits by matching commit messages against regex patterns.
 type Detector struct {
-	patterns []*regexp.Regexp
+	patterns          []*regexp.Regexp
+	conventionalTypes []string
 }
 
 // NewDetector creates a new Detector from a list of regex pattern strings.
@@ -54,20 +56,39 @@ func (d *Detector) IsBugfix(message string) bool {
 	return false
 }
 
+// WithConventionalTypes classifies Conventional Commits by type instead of the patterns:
+// a commit is a bugfix when its type is one of types. Other commits still match the
+// patterns.
+func (d *Detector) WithConventionalTypes(types []string) *Detector {
+	d.conventionalTypes = types
+	return d
+}
+
+// IsBugfixCommit classifies a commit by its Conventional Commit type when enabled, or
+// else by its message.
+func (d *Detector) IsBugfixCommit(commit git.CommitInfo) bool {
+	if len(d.conventionalTypes) > 0 {
+		if c, ok := conventional.ParseCommit(commit); ok {
+			return c.Is(d.conventionalTypes)
+		}
+	}
+	return d.IsBugfix(commit.Message)
+}
+
 // Detect scans the given change sets and returns the bugfix detection result.
-// A commit is classified as a bugfix if its message matches any of the configured patterns.
+// A commit is classified as a bugfix by IsBugfixCommit.
 func (d *Detector) Detect(changeSets []git.CommitChangeSet) *BugfixResult {
 	result := &BugfixResult{
 		BugfixCommits:    make(map[string]struct{}),
 		FileBugfixCounts: make(map[string]int),
 	}
 
-	if len(d.patterns) == 0 {
+	if len(d.patterns) == 0 && len(d.conventionalTypes) == 0 {
 		return result
 	}
 
 	for _, cs := range changeSets {
-		if !d.IsBugfix(cs.Commit.Message) {
+		if !d.IsBugfixCommit(cs.Commit) {
 			continue
 		}
 
@@ -224,3 +224,41 @@ func TestDetect_EmptyChangeSets(t *testing.T) {
 		t.Errorf("TotalBugfixes = %d, want 0", result.TotalBugfixes)
 	}
 }
+
+func TestIsBugfixCommit_ConventionalTypes(t *testing.T) {
+	d, err := NewDetector([]string{`\bfix(ed|es)?\b`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.WithConventionalTypes([]string{"fix", "perf"})
+
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"fix(parser): handle empty input", true},
+		{"perf: cache lookups", true},
+		{"feat: add fix command", false},
+		{"chore: fixed lint", false},
+		{"Fixed crash on startup", true}, // Not conventional: patterns apply
+		{"Add parser", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := d.IsBugfixCommit(git.CommitInfo{Message: tt.message}); got != tt.want {
+				t.Errorf("IsBugfixCommit(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+
+	// Types alone are enough to detect bugfixes
+	typesOnly, _ := NewDetector(nil)
+	typesOnly.WithConventionalTypes([]string{"fix"})
+	result := typesOnly.Detect([]git.CommitChangeSet{
+		{Commit: git.CommitInfo{SHA: "a", Message: "fix: crash"}, Changes: []git.FileChange{{Path: "a.go"}}},
+		{Commit: git.CommitInfo{SHA: "b", Message: "feat: fix command"}, Changes: []git.FileChange{{Path: "b.go"}}},
+	})
+	if result.TotalBugfixes != 1 || result.FileBugfixCounts["a.go"] != 1 {
+		t.Errorf("Detect() = %+v, expected only the fix commit", result)
+	}
+}
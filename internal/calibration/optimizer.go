@@ -33,12 +33,12 @@ type CalibrateResult struct {
 // fileFeatures holds normalized feature values for a single file.
 type fileFeatures struct {
 	path     string
-	features [7]float64 // commit, churn, recency, burst, ownership, bugfix, complexity
+	features [8]float64 // commit, churn, recency, burst, ownership, bugfix, complexity, coupling
 	isBugfix bool
 }
 
 // weightNames defines the order of weight components for the optimizer.
-var weightNames = [7]string{"commit", "churn", "recency", "burst", "ownership", "bugfix", "complexity"}
+var weightNames = [8]string{"commit", "churn", "recency", "burst", "ownership", "bugfix", "complexity", "coupling"}
 
 // Calibrate optimizes scoring weights to maximize detection of bugfix files.
 func Calibrate(input CalibrateInput) CalibrateResult {
@@ -73,7 +73,7 @@ func Calibrate(input CalibrateInput) CalibrateResult {
 
 		ff := fileFeatures{
 			path: path,
-			features: [7]float64{
+			features: [8]float64{
 				scoring.NormLog(float64(fm.CommitCount), ctx.CommitCount),
 				scoring.NormLog(float64(fm.ChurnTotal()), ctx.ChurnTotal),
 				scoring.RecencyDecay(daysSince, halfLife),
@@ -81,6 +81,7 @@ func Calibrate(input CalibrateInput) CalibrateResult {
 				1.0 - fm.OwnershipRatio(),
 				scoring.NormLog(float64(fm.BugfixCount), ctx.BugfixCount),
 				scoring.NormLog(float64(fm.FileSize), ctx.FileSize),
+				scoring.NormLog(fm.CouplingDegree, ctx.Coupling),
 			},
 		}
 		_, ff.isBugfix = input.BugfixFiles[path]
@@ -118,7 +119,7 @@ func Calibrate(input CalibrateInput) CalibrateResult {
 }
 
 // detectionRate calculates the recall of bugfix files in the top N% of ranked files.
-func detectionRate(files []fileFeatures, weights [7]float64, topPercent int) float64 {
+func detectionRate(files []fileFeatures, weights [8]float64, topPercent int) float64 {
 	type scored struct {
 		score    float64
 		isBugfix bool
@@ -163,9 +164,9 @@ func detectionRate(files []fileFeatures, weights [7]float64, topPercent int) flo
 }
 
 // optimizeWeights uses coordinate descent to find weights that maximize detection rate.
-func optimizeWeights(files []fileFeatures, topPercent int) [7]float64 {
+func optimizeWeights(files []fileFeatures, topPercent int) [8]float64 {
 	// Start with equal weights
-	weights := [7]float64{1.0 / 7, 1.0 / 7, 1.0 / 7, 1.0 / 7, 1.0 / 7, 1.0 / 7, 1.0 / 7}
+	weights := [8]float64{1.0 / 8, 1.0 / 8, 1.0 / 8, 1.0 / 8, 1.0 / 8, 1.0 / 8, 1.0 / 8, 1.0 / 8}
 	bestRate := detectionRate(files, weights, topPercent)
 
 	step := 0.05
@@ -174,8 +175,8 @@ func optimizeWeights(files []fileFeatures, topPercent int) [7]float64 {
 	for iter := 0; iter < maxIterations; iter++ {
 		improved := false
 
-		for i := 0; i < 7; i++ {
-			for j := 0; j < 7; j++ {
+		for i := range weights {
+			for j := range weights {
 				if i == j {
 					continue
 				}
@@ -216,12 +217,12 @@ func optimizeWeights(files []fileFeatures, topPercent int) [7]float64 {
 }
 
 // weightsToVec converts WeightConfig to an array.
-func weightsToVec(w config.WeightConfig) [7]float64 {
-	return [7]float64{w.Commit, w.Churn, w.Recency, w.Burst, w.Ownership, w.Bugfix, w.Complexity}
+func weightsToVec(w config.WeightConfig) [8]float64 {
+	return [8]float64{w.Commit, w.Churn, w.Recency, w.Burst, w.Ownership, w.Bugfix, w.Complexity, w.Coupling}
 }
 
 // vecToWeights converts an array to WeightConfig.
-func vecToWeights(v [7]float64) config.WeightConfig {
+func vecToWeights(v [8]float64) config.WeightConfig {
 	return config.WeightConfig{
 		Commit:     roundTo(v[0], 2),
 		Churn:      roundTo(v[1], 2),
@@ -230,6 +231,7 @@ func vecToWeights(v [7]float64) config.WeightConfig {
 		Ownership:  roundTo(v[4], 2),
 		Bugfix:     roundTo(v[5], 2),
 		Complexity: roundTo(v[6], 2),
+		Coupling:   roundTo(v[7], 2),
 	}
 }
 
@@ -239,6 +241,6 @@ func roundTo(val float64, decimals int) float64 {
 }
 
 // WeightNames returns the ordered weight component names (for display).
-func WeightNames() [7]string {
+func WeightNames() [8]string {
 	return weightNames
 }
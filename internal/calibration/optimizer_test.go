@@ -162,15 +162,15 @@ func TestCalibrate_RecommendedImproves(t *testing.T) {
 
 func TestDetectionRate(t *testing.T) {
 	files := []fileFeatures{
-		{path: "buggy1.go", features: [7]float64{0.9, 0, 0, 0, 0, 0, 0}, isBugfix: true},
-		{path: "buggy2.go", features: [7]float64{0.8, 0, 0, 0, 0, 0, 0}, isBugfix: true},
-		{path: "clean1.go", features: [7]float64{0.5, 0, 0, 0, 0, 0, 0}, isBugfix: false},
-		{path: "clean2.go", features: [7]float64{0.3, 0, 0, 0, 0, 0, 0}, isBugfix: false},
-		{path: "clean3.go", features: [7]float64{0.1, 0, 0, 0, 0, 0, 0}, isBugfix: false},
+		{path: "buggy1.go", features: [8]float64{0.9, 0, 0, 0, 0, 0, 0, 0}, isBugfix: true},
+		{path: "buggy2.go", features: [8]float64{0.8, 0, 0, 0, 0, 0, 0, 0}, isBugfix: true},
+		{path: "clean1.go", features: [8]float64{0.5, 0, 0, 0, 0, 0, 0, 0}, isBugfix: false},
+		{path: "clean2.go", features: [8]float64{0.3, 0, 0, 0, 0, 0, 0, 0}, isBugfix: false},
+		{path: "clean3.go", features: [8]float64{0.1, 0, 0, 0, 0, 0, 0, 0}, isBugfix: false},
 	}
 
 	// All weight on commit dimension
-	weights := [7]float64{1, 0, 0, 0, 0, 0, 0}
+	weights := [8]float64{1, 0, 0, 0, 0, 0, 0, 0}
 
 	// Top 40% = 2 files → both bugfix files are in top 2
 	rate := detectionRate(files, weights, 40)
@@ -194,6 +194,7 @@ func TestWeightsToVec_VecToWeights_Roundtrip(t *testing.T) {
 		Ownership:  0.10,
 		Bugfix:     0.15,
 		Complexity: 0.10,
+		Coupling:   0.05,
 	}
 
 	vec := weightsToVec(original)
@@ -205,6 +206,9 @@ func TestWeightsToVec_VecToWeights_Roundtrip(t *testing.T) {
 	if math.Abs(result.Complexity-original.Complexity) > 0.001 {
 		t.Errorf("Complexity mismatch: %f != %f", result.Complexity, original.Complexity)
 	}
+	if math.Abs(result.Coupling-original.Coupling) > 0.001 {
+		t.Errorf("Coupling mismatch: %f != %f", result.Coupling, original.Coupling)
+	}
 }
 
 func TestRoundTo(t *testing.T) {
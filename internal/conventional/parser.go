@@ -0,0 +1,124 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// Commit is a commit message parsed as a Conventional Commit
+// (https://www.conventionalcommits.org): "type(scope)!: description", an optional body
+// and trailing footers.
+type Commit struct {
+	Type        string // Lowercase, e.g. "fix"
+	Scope       string // Empty when the header has none
+	Breaking    bool   // "!" in the header or a BREAKING CHANGE footer
+	Description string
+	Footers     []Footer
+}
+
+// Footer is a "Token: value" or "Token #value" trailer. Values may span lines.
+type Footer struct {
+	Token string
+	Value string
+}
+
+// breakingTokens mark breaking changes in footers. Unlike other tokens they must be
+// uppercase.
+var breakingTokens = map[string]bool{"BREAKING CHANGE": true, "BREAKING-CHANGE": true}
+
+var (
+	headerPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()\r\n]*)\))?(!)?: +(\S.*)$`)
+	footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z][A-Za-z0-9-]*)(?:: | #)(.*)$`)
+)
+
+// Parse parses a subject line and message body. ok is false when the subject is not a
+// Conventional Commit header.
+func Parse(subject, body string) (c Commit, ok bool) {
+	m := headerPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return Commit{}, false
+	}
+	c = Commit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       strings.TrimSpace(m[2]),
+		Breaking:    m[3] == "!",
+		Description: m[4],
+		Footers:     parseFooters(body),
+	}
+	for _, f := range c.Footers {
+		if breakingTokens[f.Token] {
+			c.Breaking = true
+		}
+	}
+	return c, true
+}
+
+// ParseCommit parses the message of a commit read from history.
+func ParseCommit(info git.CommitInfo) (Commit, bool) {
+	return Parse(info.Message, info.Body)
+}
+
+// Footer returns the value of the first footer with the token, compared without case.
+func (c Commit) Footer(token string) (string, bool) {
+	for _, f := range c.Footers {
+		if strings.EqualFold(f.Token, token) {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// parseFooters reads the footers from the last paragraph of the body. The paragraph is
+// only a footer block when its first line is a footer.
+func parseFooters(body string) []Footer {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if body == "" {
+		return nil
+	}
+	paragraph := body
+	if idx := strings.LastIndex(body, "\n\n"); idx != -1 {
+		paragraph = body[idx+2:]
+	}
+
+	var footers []Footer
+	for _, line := range strings.Split(paragraph, "\n") {
+		if m := footerPattern.FindStringSubmatch(line); m != nil {
+			footers = append(footers, Footer{Token: m[1], Value: strings.TrimSpace(m[2])})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil
+		}
+		last := &footers[len(footers)-1]
+		last.Value = strings.TrimSpace(last.Value + "\n" + strings.TrimSpace(line))
+	}
+	return footers
+}
+
+// Is reports whether the commit is of one of the types, compared without case.
+func (c Commit) Is(types []string) bool {
+	for _, t := range types {
+		if strings.EqualFold(strings.TrimSpace(t), c.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterNoise drops commits whose Conventional Commit type is one of noiseTypes.
+// Commits that are not Conventional Commits are kept.
+func FilterNoise(changeSets []git.CommitChangeSet, noiseTypes []string) []git.CommitChangeSet {
+	if len(noiseTypes) == 0 {
+		return changeSets
+	}
+	kept := make([]git.CommitChangeSet, 0, len(changeSets))
+	for _, cs := range changeSets {
+		if c, ok := ParseCommit(cs.Commit); ok && c.Is(noiseTypes) {
+			continue
+		}
+		kept = append(kept, cs)
+	}
+	return kept
+}
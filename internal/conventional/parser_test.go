@@ -0,0 +1,101 @@
+package conventional
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    Commit
+		wantOK  bool
+	}{
+		{
+			name:    "Type only",
+			subject: "fix: handle empty input",
+			want:    Commit{Type: "fix", Description: "handle empty input"},
+			wantOK:  true,
+		},
+		{
+			name:    "Scope and breaking marker",
+			subject: "Feat(api)!: drop v1 routes",
+			want:    Commit{Type: "feat", Scope: "api", Breaking: true, Description: "drop v1 routes"},
+			wantOK:  true,
+		},
+		{
+			name:    "Footers",
+			subject: "refactor(core): split parser",
+			body:    "Moves tokenizing out of the parser.\n\nBREAKING CHANGE: Parse takes a reader\n  instead of a string\nReviewed-by: Ann\nRefs #12",
+			want: Commit{
+				Type: "refactor", Scope: "core", Breaking: true, Description: "split parser",
+				Footers: []Footer{
+					{Token: "BREAKING CHANGE", Value: "Parse takes a reader\ninstead of a string"},
+					{Token: "Reviewed-by", Value: "Ann"},
+					{Token: "Refs", Value: "12"},
+				},
+			},
+			wantOK: true,
+		},
+		{
+			name:    "Body without footers",
+			subject: "docs: explain config",
+			body:    "First paragraph.\n\nSecond paragraph, not a footer.",
+			want:    Commit{Type: "docs", Description: "explain config"},
+			wantOK:  true,
+		},
+		{name: "Plain message", subject: "Fix crash on startup"},
+		{name: "Missing description", subject: "fix: "},
+		{name: "Merge commit", subject: "Merge branch 'main' into feature"},
+		{name: "Space before colon", subject: "fix (api): timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Parse(tt.subject, tt.body)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommit_Footer(t *testing.T) {
+	c, _ := Parse("fix: x", "Closes: #4\nBREAKING-CHANGE: config keys renamed\nbreaking change: not a footer token")
+	if v, ok := c.Footer("closes"); !ok || v != "#4" {
+		t.Errorf("Footer(closes) = %q, %v", v, ok)
+	}
+	if !c.Breaking {
+		t.Error("Breaking = false, expected the BREAKING-CHANGE footer to count")
+	}
+	if _, ok := c.Footer("Refs"); ok {
+		t.Error("Footer(Refs) found a footer that is not there")
+	}
+}
+
+func TestFilterNoise(t *testing.T) {
+	changeSets := []git.CommitChangeSet{
+		{Commit: git.CommitInfo{SHA: "1", Message: "feat: add parser"}},
+		{Commit: git.CommitInfo{SHA: "2", Message: "chore(deps): bump x"}},
+		{Commit: git.CommitInfo{SHA: "3", Message: "Style: gofmt"}},
+		{Commit: git.CommitInfo{SHA: "4", Message: "Update docs"}},
+	}
+
+	var kept []string
+	for _, cs := range FilterNoise(changeSets, []string{"chore", "style", "docs"}) {
+		kept = append(kept, cs.Commit.SHA)
+	}
+	if want := []string{"1", "4"}; !reflect.DeepEqual(kept, want) {
+		t.Errorf("FilterNoise() kept %v, want %v", kept, want)
+	}
+	if got := FilterNoise(changeSets, nil); len(got) != len(changeSets) {
+		t.Errorf("FilterNoise() without types kept %d of %d commits", len(got), len(changeSets))
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
 	"github.com/masmgr/bugspots-go/internal/git"
 )
 
@@ -16,8 +17,9 @@ type FilePair struct {
 
 // NewFilePair creates a new file pair with consistent ordering.
 func NewFilePair(a, b string) FilePair {
-	// Ensure consistent ordering (lexicographically smaller first)
-	if strings.ToLower(a) > strings.ToLower(b) {
+	// Ensure consistent ordering (case-insensitively smaller first, exact order as tie-break)
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if la > lb || (la == lb && a > b) {
 		a, b = b, a
 	}
 	return FilePair{FileA: a, FileB: b}
@@ -37,10 +39,12 @@ type ChangeCoupling struct {
 
 // CouplingAnalysisResult holds the results of coupling analysis.
 type CouplingAnalysisResult struct {
-	Couplings    []ChangeCoupling
-	TotalCommits int
-	TotalFiles   int
-	TotalPairs   int
+	Couplings     []ChangeCoupling
+	TotalCommits  int
+	TotalFiles    int
+	TotalPairs    int
+	Clusters      *ClusterAnalysisResult // Set when cluster detection is enabled
+	CrossBoundary *CrossBoundaryResult   // Set when cross-boundary reporting is enabled
 }
 
 // Analyzer analyzes change coupling between files based on co-commit patterns.
@@ -53,8 +57,75 @@ func NewAnalyzer(options config.CouplingConfig) *Analyzer {
 	return &Analyzer{options: options}
 }
 
+// renameAliases collects every rename in the history before counting, so commits
+// processed before their file's rename is seen still map to the canonical path.
+func renameAliases(changeSets []git.CommitChangeSet) *aggregation.PathAliases {
+	aliases := aggregation.NewPathAliases()
+	for _, cs := range changeSets {
+		for _, change := range cs.Changes {
+			if change.Kind == git.ChangeKindRenamed && change.OldPath != "" {
+				aliases.Rename(change.OldPath, change.Path)
+			}
+		}
+	}
+	return aliases
+}
+
 // Analyze performs coupling analysis on commit change sets.
+// Renamed files are tracked under their current path, using the same alias chain
+// as file metrics aggregation, so their history is not split across two nodes.
 func (a *Analyzer) Analyze(changeSets []git.CommitChangeSet) CouplingAnalysisResult {
+	result := a.analyzeAll(changeSets)
+	couplings := result.Couplings
+
+	// Detect clusters on every pair that passed the filters, not just the top N
+	if a.options.DetectClusters {
+		detected := DetectClusters(couplings)
+		result.Clusters = &detected
+	}
+
+	// Classify pairs by module on the full filtered set as well. Patterns are validated
+	// by NewBoundaryResolver before analysis; invalid ones here simply never match.
+	if a.options.CrossBoundary {
+		resolver := &BoundaryResolver{depth: a.options.Boundaries.Depth, modules: a.options.Boundaries.Modules}
+		analyzed := AnalyzeBoundaries(couplings, resolver)
+		result.CrossBoundary = &analyzed
+	}
+
+	// Return top N pairs
+	if len(couplings) > a.options.TopPairs {
+		result.Couplings = couplings[:a.options.TopPairs]
+	}
+
+	return result
+}
+
+// FileDegree summarizes how strongly a file is coupled to the rest of the codebase.
+type FileDegree struct {
+	Partners int     // Number of files coupled to this file (pairs passing the filters)
+	Strength float64 // Sum of Jaccard coefficients over those pairs
+}
+
+// FileDegrees computes the coupling degree of every file over all pairs that pass
+// the filters. Paths are canonical (post-rename), matching file metrics aggregation.
+func (a *Analyzer) FileDegrees(changeSets []git.CommitChangeSet) map[string]FileDegree {
+	degrees := make(map[string]FileDegree)
+	for _, c := range a.analyzeAll(changeSets).Couplings {
+		for _, path := range []string{c.FileA, c.FileB} {
+			d := degrees[path]
+			d.Partners++
+			d.Strength += c.JaccardCoefficient
+			degrees[path] = d
+		}
+	}
+	return degrees
+}
+
+// analyzeAll computes every coupling pair that passes the filters, sorted by Jaccard
+// coefficient descending, without truncating to TopPairs.
+func (a *Analyzer) analyzeAll(changeSets []git.CommitChangeSet) CouplingAnalysisResult {
+	aliases := renameAliases(changeSets)
+
 	fileCommitCounts := make(map[string]int)
 	pairCoCommitCounts := make(map[FilePair]int)
 	totalCommits := 0
@@ -69,7 +140,7 @@ func (a *Analyzer) Analyze(changeSets []git.CommitChangeSet) CouplingAnalysisRes
 				continue
 			}
 
-			path := strings.ToLower(change.Path)
+			path := aliases.Canonical(change.Path)
 			if _, seen := seenFiles[path]; seen {
 				continue
 			}
@@ -159,11 +230,6 @@ func (a *Analyzer) Analyze(changeSets []git.CommitChangeSet) CouplingAnalysisRes
 		return couplings[i].JaccardCoefficient > couplings[j].JaccardCoefficient
 	})
 
-	// Return top N pairs
-	if len(couplings) > a.options.TopPairs {
-		couplings = couplings[:a.options.TopPairs]
-	}
-
 	return CouplingAnalysisResult{
 		Couplings:    couplings,
 		TotalCommits: totalCommits,
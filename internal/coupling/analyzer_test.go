@@ -38,6 +38,7 @@ func TestNewFilePair_Symmetry(t *testing.T) {
 		{"file1.go", "file2.go"},
 		{"src/main.go", "lib/utils.go"},
 		{"AAA.go", "bbb.go"},
+		{"Readme.md", "README.md"},
 	}
 
 	for _, p := range pairs {
@@ -310,3 +311,87 @@ func TestAnalyzer_Analyze_SortedByJaccard(t *testing.T) {
 		}
 	}
 }
+
+func TestAnalyzer_Analyze_FollowsRenames(t *testing.T) {
+	renamed := makeChangeSet("3", "new/name.go", "b.go")
+	renamed.Changes[0].Kind = git.ChangeKindRenamed
+	renamed.Changes[0].OldPath = "old/name.go"
+
+	// Newest first, like git log: the rename is seen before older commits to the old path.
+	changeSets := []git.CommitChangeSet{
+		makeChangeSet("4", "new/name.go", "b.go"),
+		renamed,
+		makeChangeSet("2", "old/name.go", "b.go"),
+		makeChangeSet("1", "old/name.go", "b.go"),
+	}
+
+	result := NewAnalyzer(defaultCouplingConfig()).Analyze(changeSets)
+
+	if len(result.Couplings) != 1 {
+		t.Fatalf("expected 1 coupling, got %d: %+v", len(result.Couplings), result.Couplings)
+	}
+	c := result.Couplings[0]
+	if c.FileA != "b.go" || c.FileB != "new/name.go" {
+		t.Errorf("pair = (%s, %s), expected (b.go, new/name.go)", c.FileA, c.FileB)
+	}
+	if c.CoCommitCount != 4 {
+		t.Errorf("CoCommitCount = %d, expected 4", c.CoCommitCount)
+	}
+	if result.TotalFiles != 2 {
+		t.Errorf("TotalFiles = %d, expected 2", result.TotalFiles)
+	}
+}
+
+func TestAnalyzer_Analyze_PreservesCase(t *testing.T) {
+	changeSets := []git.CommitChangeSet{
+		makeChangeSet("1", "src/Main.go", "README.md", "Readme.md"),
+		makeChangeSet("2", "src/Main.go", "README.md"),
+	}
+
+	result := NewAnalyzer(defaultCouplingConfig()).Analyze(changeSets)
+
+	if result.TotalFiles != 3 {
+		t.Errorf("TotalFiles = %d, expected 3 (case-differing paths are distinct)", result.TotalFiles)
+	}
+	found := false
+	for _, c := range result.Couplings {
+		if c.FileA == "README.md" && c.FileB == "src/Main.go" {
+			found = true
+			if c.CoCommitCount != 2 {
+				t.Errorf("CoCommitCount = %d, expected 2", c.CoCommitCount)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected pair (README.md, src/Main.go) with original case, got %+v", result.Couplings)
+	}
+}
+
+func TestAnalyzer_FileDegrees(t *testing.T) {
+	cfg := defaultCouplingConfig()
+	cfg.TopPairs = 1
+
+	changeSets := []git.CommitChangeSet{
+		makeChangeSet("1", "hub.go", "a.go"),
+		makeChangeSet("2", "hub.go", "b.go"),
+		makeChangeSet("3", "hub.go", "c.go"),
+		makeChangeSet("4", "solo.go"),
+	}
+
+	degrees := NewAnalyzer(cfg).FileDegrees(changeSets)
+
+	// Degrees use every filtered pair, not just the top N
+	hub := degrees["hub.go"]
+	if hub.Partners != 3 {
+		t.Errorf("hub.go Partners = %d, expected 3", hub.Partners)
+	}
+	if math.Abs(hub.Strength-1.0) > 1e-9 {
+		t.Errorf("hub.go Strength = %f, expected 1.0 (3 × 1/3)", hub.Strength)
+	}
+	if degrees["a.go"].Partners != 1 {
+		t.Errorf("a.go Partners = %d, expected 1", degrees["a.go"].Partners)
+	}
+	if _, ok := degrees["solo.go"]; ok {
+		t.Error("solo.go should have no coupling degree")
+	}
+}
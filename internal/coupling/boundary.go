@@ -0,0 +1,144 @@
+package coupling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+// RootBoundary is the module name assigned to files in the repository root.
+const RootBoundary = "(root)"
+
+// BoundaryResolver maps file paths to module (boundary) names.
+type BoundaryResolver struct {
+	depth   int
+	modules []config.BoundaryModule
+}
+
+// NewBoundaryResolver creates a resolver from boundary configuration.
+// Returns an error if any module pattern is not a valid glob.
+func NewBoundaryResolver(cfg config.BoundaryConfig) (*BoundaryResolver, error) {
+	for _, m := range cfg.Modules {
+		if strings.TrimSpace(m.Name) == "" {
+			return nil, fmt.Errorf("boundary module with patterns %v has no name", m.Patterns)
+		}
+		for _, p := range m.Patterns {
+			if !doublestar.ValidatePattern(p) {
+				return nil, fmt.Errorf("invalid glob pattern %q for boundary module %q", p, m.Name)
+			}
+		}
+	}
+	return &BoundaryResolver{depth: cfg.Depth, modules: cfg.Modules}, nil
+}
+
+// Resolve returns the module name for a path. Explicit module patterns are checked
+// in order; otherwise the module is the leading directories up to the configured depth,
+// using the same subsystem logic as JIT commit metrics.
+func (r *BoundaryResolver) Resolve(path string) string {
+	normalized := strings.ReplaceAll(path, "\\", "/")
+	for _, m := range r.modules {
+		for _, p := range m.Patterns {
+			if matched, _ := doublestar.Match(p, normalized); matched {
+				return m.Name
+			}
+		}
+	}
+
+	if subsystem := aggregation.Subsystem(normalized, r.depth); subsystem != "" {
+		return subsystem
+	}
+	return RootBoundary
+}
+
+// CrossBoundaryCoupling is a coupled pair whose files belong to different modules.
+type CrossBoundaryCoupling struct {
+	ChangeCoupling
+	BoundaryA string
+	BoundaryB string
+}
+
+// BoundaryMatrix counts coupled pairs between each pair of modules.
+// Counts is symmetric and indexed like Boundaries; the diagonal holds pairs within a module.
+type BoundaryMatrix struct {
+	Boundaries []string
+	Counts     [][]int
+}
+
+// CrossBoundaryResult holds couplings that cross module boundaries.
+type CrossBoundaryResult struct {
+	Pairs      []CrossBoundaryCoupling // Sorted by Jaccard coefficient descending
+	Matrix     BoundaryMatrix
+	TotalPairs int // Number of coupled pairs considered
+}
+
+// AnalyzeBoundaries classifies coupled pairs by module and ranks those that cross
+// module boundaries by coupling strength.
+func AnalyzeBoundaries(couplings []ChangeCoupling, resolver *BoundaryResolver) CrossBoundaryResult {
+	result := CrossBoundaryResult{TotalPairs: len(couplings)}
+
+	boundaryOf := make(map[string]string)
+	resolve := func(path string) string {
+		b, ok := boundaryOf[path]
+		if !ok {
+			b = resolver.Resolve(path)
+			boundaryOf[path] = b
+		}
+		return b
+	}
+
+	type key struct{ a, b string }
+	counts := make(map[key]int)
+	names := make(map[string]struct{})
+
+	for _, c := range couplings {
+		ba, bb := resolve(c.FileA), resolve(c.FileB)
+		names[ba] = struct{}{}
+		names[bb] = struct{}{}
+
+		if ba > bb {
+			counts[key{bb, ba}]++
+		} else {
+			counts[key{ba, bb}]++
+		}
+
+		if ba != bb {
+			result.Pairs = append(result.Pairs, CrossBoundaryCoupling{
+				ChangeCoupling: c,
+				BoundaryA:      ba,
+				BoundaryB:      bb,
+			})
+		}
+	}
+
+	sort.SliceStable(result.Pairs, func(i, j int) bool {
+		return result.Pairs[i].JaccardCoefficient > result.Pairs[j].JaccardCoefficient
+	})
+
+	boundaries := make([]string, 0, len(names))
+	for n := range names {
+		boundaries = append(boundaries, n)
+	}
+	sort.Strings(boundaries)
+	index := make(map[string]int, len(boundaries))
+	for i, b := range boundaries {
+		index[b] = i
+	}
+
+	matrix := make([][]int, len(boundaries))
+	for i := range matrix {
+		matrix[i] = make([]int, len(boundaries))
+	}
+	for k, n := range counts {
+		i, j := index[k.a], index[k.b]
+		matrix[i][j] = n
+		matrix[j][i] = n
+	}
+	result.Matrix = BoundaryMatrix{Boundaries: boundaries, Counts: matrix}
+
+	return result
+}
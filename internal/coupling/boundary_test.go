@@ -0,0 +1,139 @@
+package coupling
+
+import (
+	"testing"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func TestBoundaryResolver_Resolve(t *testing.T) {
+	resolver, err := NewBoundaryResolver(config.BoundaryConfig{
+		Depth: 2,
+		Modules: []config.BoundaryModule{
+			{Name: "frontend", Patterns: []string{"web/**", "ui/**"}},
+			{Name: "schema", Patterns: []string{"**/*.sql"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewBoundaryResolver() error = %v", err)
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"web/app/main.ts", "frontend"},
+		{"ui/button.tsx", "frontend"},
+		{"db/migrations/001.sql", "schema"},
+		{"internal/scoring/file_scorer.go", "internal/scoring"},
+		{"cmd/root.go", "cmd"},
+		{"internal\\output\\json.go", "internal/output"},
+		{"README.md", RootBoundary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := resolver.Resolve(tt.path); got != tt.expected {
+				t.Errorf("Resolve(%q) = %q, expected %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewBoundaryResolver_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []config.BoundaryModule
+	}{
+		{"missing name", []config.BoundaryModule{{Patterns: []string{"src/**"}}}},
+		{"bad pattern", []config.BoundaryModule{{Name: "src", Patterns: []string{"src/[**"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBoundaryResolver(config.BoundaryConfig{Depth: 1, Modules: tt.modules}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestAnalyzeBoundaries(t *testing.T) {
+	resolver, _ := NewBoundaryResolver(config.BoundaryConfig{Depth: 1})
+	couplings := []ChangeCoupling{
+		{FileA: "api/a.go", FileB: "api/b.go", JaccardCoefficient: 0.9},
+		{FileA: "api/a.go", FileB: "db/x.go", JaccardCoefficient: 0.3},
+		{FileA: "api/b.go", FileB: "db/y.go", JaccardCoefficient: 0.6},
+		{FileA: "db/x.go", FileB: "main.go", JaccardCoefficient: 0.2},
+	}
+
+	result := AnalyzeBoundaries(couplings, resolver)
+
+	if result.TotalPairs != 4 {
+		t.Errorf("TotalPairs = %d, expected 4", result.TotalPairs)
+	}
+	if len(result.Pairs) != 3 {
+		t.Fatalf("expected 3 cross-boundary pairs, got %d", len(result.Pairs))
+	}
+	// Ranked by strength
+	if result.Pairs[0].FileB != "db/y.go" || result.Pairs[0].BoundaryA != "api" || result.Pairs[0].BoundaryB != "db" {
+		t.Errorf("unexpected strongest pair: %+v", result.Pairs[0])
+	}
+	if result.Pairs[2].BoundaryB != RootBoundary {
+		t.Errorf("expected root boundary for main.go, got %q", result.Pairs[2].BoundaryB)
+	}
+
+	expectedBoundaries := []string{RootBoundary, "api", "db"}
+	if len(result.Matrix.Boundaries) != len(expectedBoundaries) {
+		t.Fatalf("Boundaries = %v, expected %v", result.Matrix.Boundaries, expectedBoundaries)
+	}
+	for i, b := range expectedBoundaries {
+		if result.Matrix.Boundaries[i] != b {
+			t.Errorf("Boundaries[%d] = %q, expected %q", i, result.Matrix.Boundaries[i], b)
+		}
+	}
+
+	expectedCounts := [][]int{
+		{0, 0, 1},
+		{0, 1, 2},
+		{1, 2, 0},
+	}
+	for i := range expectedCounts {
+		for j := range expectedCounts[i] {
+			if result.Matrix.Counts[i][j] != expectedCounts[i][j] {
+				t.Errorf("Counts[%d][%d] = %d, expected %d", i, j, result.Matrix.Counts[i][j], expectedCounts[i][j])
+			}
+		}
+	}
+}
+
+func TestAnalyzer_Analyze_CrossBoundary(t *testing.T) {
+	cfg := defaultCouplingConfig()
+	cfg.TopPairs = 1
+	cfg.Boundaries.Depth = 1
+
+	changeSets := []git.CommitChangeSet{
+		makeChangeSet("1", "api/a.go", "api/b.go"),
+		makeChangeSet("2", "api/a.go", "api/b.go"),
+		makeChangeSet("3", "api/c.go", "db/x.go"),
+	}
+
+	result := NewAnalyzer(cfg).Analyze(changeSets)
+	if result.CrossBoundary != nil {
+		t.Fatal("CrossBoundary should be nil when disabled")
+	}
+
+	cfg.CrossBoundary = true
+	result = NewAnalyzer(cfg).Analyze(changeSets)
+	if result.CrossBoundary == nil {
+		t.Fatal("CrossBoundary should be set when enabled")
+	}
+	// Boundary analysis uses all filtered pairs, not just the top N
+	if len(result.CrossBoundary.Pairs) != 1 {
+		t.Fatalf("expected 1 cross-boundary pair despite TopPairs=1, got %d", len(result.CrossBoundary.Pairs))
+	}
+	if p := result.CrossBoundary.Pairs[0]; p.BoundaryA != "api" || p.BoundaryB != "db" {
+		t.Errorf("unexpected pair boundaries: %+v", p)
+	}
+}
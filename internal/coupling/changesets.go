@@ -0,0 +1,99 @@
+package coupling
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// GroupChangeSets merges commits into logical change sets according to the configured mode,
+// so that files committed separately as part of the same piece of work count as co-changed.
+// mergeOf maps commit SHAs to the merge that brought them in and is only used in merge mode.
+// Commits that do not belong to any group are kept as individual change sets.
+// The result is ordered like the input, by the position of each group's first commit.
+func GroupChangeSets(changeSets []git.CommitChangeSet, cfg config.ChangeSetConfig, mergeOf map[string]string) ([]git.CommitChangeSet, error) {
+	var keyOf func(i int) string
+
+	switch cfg.Mode {
+	case "", config.ChangeSetModeCommit:
+		return changeSets, nil
+	case config.ChangeSetModeAuthor:
+		keys := authorSessionKeys(changeSets, time.Duration(cfg.WindowHours)*time.Hour)
+		keyOf = func(i int) string { return keys[i] }
+	case config.ChangeSetModeIssue:
+		pattern := cfg.IssuePattern
+		if pattern == "" {
+			pattern = config.DefaultIssuePattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue pattern %q: %w", pattern, err)
+		}
+		keyOf = func(i int) string {
+			if key := re.FindString(changeSets[i].Commit.Message); key != "" {
+				return "issue:" + strings.ToUpper(key)
+			}
+			return ""
+		}
+	case config.ChangeSetModeMerge:
+		keyOf = func(i int) string {
+			if merge, ok := mergeOf[changeSets[i].Commit.SHA]; ok {
+				return "merge:" + merge
+			}
+			return ""
+		}
+	default:
+		return nil, fmt.Errorf("unknown change set mode %q (expected commit|author|issue|merge)", cfg.Mode)
+	}
+
+	grouped := make([]git.CommitChangeSet, 0, len(changeSets))
+	indexOf := make(map[string]int)
+	for i, cs := range changeSets {
+		key := keyOf(i)
+		if key == "" {
+			grouped = append(grouped, cs)
+			continue
+		}
+		if idx, ok := indexOf[key]; ok {
+			grouped[idx].Changes = append(grouped[idx].Changes, cs.Changes...)
+			continue
+		}
+		indexOf[key] = len(grouped)
+		grouped = append(grouped, git.CommitChangeSet{
+			Commit:  cs.Commit,
+			Changes: append([]git.FileChange(nil), cs.Changes...),
+		})
+	}
+
+	return grouped, nil
+}
+
+// authorSessionKeys assigns each commit to a session of consecutive commits by the same
+// author where no two neighbouring commits are more than window apart.
+func authorSessionKeys(changeSets []git.CommitChangeSet, window time.Duration) []string {
+	byAuthor := make(map[string][]int)
+	for i, cs := range changeSets {
+		author := cs.Commit.Author.ContributorKey()
+		byAuthor[author] = append(byAuthor[author], i)
+	}
+
+	keys := make([]string, len(changeSets))
+	for author, indices := range byAuthor {
+		sort.SliceStable(indices, func(a, b int) bool {
+			return changeSets[indices[a]].Commit.When.Before(changeSets[indices[b]].Commit.When)
+		})
+		session := 0
+		for n, i := range indices {
+			if n > 0 && changeSets[i].Commit.When.Sub(changeSets[indices[n-1]].Commit.When) > window {
+				session++
+			}
+			keys[i] = fmt.Sprintf("author:%s:%d", author, session)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,179 @@
+package coupling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func commitAt(sha, email, message string, when time.Time, files ...string) git.CommitChangeSet {
+	cs := makeChangeSet(sha, files...)
+	cs.Commit.Author = git.AuthorInfo{Name: email, Email: email}
+	cs.Commit.Message = message
+	cs.Commit.When = when
+	return cs
+}
+
+func groupedFiles(cs git.CommitChangeSet) []string {
+	files := make([]string, len(cs.Changes))
+	for i, c := range cs.Changes {
+		files[i] = c.Path
+	}
+	return files
+}
+
+func TestGroupChangeSets_CommitMode(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	changeSets := []git.CommitChangeSet{
+		commitAt("1", "a@x", "one", base, "a.go"),
+		commitAt("2", "a@x", "two", base.Add(time.Minute), "b.go"),
+	}
+
+	grouped, err := GroupChangeSets(changeSets, config.ChangeSetConfig{Mode: config.ChangeSetModeCommit}, nil)
+	if err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+	if len(grouped) != 2 {
+		t.Errorf("expected commits to stay separate, got %d change sets", len(grouped))
+	}
+}
+
+func TestGroupChangeSets_AuthorMode(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	// Input is newest first, like git log.
+	changeSets := []git.CommitChangeSet{
+		commitAt("5", "a@x", "later", base.Add(10*time.Hour), "d.go"),
+		commitAt("4", "b@x", "other author", base.Add(90*time.Minute), "c.go"),
+		commitAt("3", "A@X", "three", base.Add(2*time.Hour), "c.go"),
+		commitAt("2", "a@x", "two", base.Add(time.Hour), "b.go"),
+		commitAt("1", "a@x", "one", base, "a.go"),
+	}
+
+	grouped, err := GroupChangeSets(changeSets, config.ChangeSetConfig{Mode: config.ChangeSetModeAuthor, WindowHours: 1}, nil)
+	if err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+
+	if len(grouped) != 3 {
+		t.Fatalf("expected 3 change sets, got %d", len(grouped))
+	}
+	if files := groupedFiles(grouped[0]); len(files) != 1 || files[0] != "d.go" {
+		t.Errorf("change set 0 = %v, expected [d.go] (outside window)", files)
+	}
+	if files := groupedFiles(grouped[1]); len(files) != 1 || files[0] != "c.go" {
+		t.Errorf("change set 1 = %v, expected [c.go] from another author", files)
+	}
+	if files := groupedFiles(grouped[2]); len(files) != 3 {
+		t.Errorf("change set 2 = %v, expected three files from one session", files)
+	}
+}
+
+func TestGroupChangeSets_IssueMode(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	changeSets := []git.CommitChangeSet{
+		commitAt("1", "a@x", "PROJ-12 update api", base, "api.go"),
+		commitAt("2", "b@x", "proj-12: update client", base.Add(48*time.Hour), "client.go"),
+		commitAt("3", "a@x", "Fix #7", base, "x.go"),
+		commitAt("4", "a@x", "no reference", base, "y.go"),
+		commitAt("5", "c@x", "follow-up for #7", base, "z.go"),
+	}
+
+	cfg := config.ChangeSetConfig{Mode: config.ChangeSetModeIssue, IssuePattern: `(?i)\bPROJ-\d+\b|#\d+\b`}
+	grouped, err := GroupChangeSets(changeSets, cfg, nil)
+	if err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+
+	if len(grouped) != 3 {
+		t.Fatalf("expected 3 change sets, got %d", len(grouped))
+	}
+	if files := groupedFiles(grouped[0]); len(files) != 2 {
+		t.Errorf("PROJ-12 change set = %v, expected 2 files", files)
+	}
+	if files := groupedFiles(grouped[1]); len(files) != 2 || files[1] != "z.go" {
+		t.Errorf("#7 change set = %v, expected [x.go z.go]", files)
+	}
+}
+
+func TestGroupChangeSets_MergeMode(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	changeSets := []git.CommitChangeSet{
+		commitAt("f2", "a@x", "b", base, "b.go"),
+		commitAt("d1", "a@x", "direct", base, "c.go"),
+		commitAt("f1", "b@x", "a", base, "a.go"),
+	}
+	mergeOf := map[string]string{"f1": "m1", "f2": "m1"}
+
+	grouped, err := GroupChangeSets(changeSets, config.ChangeSetConfig{Mode: config.ChangeSetModeMerge}, mergeOf)
+	if err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+	if len(grouped) != 2 {
+		t.Fatalf("expected 2 change sets, got %d", len(grouped))
+	}
+	if files := groupedFiles(grouped[0]); len(files) != 2 {
+		t.Errorf("merge change set = %v, expected 2 files", files)
+	}
+}
+
+func TestGroupChangeSets_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ChangeSetConfig
+	}{
+		{"unknown mode", config.ChangeSetConfig{Mode: "weekly"}},
+		{"bad issue pattern", config.ChangeSetConfig{Mode: config.ChangeSetModeIssue, IssuePattern: "("}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GroupChangeSets(nil, tt.cfg, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGroupChangeSets_DoesNotMutateInput(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	changeSets := []git.CommitChangeSet{
+		commitAt("2", "a@x", "two", base.Add(time.Minute), "b.go"),
+		commitAt("1", "a@x", "one", base, "a.go"),
+	}
+
+	if _, err := GroupChangeSets(changeSets, config.ChangeSetConfig{Mode: config.ChangeSetModeAuthor, WindowHours: 1}, nil); err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+	if len(changeSets[0].Changes) != 1 {
+		t.Errorf("input change set was modified: %v", groupedFiles(changeSets[0]))
+	}
+}
+
+func TestAnalyzer_Analyze_GroupedChangeSets(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	// One file per commit: no coupling unless commits are grouped.
+	changeSets := []git.CommitChangeSet{
+		commitAt("4", "a@x", "d", base.Add(25*time.Hour), "b.go"),
+		commitAt("3", "a@x", "c", base.Add(24*time.Hour), "a.go"),
+		commitAt("2", "a@x", "b", base.Add(time.Hour), "b.go"),
+		commitAt("1", "a@x", "a", base, "a.go"),
+	}
+
+	if result := NewAnalyzer(defaultCouplingConfig()).Analyze(changeSets); len(result.Couplings) != 0 {
+		t.Fatalf("expected no couplings per commit, got %d", len(result.Couplings))
+	}
+
+	grouped, err := GroupChangeSets(changeSets, config.ChangeSetConfig{Mode: config.ChangeSetModeAuthor, WindowHours: 2}, nil)
+	if err != nil {
+		t.Fatalf("GroupChangeSets() error = %v", err)
+	}
+	result := NewAnalyzer(defaultCouplingConfig()).Analyze(grouped)
+	if len(result.Couplings) != 1 {
+		t.Fatalf("expected 1 coupling, got %d", len(result.Couplings))
+	}
+	if result.Couplings[0].CoCommitCount != 2 {
+		t.Errorf("CoCommitCount = %d, expected 2", result.Couplings[0].CoCommitCount)
+	}
+}
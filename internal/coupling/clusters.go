@@ -0,0 +1,349 @@
+package coupling
+
+import (
+	"math"
+	"path"
+	"sort"
+)
+
+// Cluster is a group of files that tend to change together, found by community
+// detection on the co-change graph.
+type Cluster struct {
+	ID             int
+	Files          []string
+	Directories    []string // Distinct parent directories of the member files
+	InternalWeight float64  // Sum of coupling weights between member files
+	ExternalWeight float64  // Sum of coupling weights from members to non-members
+	Cohesion       float64  // InternalWeight / (InternalWeight + ExternalWeight)
+}
+
+// SpansDirectories reports whether the cluster's files live in more than one directory,
+// i.e. the cluster is a logical module that cuts across the directory structure.
+func (c Cluster) SpansDirectories() bool {
+	return len(c.Directories) > 1
+}
+
+// BridgeFile is a file coupled to files in clusters other than its own.
+type BridgeFile struct {
+	Path              string
+	ClusterID         int     // 0 when the file does not belong to any reported cluster
+	ConnectedClusters int     // Number of other clusters this file is coupled to
+	ExternalRatio     float64 // Share of the file's coupling weight going to other clusters
+}
+
+// ClusterAnalysisResult holds the result of community detection on the co-change graph.
+type ClusterAnalysisResult struct {
+	Clusters   []Cluster
+	Bridges    []BridgeFile
+	Modularity float64
+}
+
+// coChangeGraph is an undirected weighted graph over file indices.
+// adjacency[i][j] == adjacency[j][i]; self-loops appear only in aggregated graphs.
+type coChangeGraph struct {
+	adjacency []map[int]float64
+}
+
+func (g *coChangeGraph) degree(i int) float64 {
+	var k float64
+	for _, w := range g.adjacency[i] {
+		k += w
+	}
+	return k
+}
+
+// DetectClusters builds a co-change graph weighted by Jaccard coefficient and
+// partitions it with the Louvain method. Singleton communities are not reported.
+func DetectClusters(couplings []ChangeCoupling) ClusterAnalysisResult {
+	if len(couplings) == 0 {
+		return ClusterAnalysisResult{}
+	}
+
+	// Assign stable node indices in path order so results are deterministic.
+	pathSet := make(map[string]struct{})
+	for _, c := range couplings {
+		pathSet[c.FileA] = struct{}{}
+		pathSet[c.FileB] = struct{}{}
+	}
+	paths := make([]string, 0, len(pathSet))
+	for p := range pathSet {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	index := make(map[string]int, len(paths))
+	for i, p := range paths {
+		index[p] = i
+	}
+
+	graph := &coChangeGraph{adjacency: make([]map[int]float64, len(paths))}
+	for i := range graph.adjacency {
+		graph.adjacency[i] = make(map[int]float64)
+	}
+	for _, c := range couplings {
+		a, b := index[c.FileA], index[c.FileB]
+		if a == b || c.JaccardCoefficient <= 0 {
+			continue
+		}
+		graph.adjacency[a][b] += c.JaccardCoefficient
+		graph.adjacency[b][a] += c.JaccardCoefficient
+	}
+
+	membership := louvain(graph)
+	return summarizeClusters(graph, paths, membership)
+}
+
+// louvain returns a community index for every node of g.
+func louvain(g *coChangeGraph) []int {
+	n := len(g.adjacency)
+	membership := make([]int, n)
+	for i := range membership {
+		membership[i] = i
+	}
+
+	current := g
+	// nodeOf maps original nodes to nodes of the current (aggregated) graph.
+	nodeOf := make([]int, n)
+	copy(nodeOf, membership)
+
+	for level := 0; level < 32; level++ {
+		community, moved := localMoving(current)
+		if !moved {
+			break
+		}
+		community = renumber(community)
+		for i := range nodeOf {
+			nodeOf[i] = community[nodeOf[i]]
+		}
+		current = aggregate(current, community)
+	}
+
+	copy(membership, nodeOf)
+	return membership
+}
+
+// localMoving greedily moves single nodes between communities while modularity improves.
+func localMoving(g *coChangeGraph) ([]int, bool) {
+	n := len(g.adjacency)
+	community := make([]int, n)
+	degree := make([]float64, n)
+	total := make([]float64, n) // Sum of degrees per community
+	var m2 float64
+	for i := 0; i < n; i++ {
+		community[i] = i
+		degree[i] = g.degree(i)
+		total[i] = degree[i]
+		m2 += degree[i]
+	}
+	if m2 == 0 {
+		return community, false
+	}
+
+	movedAny := false
+	for pass := 0; pass < 100; pass++ {
+		moved := false
+		for i := 0; i < n; i++ {
+			own := community[i]
+			total[own] -= degree[i]
+
+			// Weight from i to each neighbouring community (self-loops excluded).
+			links := make(map[int]float64)
+			for j, w := range g.adjacency[i] {
+				if j != i {
+					links[community[j]] += w
+				}
+			}
+
+			best := own
+			bestGain := links[own] - total[own]*degree[i]/m2
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+			for _, c := range candidates {
+				gain := links[c] - total[c]*degree[i]/m2
+				if gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+
+			total[best] += degree[i]
+			if best != own {
+				community[i] = best
+				moved = true
+				movedAny = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	return community, movedAny
+}
+
+// renumber maps community labels to a dense 0..k-1 range in order of first appearance.
+func renumber(community []int) []int {
+	labels := make(map[int]int)
+	out := make([]int, len(community))
+	for i, c := range community {
+		id, ok := labels[c]
+		if !ok {
+			id = len(labels)
+			labels[c] = id
+		}
+		out[i] = id
+	}
+	return out
+}
+
+// aggregate collapses each community into a single node, keeping internal weight as a self-loop.
+func aggregate(g *coChangeGraph, community []int) *coChangeGraph {
+	size := 0
+	for _, c := range community {
+		if c+1 > size {
+			size = c + 1
+		}
+	}
+	next := &coChangeGraph{adjacency: make([]map[int]float64, size)}
+	for i := range next.adjacency {
+		next.adjacency[i] = make(map[int]float64)
+	}
+	for i, neighbours := range g.adjacency {
+		for j, w := range neighbours {
+			next.adjacency[community[i]][community[j]] += w
+		}
+	}
+	return next
+}
+
+func summarizeClusters(g *coChangeGraph, paths []string, membership []int) ClusterAnalysisResult {
+	members := make(map[int][]int)
+	for i, c := range membership {
+		members[c] = append(members[c], i)
+	}
+
+	// Modularity of the final partition on the original graph.
+	var m2 float64
+	degree := make([]float64, len(paths))
+	for i := range paths {
+		degree[i] = g.degree(i)
+		m2 += degree[i]
+	}
+	var modularity float64
+	if m2 > 0 {
+		for _, nodes := range members {
+			var in, tot float64
+			for _, i := range nodes {
+				tot += degree[i]
+				for j, w := range g.adjacency[i] {
+					if membership[j] == membership[i] {
+						in += w
+					}
+				}
+			}
+			modularity += in/m2 - (tot/m2)*(tot/m2)
+		}
+		if math.Abs(modularity) < 1e-12 {
+			modularity = 0
+		}
+	}
+
+	result := ClusterAnalysisResult{Modularity: modularity}
+
+	clusterIDs := make(map[int]int) // community -> reported cluster ID
+	type pending struct {
+		community int
+		cluster   Cluster
+	}
+	var found []pending
+	for community, nodes := range members {
+		if len(nodes) < 2 {
+			continue
+		}
+		cl := Cluster{}
+		dirs := make(map[string]struct{})
+		for _, i := range nodes {
+			cl.Files = append(cl.Files, paths[i])
+			dirs[path.Dir(paths[i])] = struct{}{}
+			for j, w := range g.adjacency[i] {
+				if membership[j] == community {
+					cl.InternalWeight += w / 2 // each internal edge is visited from both ends
+				} else {
+					cl.ExternalWeight += w
+				}
+			}
+		}
+		sort.Strings(cl.Files)
+		for d := range dirs {
+			cl.Directories = append(cl.Directories, d)
+		}
+		sort.Strings(cl.Directories)
+		if sum := cl.InternalWeight + cl.ExternalWeight; sum > 0 {
+			cl.Cohesion = cl.InternalWeight / sum
+		}
+		found = append(found, pending{community: community, cluster: cl})
+	}
+
+	// Largest clusters first, then by internal weight, then by first file for stability.
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i].cluster, found[j].cluster
+		if len(a.Files) != len(b.Files) {
+			return len(a.Files) > len(b.Files)
+		}
+		if math.Abs(a.InternalWeight-b.InternalWeight) > 1e-9 {
+			return a.InternalWeight > b.InternalWeight
+		}
+		return a.Files[0] < b.Files[0]
+	})
+	for i := range found {
+		found[i].cluster.ID = i + 1
+		clusterIDs[found[i].community] = i + 1
+		result.Clusters = append(result.Clusters, found[i].cluster)
+	}
+
+	for i, p := range paths {
+		own, clustered := clusterIDs[membership[i]] // own is 0 for unclustered files
+		others := make(map[int]struct{})
+		var external, total float64
+		for j, w := range g.adjacency[i] {
+			total += w
+			if membership[j] == membership[i] {
+				continue
+			}
+			external += w
+			if id, ok := clusterIDs[membership[j]]; ok {
+				others[id] = struct{}{}
+			}
+		}
+		// A clustered file bridges as soon as it reaches another cluster; an
+		// unclustered file only when it links at least two clusters together.
+		minOthers := 1
+		if !clustered {
+			minOthers = 2
+		}
+		if len(others) < minOthers || total == 0 {
+			continue
+		}
+		result.Bridges = append(result.Bridges, BridgeFile{
+			Path:              p,
+			ClusterID:         own,
+			ConnectedClusters: len(others),
+			ExternalRatio:     external / total,
+		})
+	}
+
+	sort.Slice(result.Bridges, func(i, j int) bool {
+		a, b := result.Bridges[i], result.Bridges[j]
+		if a.ConnectedClusters != b.ConnectedClusters {
+			return a.ConnectedClusters > b.ConnectedClusters
+		}
+		// Ratios are summed in map order, so ignore rounding noise when comparing.
+		if math.Abs(a.ExternalRatio-b.ExternalRatio) > 1e-9 {
+			return a.ExternalRatio > b.ExternalRatio
+		}
+		return a.Path < b.Path
+	})
+
+	return result
+}
@@ -0,0 +1,119 @@
+package coupling
+
+import (
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+func clique(files ...string) []ChangeCoupling {
+	var out []ChangeCoupling
+	for i := 0; i < len(files); i++ {
+		for j := i + 1; j < len(files); j++ {
+			out = append(out, ChangeCoupling{FileA: files[i], FileB: files[j], JaccardCoefficient: 0.9})
+		}
+	}
+	return out
+}
+
+func TestDetectClusters_Empty(t *testing.T) {
+	result := DetectClusters(nil)
+	if len(result.Clusters) != 0 || len(result.Bridges) != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestDetectClusters_TwoCommunities(t *testing.T) {
+	couplings := append(clique("api/a.go", "api/b.go", "api/c.go"), clique("db/x.go", "db/y.go", "db/z.go")...)
+	// Weak link between the two groups
+	couplings = append(couplings, ChangeCoupling{FileA: "api/c.go", FileB: "db/x.go", JaccardCoefficient: 0.1})
+
+	result := DetectClusters(couplings)
+
+	if len(result.Clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %+v", len(result.Clusters), result.Clusters)
+	}
+	for _, cl := range result.Clusters {
+		if len(cl.Files) != 3 {
+			t.Errorf("cluster %d has %d files, expected 3", cl.ID, len(cl.Files))
+		}
+		if cl.SpansDirectories() {
+			t.Errorf("cluster %d should not span directories: %v", cl.ID, cl.Directories)
+		}
+		if cl.Cohesion <= 0.9 || cl.Cohesion >= 1.0 {
+			t.Errorf("cluster %d cohesion = %f, expected in (0.9, 1.0)", cl.ID, cl.Cohesion)
+		}
+	}
+	if result.Modularity <= 0 {
+		t.Errorf("Modularity = %f, expected > 0", result.Modularity)
+	}
+
+	if len(result.Bridges) != 2 {
+		t.Fatalf("expected 2 bridge files, got %d: %+v", len(result.Bridges), result.Bridges)
+	}
+	if result.Bridges[0].Path != "api/c.go" || result.Bridges[1].Path != "db/x.go" {
+		t.Errorf("unexpected bridges: %+v", result.Bridges)
+	}
+	if result.Bridges[0].ConnectedClusters != 1 {
+		t.Errorf("ConnectedClusters = %d, expected 1", result.Bridges[0].ConnectedClusters)
+	}
+}
+
+func TestDetectClusters_CrossDirectoryModule(t *testing.T) {
+	result := DetectClusters(clique("api/user.go", "db/user.sql", "web/user.ts"))
+
+	if len(result.Clusters) != 1 {
+		t.Fatalf("expected 1 cluster, got %d", len(result.Clusters))
+	}
+	cl := result.Clusters[0]
+	if !cl.SpansDirectories() || len(cl.Directories) != 3 {
+		t.Errorf("expected cluster to span 3 directories, got %v", cl.Directories)
+	}
+	if cl.Cohesion != 1.0 {
+		t.Errorf("Cohesion = %f, expected 1.0 for an isolated cluster", cl.Cohesion)
+	}
+}
+
+func TestDetectClusters_Deterministic(t *testing.T) {
+	couplings := append(clique("a.go", "b.go", "c.go"), clique("d.go", "e.go", "f.go")...)
+	couplings = append(couplings, ChangeCoupling{FileA: "c.go", FileB: "d.go", JaccardCoefficient: 0.2})
+
+	first := DetectClusters(couplings)
+	for i := 0; i < 10; i++ {
+		again := DetectClusters(couplings)
+		if len(again.Clusters) != len(first.Clusters) {
+			t.Fatalf("cluster count changed between runs: %d vs %d", len(again.Clusters), len(first.Clusters))
+		}
+		for j := range again.Clusters {
+			if again.Clusters[j].Files[0] != first.Clusters[j].Files[0] {
+				t.Fatalf("cluster order changed between runs")
+			}
+		}
+	}
+}
+
+func TestAnalyzer_Analyze_DetectClusters(t *testing.T) {
+	cfg := defaultCouplingConfig()
+	cfg.TopPairs = 1
+
+	changeSets := []git.CommitChangeSet{
+		makeChangeSet("1", "a.go", "b.go"),
+		makeChangeSet("2", "a.go", "b.go"),
+		makeChangeSet("3", "c.go", "d.go"),
+	}
+
+	result := NewAnalyzer(cfg).Analyze(changeSets)
+	if result.Clusters != nil {
+		t.Fatal("Clusters should be nil when DetectClusters is disabled")
+	}
+
+	cfg.DetectClusters = true
+	result = NewAnalyzer(cfg).Analyze(changeSets)
+	if result.Clusters == nil {
+		t.Fatal("Clusters should be set when DetectClusters is enabled")
+	}
+	// Clustering uses all filtered pairs, not just the top N
+	if len(result.Clusters.Clusters) != 2 {
+		t.Errorf("expected 2 clusters despite TopPairs=1, got %d", len(result.Clusters.Clusters))
+	}
+}
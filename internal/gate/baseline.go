@@ -0,0 +1,156 @@
+package gate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// DefaultBaselinePath is the baseline file written by the baseline command.
+const DefaultBaselinePath = ".bugspots-baseline.json"
+
+// baselineVersion is the file format version written to new baselines.
+const baselineVersion = 1
+
+// suppressionDateLayout is the format of Suppression.Expires.
+const suppressionDateLayout = "2006-01-02"
+
+// Baseline records accepted hotspots and suppressed paths. Files listed in it pass the
+// threshold and high-risk checks until their score rises beyond the tolerance.
+type Baseline struct {
+	Version      int            `json:"version"`
+	GeneratedAt  time.Time      `json:"generatedAt"`
+	Files        []BaselineFile `json:"files"`
+	Suppressions []Suppression  `json:"suppressions,omitempty"`
+}
+
+// BaselineFile is an accepted hotspot and the score it was accepted at.
+type BaselineFile struct {
+	Path      string  `json:"path"`
+	RiskScore float64 `json:"riskScore"`
+	RiskLevel string  `json:"riskLevel"`
+}
+
+// Suppression excludes matching files from every gate check until it expires.
+type Suppression struct {
+	Path    string `json:"path"`    // File path or glob pattern (** matches directories)
+	Reason  string `json:"reason"`  // Why the hotspot is accepted
+	Expires string `json:"expires"` // Last day the suppression applies (YYYY-MM-DD)
+}
+
+// NewBaseline records the items at or above minLevel; an empty level records every
+// item. Suppressions are carried over by the caller.
+func NewBaseline(items []scoring.FileRiskItem, minLevel config.RiskLevel, generatedAt time.Time) *Baseline {
+	b := &Baseline{Version: baselineVersion, GeneratedAt: generatedAt, Files: []BaselineFile{}}
+	for _, item := range items {
+		if levelRank(item.RiskLevel) < levelRank(minLevel) {
+			continue
+		}
+		b.Files = append(b.Files, BaselineFile{Path: item.Path, RiskScore: item.RiskScore, RiskLevel: string(item.RiskLevel)})
+	}
+	return b
+}
+
+func levelRank(level config.RiskLevel) int {
+	switch level {
+	case config.RiskLevelHigh:
+		return 2
+	case config.RiskLevelMedium:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// LoadBaseline reads and validates a baseline file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if err := b.validate(); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return &b, nil
+}
+
+func (b *Baseline) validate() error {
+	if b.Version != baselineVersion {
+		return fmt.Errorf("unsupported version %d (expected %d)", b.Version, baselineVersion)
+	}
+	for i, f := range b.Files {
+		if f.Path == "" {
+			return fmt.Errorf("files[%d]: path is required", i)
+		}
+	}
+	for i, s := range b.Suppressions {
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("suppressions[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (s Suppression) validate() error {
+	if s.Path == "" {
+		return errors.New("path is required")
+	}
+	if !doublestar.ValidatePattern(s.Path) {
+		return fmt.Errorf("invalid path pattern %q", s.Path)
+	}
+	if s.Reason == "" {
+		return fmt.Errorf("%s: reason is required", s.Path)
+	}
+	if _, err := time.Parse(suppressionDateLayout, s.Expires); err != nil {
+		return fmt.Errorf("%s: expires must be a date (YYYY-MM-DD), got %q", s.Path, s.Expires)
+	}
+	return nil
+}
+
+// Expired reports whether the suppression no longer applies at now. A suppression
+// expiring on a day still applies during that day.
+func (s Suppression) Expired(now time.Time) bool {
+	expires, err := time.ParseInLocation(suppressionDateLayout, s.Expires, now.Location())
+	if err != nil {
+		return true
+	}
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// Matches reports whether the suppression covers path.
+func (s Suppression) Matches(path string) bool {
+	matched, _ := doublestar.Match(s.Path, path)
+	return matched
+}
+
+// Scores returns the accepted score of each baseline file by path.
+func (b *Baseline) Scores() map[string]float64 {
+	scores := make(map[string]float64, len(b.Files))
+	for _, f := range b.Files {
+		scores[f.Path] = f.RiskScore
+	}
+	return scores
+}
+
+// WriteFile writes the baseline as indented JSON.
+func (b *Baseline) WriteFile(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
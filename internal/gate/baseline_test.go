@@ -0,0 +1,130 @@
+package gate
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+func TestNewBaseline(t *testing.T) {
+	generatedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		minLevel config.RiskLevel
+		want     int
+	}{
+		{"High", config.RiskLevelHigh, 2},
+		{"Medium", config.RiskLevelMedium, 3},
+		{"All", "", 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBaseline(newTestItems(), tt.minLevel, generatedAt)
+			if len(b.Files) != tt.want {
+				t.Errorf("files = %d, want %d", len(b.Files), tt.want)
+			}
+			if b.Version != baselineVersion || !b.GeneratedAt.Equal(generatedAt) {
+				t.Errorf("header = %d %v", b.Version, b.GeneratedAt)
+			}
+		})
+	}
+}
+
+func TestBaseline_RoundTrip(t *testing.T) {
+	path := t.TempDir() + "/" + DefaultBaselinePath
+	b := NewBaseline(newTestItems(), config.RiskLevelHigh, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	b.Suppressions = []Suppression{{Path: "legacy/**", Reason: "rewrite planned", Expires: "2026-12-31"}}
+	if err := b.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	loaded, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline failed: %v", err)
+	}
+	scores := loaded.Scores()
+	if len(scores) != 2 || scores["src/hot.go"] != 0.85 || scores["src/warm.go"] != 0.72 {
+		t.Errorf("scores = %v", scores)
+	}
+	if len(loaded.Suppressions) != 1 || loaded.Suppressions[0] != b.Suppressions[0] {
+		t.Errorf("suppressions = %+v", loaded.Suppressions)
+	}
+}
+
+func TestLoadBaseline_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantMsg string
+	}{
+		{"NotJSON", `baseline`, "failed to parse baseline"},
+		{"Version", `{"version":2,"files":[]}`, "unsupported version 2"},
+		{"FilePath", `{"version":1,"files":[{"riskScore":0.8}]}`, "files[0]: path is required"},
+		{"SuppressionPath", `{"version":1,"suppressions":[{"reason":"r","expires":"2026-01-01"}]}`, "suppressions[0]: path is required"},
+		{"SuppressionPattern", `{"version":1,"suppressions":[{"path":"src/[a","reason":"r","expires":"2026-01-01"}]}`, "invalid path pattern"},
+		{"SuppressionReason", `{"version":1,"suppressions":[{"path":"a.go","expires":"2026-01-01"}]}`, "a.go: reason is required"},
+		{"SuppressionExpires", `{"version":1,"suppressions":[{"path":"a.go","reason":"r","expires":"next year"}]}`, "expires must be a date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/baseline.json"
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write baseline: %v", err)
+			}
+			_, err := LoadBaseline(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("err = %v, want error containing %q", err, tt.wantMsg)
+			}
+		})
+	}
+
+	if _, err := LoadBaseline(t.TempDir() + "/missing.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestSuppression_Expired(t *testing.T) {
+	s := Suppression{Path: "a.go", Reason: "r", Expires: "2026-06-30"}
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"Before", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), false},
+		{"LastDay", time.Date(2026, 6, 30, 23, 59, 0, 0, time.UTC), false},
+		{"After", time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Expired(tt.now); got != tt.want {
+				t.Errorf("Expired(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppression_Matches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"src/hot.go", "src/hot.go", true},
+		{"src/hot.go", "src/warm.go", false},
+		{"legacy/**", "legacy/a/b.go", true},
+		{"*.go", "src/hot.go", false},
+		{"**/*.go", "src/hot.go", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"_"+tt.path, func(t *testing.T) {
+			if got := (Suppression{Path: tt.pattern}).Matches(tt.path); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// Check names used in Result.Checks.
+const (
+	CheckThreshold        = "threshold"         // A file scores at or above the threshold
+	CheckChangedThreshold = "changed-threshold" // The threshold check in --diff mode
+	CheckMaxHighRisk      = "max-high-risk"     // Too many high-risk files
+	CheckScoreIncrease    = "score-increase"    // A file's score rose too far over the baseline report
+)
+
+// Scope values for Result.Scope.
+const (
+	ScopeAll     = "all"
+	ScopeChanged = "changed"
+)
+
+// maxMessagePaths limits how many files are named in a failure message.
+const maxMessagePaths = 3
+
+// Options selects the gate checks to run.
+type Options struct {
+	Threshold   float64            // Fail when a file scores at or above this; 0 disables the check
+	MaxHighRisk int                // Fail when more files than this are high risk; negative disables the check
+	Baseline    map[string]float64 // Previous scores by path; nil disables the increase check
+	MaxIncrease float64            // Score increase over Baseline tolerated before failing
+	Changed     bool               // Items are the files changed in --diff mode
+
+	// AcceptBaseline lets files in Baseline that rose no more than MaxIncrease pass the
+	// threshold and high-risk checks, so only new or worsening hotspots fail.
+	AcceptBaseline bool
+	Suppressions   []Suppression // Files matching an unexpired suppression skip every check
+	Now            time.Time     // Reference time for suppression expiry
+}
+
+// Enabled reports whether any check is configured.
+func (o Options) Enabled() bool {
+	return o.Threshold > 0 || o.MaxHighRisk >= 0 || o.Baseline != nil
+}
+
+// Result is the machine-readable outcome of a gate evaluation.
+type Result struct {
+	Passed              bool             `json:"passed"`
+	Scope               string           `json:"scope"`
+	FilesEvaluated      int              `json:"filesEvaluated"`
+	Checks              []Check          `json:"checks"`
+	Suppressed          []SuppressedFile `json:"suppressed,omitempty"`
+	ExpiredSuppressions []Suppression    `json:"expiredSuppressions,omitempty"`
+}
+
+// Check is the outcome of one gate check. Limit is the configured limit and Actual the
+// observed value it was compared with (highest score, high-risk count or largest increase).
+// Accepted counts files that would have failed but are recorded in the baseline; they
+// do not contribute to Actual.
+type Check struct {
+	Name       string      `json:"name"`
+	Passed     bool        `json:"passed"`
+	Limit      float64     `json:"limit"`
+	Actual     float64     `json:"actual"`
+	Accepted   int         `json:"accepted,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// SuppressedFile is a file excluded from the checks by a suppression.
+type SuppressedFile struct {
+	Path      string  `json:"path"`
+	RiskScore float64 `json:"riskScore"`
+	Reason    string  `json:"reason"`
+	Expires   string  `json:"expires"`
+}
+
+// Violation is a file that made a check fail.
+type Violation struct {
+	Path          string   `json:"path"`
+	RiskScore     float64  `json:"riskScore"`
+	RiskLevel     string   `json:"riskLevel"`
+	BaselineScore *float64 `json:"baselineScore,omitempty"`
+}
+
+// Evaluate runs the configured checks over items, which are expected in descending
+// score order as returned by the file scorer.
+func Evaluate(items []scoring.FileRiskItem, opts Options) Result {
+	result := Result{Passed: true, Scope: ScopeAll, Checks: []Check{}}
+	if opts.Changed {
+		result.Scope = ScopeChanged
+	}
+	items = result.suppress(items, opts)
+	result.FilesEvaluated = len(items)
+
+	if opts.Threshold > 0 {
+		result.add(thresholdCheck(items, opts))
+	}
+	if opts.MaxHighRisk >= 0 {
+		result.add(highRiskCheck(items, opts))
+	}
+	if opts.Baseline != nil {
+		result.add(increaseCheck(items, opts.Baseline, opts.MaxIncrease))
+	}
+	return result
+}
+
+func (r *Result) add(c Check) {
+	r.Checks = append(r.Checks, c)
+	if !c.Passed {
+		r.Passed = false
+	}
+}
+
+// suppress removes the items covered by an unexpired suppression, recording them and
+// every expired suppression in the result.
+func (r *Result) suppress(items []scoring.FileRiskItem, opts Options) []scoring.FileRiskItem {
+	var active []Suppression
+	for _, s := range opts.Suppressions {
+		if s.Expired(opts.Now) {
+			r.ExpiredSuppressions = append(r.ExpiredSuppressions, s)
+		} else {
+			active = append(active, s)
+		}
+	}
+	if len(active) == 0 {
+		return items
+	}
+
+	kept := make([]scoring.FileRiskItem, 0, len(items))
+	for _, item := range items {
+		if s, ok := matchSuppression(active, item.Path); ok {
+			r.Suppressed = append(r.Suppressed, SuppressedFile{Path: item.Path, RiskScore: item.RiskScore, Reason: s.Reason, Expires: s.Expires})
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+func matchSuppression(suppressions []Suppression, path string) (Suppression, bool) {
+	for _, s := range suppressions {
+		if s.Matches(path) {
+			return s, true
+		}
+	}
+	return Suppression{}, false
+}
+
+// accepted reports whether item is a baseline hotspot that has not risen beyond the tolerance.
+func accepted(item scoring.FileRiskItem, opts Options) bool {
+	if !opts.AcceptBaseline {
+		return false
+	}
+	prev, ok := opts.Baseline[item.Path]
+	return ok && item.RiskScore-prev <= opts.MaxIncrease
+}
+
+func thresholdCheck(items []scoring.FileRiskItem, opts Options) Check {
+	name := CheckThreshold
+	if opts.Changed {
+		name = CheckChangedThreshold
+	}
+	c := Check{Name: name, Limit: opts.Threshold}
+	for _, item := range items {
+		if item.RiskScore < opts.Threshold {
+			c.Actual = max(c.Actual, item.RiskScore)
+			continue
+		}
+		if accepted(item, opts) {
+			c.Accepted++
+			continue
+		}
+		c.Actual = max(c.Actual, item.RiskScore)
+		c.Violations = append(c.Violations, newViolation(item))
+	}
+	c.Passed = len(c.Violations) == 0
+	return c
+}
+
+func highRiskCheck(items []scoring.FileRiskItem, opts Options) Check {
+	limit := opts.MaxHighRisk
+	c := Check{Name: CheckMaxHighRisk, Limit: float64(limit)}
+	var high []Violation
+	for _, item := range items {
+		if item.RiskLevel != config.RiskLevelHigh {
+			continue
+		}
+		if accepted(item, opts) {
+			c.Accepted++
+			continue
+		}
+		high = append(high, newViolation(item))
+	}
+	c.Actual = float64(len(high))
+	c.Passed = len(high) <= limit
+	if !c.Passed {
+		c.Violations = high
+	}
+	return c
+}
+
+// increaseCheck fails on files whose score exceeds their baseline score by more than
+// tolerance. Files missing from the baseline are not compared.
+func increaseCheck(items []scoring.FileRiskItem, baseline map[string]float64, tolerance float64) Check {
+	c := Check{Name: CheckScoreIncrease, Limit: tolerance}
+	for _, item := range items {
+		prev, ok := baseline[item.Path]
+		if !ok {
+			continue
+		}
+		increase := item.RiskScore - prev
+		c.Actual = max(c.Actual, increase)
+		if increase > tolerance {
+			v := newViolation(item)
+			v.BaselineScore = &prev
+			c.Violations = append(c.Violations, v)
+		}
+	}
+	sort.SliceStable(c.Violations, func(i, j int) bool {
+		return c.Violations[i].RiskScore-*c.Violations[i].BaselineScore >
+			c.Violations[j].RiskScore-*c.Violations[j].BaselineScore
+	})
+	c.Passed = len(c.Violations) == 0
+	return c
+}
+
+func newViolation(item scoring.FileRiskItem) Violation {
+	return Violation{Path: item.Path, RiskScore: item.RiskScore, RiskLevel: string(item.RiskLevel)}
+}
+
+// Err returns nil when the gate passed, or an error summarizing every failed check.
+func (r Result) Err() error {
+	if r.Passed {
+		return nil
+	}
+	var parts []string
+	for _, c := range r.Checks {
+		if !c.Passed {
+			parts = append(parts, c.describe())
+		}
+	}
+	return fmt.Errorf("quality gate failed: %s", strings.Join(parts, "; "))
+}
+
+func (c Check) describe() string {
+	var summary string
+	switch c.Name {
+	case CheckMaxHighRisk:
+		summary = fmt.Sprintf("%s: %d high-risk files (limit %d)", c.Name, int(c.Actual), int(c.Limit))
+	case CheckScoreIncrease:
+		summary = fmt.Sprintf("%s: %d files rose more than %.4f over the baseline", c.Name, len(c.Violations), c.Limit)
+	default:
+		summary = fmt.Sprintf("%s: %d files at or above %.4f", c.Name, len(c.Violations), c.Limit)
+	}
+
+	names := make([]string, 0, maxMessagePaths)
+	for i, v := range c.Violations {
+		if i == maxMessagePaths {
+			names = append(names, fmt.Sprintf("and %d more", len(c.Violations)-maxMessagePaths))
+			break
+		}
+		if v.BaselineScore != nil {
+			names = append(names, fmt.Sprintf("%s %.4f -> %.4f", v.Path, *v.BaselineScore, v.RiskScore))
+		} else {
+			names = append(names, fmt.Sprintf("%s %.4f", v.Path, v.RiskScore))
+		}
+	}
+	return summary + " (" + strings.Join(names, ", ") + ")"
+}
+
+// WriteFile writes the result as indented JSON.
+func (r Result) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal gate result: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write gate result: %w", err)
+	}
+	return nil
+}
+
+// LoadReportScores reads file scores by path from a report written by
+// `analyze --format json`. Only the files present in that report (its --top) are
+// returned.
+func LoadReportScores(path string) (map[string]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline report: %w", err)
+	}
+	var report struct {
+		Items []struct {
+			Path      string   `json:"path"`
+			RiskScore *float64 `json:"riskScore"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline report %s: %w", path, err)
+	}
+
+	scores := make(map[string]float64, len(report.Items))
+	for i, item := range report.Items {
+		if item.Path == "" || item.RiskScore == nil {
+			return nil, fmt.Errorf("baseline report %s: item %d has no path or riskScore", path, i)
+		}
+		scores[item.Path] = *item.RiskScore
+	}
+	return scores, nil
+}
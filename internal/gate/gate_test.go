@@ -0,0 +1,313 @@
+package gate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func newTestItems() []scoring.FileRiskItem {
+	return []scoring.FileRiskItem{
+		{Path: "src/hot.go", RiskScore: 0.85, RiskLevel: config.RiskLevelHigh},
+		{Path: "src/warm.go", RiskScore: 0.72, RiskLevel: config.RiskLevelHigh},
+		{Path: "src/mild.go", RiskScore: 0.5, RiskLevel: config.RiskLevelMedium},
+		{Path: "src/cold.go", RiskScore: 0.1, RiskLevel: config.RiskLevelLow},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           Options
+		wantPassed     bool
+		wantChecks     []string
+		wantViolations []string
+	}{
+		{
+			name:       "Disabled",
+			opts:       Options{MaxHighRisk: -1},
+			wantPassed: true,
+		},
+		{
+			name:           "ThresholdFails",
+			opts:           Options{Threshold: 0.7, MaxHighRisk: -1},
+			wantChecks:     []string{CheckThreshold},
+			wantViolations: []string{"src/hot.go", "src/warm.go"},
+		},
+		{
+			name:       "ThresholdPasses",
+			opts:       Options{Threshold: 0.9, MaxHighRisk: -1},
+			wantPassed: true,
+			wantChecks: []string{CheckThreshold},
+		},
+		{
+			name:           "ChangedThreshold",
+			opts:           Options{Threshold: 0.8, MaxHighRisk: -1, Changed: true},
+			wantChecks:     []string{CheckChangedThreshold},
+			wantViolations: []string{"src/hot.go"},
+		},
+		{
+			name:           "MaxHighRiskFails",
+			opts:           Options{MaxHighRisk: 1},
+			wantChecks:     []string{CheckMaxHighRisk},
+			wantViolations: []string{"src/hot.go", "src/warm.go"},
+		},
+		{
+			name:       "MaxHighRiskPasses",
+			opts:       Options{MaxHighRisk: 2},
+			wantPassed: true,
+			wantChecks: []string{CheckMaxHighRisk},
+		},
+		{
+			name: "ScoreIncrease",
+			opts: Options{
+				MaxHighRisk: -1,
+				Baseline:    map[string]float64{"src/hot.go": 0.84, "src/mild.go": 0.2, "src/cold.go": 0.0},
+				MaxIncrease: 0.05,
+			},
+			wantChecks:     []string{CheckScoreIncrease},
+			wantViolations: []string{"src/mild.go", "src/cold.go"},
+		},
+		{
+			name:       "ScoreIncreaseIgnoresNewFiles",
+			opts:       Options{MaxHighRisk: -1, Baseline: map[string]float64{}},
+			wantPassed: true,
+			wantChecks: []string{CheckScoreIncrease},
+		},
+		{
+			name:           "AllChecks",
+			opts:           Options{Threshold: 0.95, MaxHighRisk: 0, Baseline: map[string]float64{}},
+			wantChecks:     []string{CheckThreshold, CheckMaxHighRisk, CheckScoreIncrease},
+			wantViolations: []string{"src/hot.go", "src/warm.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(newTestItems(), tt.opts)
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", result.Passed, tt.wantPassed)
+			}
+			if result.FilesEvaluated != 4 {
+				t.Errorf("FilesEvaluated = %d, want 4", result.FilesEvaluated)
+			}
+
+			var names, violations []string
+			for _, c := range result.Checks {
+				names = append(names, c.Name)
+				for _, v := range c.Violations {
+					violations = append(violations, v.Path)
+				}
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantChecks, ",") {
+				t.Errorf("checks = %v, want %v", names, tt.wantChecks)
+			}
+			if strings.Join(violations, ",") != strings.Join(tt.wantViolations, ",") {
+				t.Errorf("violations = %v, want %v", violations, tt.wantViolations)
+			}
+			if (result.Err() == nil) != tt.wantPassed {
+				t.Errorf("Err() = %v, want passed %v", result.Err(), tt.wantPassed)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Scope(t *testing.T) {
+	if got := Evaluate(nil, Options{MaxHighRisk: 0}).Scope; got != ScopeAll {
+		t.Errorf("Scope = %q, want %q", got, ScopeAll)
+	}
+	if got := Evaluate(nil, Options{MaxHighRisk: 0, Changed: true}).Scope; got != ScopeChanged {
+		t.Errorf("Scope = %q, want %q", got, ScopeChanged)
+	}
+}
+
+func TestEvaluate_AcceptBaseline(t *testing.T) {
+	baseline := map[string]float64{"src/hot.go": 0.84, "src/warm.go": 0.6}
+	tests := []struct {
+		name           string
+		opts           Options
+		wantPassed     bool
+		wantAccepted   []int
+		wantViolations []string
+	}{
+		{
+			// hot.go is within tolerance; warm.go rose 0.12 and fails every check
+			name:           "RisenHotspotFails",
+			opts:           Options{Threshold: 0.7, MaxHighRisk: 0, Baseline: baseline, MaxIncrease: 0.05, AcceptBaseline: true},
+			wantAccepted:   []int{1, 1, 0},
+			wantViolations: []string{"src/warm.go", "src/warm.go", "src/warm.go"},
+		},
+		{
+			name:         "WithinTolerancePasses",
+			opts:         Options{Threshold: 0.7, MaxHighRisk: 0, Baseline: baseline, MaxIncrease: 0.2, AcceptBaseline: true},
+			wantPassed:   true,
+			wantAccepted: []int{2, 2, 0},
+		},
+		{
+			name:           "NewHotspotFails",
+			opts:           Options{MaxHighRisk: 0, Baseline: map[string]float64{"src/hot.go": 0.85}, AcceptBaseline: true},
+			wantAccepted:   []int{1, 0},
+			wantViolations: []string{"src/warm.go"},
+		},
+		{
+			name:           "BaselineReportDoesNotAccept",
+			opts:           Options{MaxHighRisk: 0, Baseline: baseline, MaxIncrease: 0.2},
+			wantAccepted:   []int{0, 0},
+			wantViolations: []string{"src/hot.go", "src/warm.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(newTestItems(), tt.opts)
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", result.Passed, tt.wantPassed)
+			}
+			var accepted []int
+			var violations []string
+			for _, c := range result.Checks {
+				accepted = append(accepted, c.Accepted)
+				for _, v := range c.Violations {
+					violations = append(violations, v.Path)
+				}
+			}
+			if fmt.Sprint(accepted) != fmt.Sprint(tt.wantAccepted) {
+				t.Errorf("accepted = %v, want %v", accepted, tt.wantAccepted)
+			}
+			if strings.Join(violations, ",") != strings.Join(tt.wantViolations, ",") {
+				t.Errorf("violations = %v, want %v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Suppressions(t *testing.T) {
+	opts := Options{
+		MaxHighRisk: 0,
+		Suppressions: []Suppression{
+			{Path: "src/hot.go", Reason: "accepted until rewrite", Expires: "2026-12-31"},
+			{Path: "src/w*.go", Reason: "expired", Expires: "2026-01-31"},
+		},
+		Now: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	result := Evaluate(newTestItems(), opts)
+
+	if result.FilesEvaluated != 3 {
+		t.Errorf("FilesEvaluated = %d, want 3", result.FilesEvaluated)
+	}
+	if len(result.Suppressed) != 1 || result.Suppressed[0].Path != "src/hot.go" || result.Suppressed[0].Reason != "accepted until rewrite" {
+		t.Errorf("Suppressed = %+v", result.Suppressed)
+	}
+	if len(result.ExpiredSuppressions) != 1 || result.ExpiredSuppressions[0].Path != "src/w*.go" {
+		t.Errorf("ExpiredSuppressions = %+v", result.ExpiredSuppressions)
+	}
+	// The expired suppression no longer covers warm.go
+	if result.Passed || len(result.Checks[0].Violations) != 1 || result.Checks[0].Violations[0].Path != "src/warm.go" {
+		t.Errorf("checks = %+v", result.Checks)
+	}
+}
+
+func TestResult_Err(t *testing.T) {
+	items := append(newTestItems(),
+		scoring.FileRiskItem{Path: "src/a.go", RiskScore: 0.9, RiskLevel: config.RiskLevelHigh},
+		scoring.FileRiskItem{Path: "src/b.go", RiskScore: 0.9, RiskLevel: config.RiskLevelHigh},
+	)
+	opts := Options{MaxHighRisk: 3, Baseline: map[string]float64{"src/mild.go": 0.2}, MaxIncrease: 0.1}
+
+	err := Evaluate(items, opts).Err()
+	if err == nil {
+		t.Fatal("expected gate failure")
+	}
+	want := "quality gate failed: max-high-risk: 4 high-risk files (limit 3) " +
+		"(src/hot.go 0.8500, src/warm.go 0.7200, src/a.go 0.9000, and 1 more); " +
+		"score-increase: 1 files rose more than 0.1000 over the baseline (src/mild.go 0.2000 -> 0.5000)"
+	if err.Error() != want {
+		t.Errorf("Err() =\n%s\nwant\n%s", err, want)
+	}
+}
+
+func TestResult_WriteFile(t *testing.T) {
+	path := t.TempDir() + "/gate.json"
+	result := Evaluate(newTestItems(), Options{MaxHighRisk: -1, Baseline: map[string]float64{"src/cold.go": 0.0}})
+	if err := result.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read gate result: %v", err)
+	}
+	var got struct {
+		Passed bool `json:"passed"`
+		Checks []struct {
+			Name       string `json:"name"`
+			Violations []struct {
+				Path          string   `json:"path"`
+				RiskLevel     string   `json:"riskLevel"`
+				BaselineScore *float64 `json:"baselineScore"`
+			} `json:"violations"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if got.Passed || len(got.Checks) != 1 || len(got.Checks[0].Violations) != 1 {
+		t.Fatalf("unexpected result: %s", data)
+	}
+	v := got.Checks[0].Violations[0]
+	if v.Path != "src/cold.go" || v.RiskLevel != "low" || v.BaselineScore == nil || *v.BaselineScore != 0 {
+		t.Errorf("violation = %+v", v)
+	}
+}
+
+func TestLoadReportScores(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name:    "Valid",
+			content: `{"repo":"/r","items":[{"rank":1,"path":"a.go","riskScore":0.8},{"rank":2,"path":"b.go","riskScore":0}]}`,
+			want:    map[string]float64{"a.go": 0.8, "b.go": 0},
+		},
+		{name: "Empty", content: `{"items":[]}`, want: map[string]float64{}},
+		{name: "MissingScore", content: `{"items":[{"path":"a.go"}]}`, wantErr: true},
+		{name: "NotJSON", content: `risk`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/report.json"
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("Failed to write report: %v", err)
+			}
+			got, err := LoadReportScores(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("scores = %v, want %v", got, tt.want)
+			}
+			for path, score := range tt.want {
+				if got[path] != score {
+					t.Errorf("scores[%s] = %v, want %v", path, got[path], score)
+				}
+			}
+		})
+	}
+
+	if _, err := LoadReportScores(t.TempDir() + "/missing.json"); err == nil {
+		t.Error("expected error for missing report")
+	}
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// BlobReader reads file contents at revisions through one long-running
+// "git cat-file --batch" process. It is not safe for concurrent use.
+type BlobReader struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+// NewBlobReader starts a blob reader for the repository. Close releases it.
+func NewBlobReader(ctx context.Context, repoPath string) (*BlobReader, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "cat-file", "--batch")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file failed: %w", err)
+	}
+	return &BlobReader{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout)}, nil
+}
+
+// Read returns the contents of path at rev. ok is false when the path is not a file
+// at that revision.
+func (b *BlobReader) Read(rev, path string) (data []byte, ok bool, err error) {
+	if strings.ContainsAny(path, "\n") {
+		return nil, false, nil
+	}
+	if _, err := fmt.Fprintf(b.stdin, "%s:%s\n", rev, path); err != nil {
+		return nil, false, fmt.Errorf("git cat-file failed: %w", err)
+	}
+
+	// "<oid> <type> <size>" followed by the contents and a newline, or "<object> missing"
+	header, err := b.stdout.ReadString('\n')
+	if err != nil {
+		return nil, false, fmt.Errorf("git cat-file failed: %w", err)
+	}
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return nil, false, nil
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return nil, false, fmt.Errorf("unexpected git cat-file header %q", strings.TrimSpace(header))
+	}
+	data = make([]byte, size+1)
+	if _, err := io.ReadFull(b.stdout, data); err != nil {
+		return nil, false, fmt.Errorf("git cat-file failed: %w", err)
+	}
+	if fields[1] != "blob" {
+		return nil, false, nil
+	}
+	return data[:size], true, nil
+}
+
+// Close stops the git process.
+func (b *BlobReader) Close() error {
+	_ = b.stdin.Close()
+	return b.cmd.Wait()
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBlobReader_Read(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+	if err := os.MkdirAll(filepath.Join(repoDir, "dir"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "dir", "a b.txt"), []byte("first\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	testRunGit(t, repoDir, "add", ".")
+	testRunGit(t, repoDir, "commit", "-m", "initial")
+	if err := os.WriteFile(filepath.Join(repoDir, "dir", "a b.txt"), []byte("second\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	testRunGit(t, repoDir, "commit", "-am", "change")
+
+	blobs, err := NewBlobReader(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("NewBlobReader: %v", err)
+	}
+	defer blobs.Close()
+
+	tests := []struct {
+		rev, path string
+		want      string
+		ok        bool
+	}{
+		{"HEAD", "dir/a b.txt", "second\n", true},
+		{"HEAD~1", "dir/a b.txt", "first\n", true},
+		{"HEAD", "dir", "", false},
+		{"HEAD", "missing.txt", "", false},
+		{"HEAD", "dir/a b.txt", "second\n", true},
+	}
+	for _, tt := range tests {
+		data, ok, err := blobs.Read(tt.rev, tt.path)
+		if err != nil {
+			t.Fatalf("Read(%s, %s): %v", tt.rev, tt.path, err)
+		}
+		if ok != tt.ok || string(data) != tt.want {
+			t.Errorf("Read(%s, %s) = %q, %v, want %q, %v", tt.rev, tt.path, data, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Hunk is a changed line range of a diff without context lines. A side with no
+// lines is a pure insertion or deletion after line Start (0 at the top of the file).
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+}
+
+// FileHunks lists the changed line ranges of one file in one commit.
+type FileHunks struct {
+	Path    string // Path after the commit; the removed path for deletions
+	OldPath string // Path before the commit, for renames
+	Added   bool   // The commit created the file
+	Deleted bool   // The commit removed the file
+	Hunks   []Hunk
+}
+
+// CommitHunks lists the changed files of one commit.
+type CommitHunks struct {
+	SHA   string
+	Files []FileHunks
+}
+
+// ReadHunks reads the changed line ranges of the commits selected by the reader's
+// options, keyed by commit SHA. Pathspecs (e.g. "*.go") limit the files diffed, and
+// the include/exclude filters apply as in ReadChanges. Binary files have no hunks;
+// renames without content changes are listed with none.
+func (r *HistoryReader) ReadHunks(ctx context.Context, pathspecs ...string) (map[string][]FileHunks, error) {
+	history, err := r.readHunkLog(ctx, []string{"--no-merges", "--topo-order"}, pathspecs)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]FileHunks, len(history))
+	for _, ch := range history {
+		result[ch.SHA] = ch.Files
+	}
+	return result, nil
+}
+
+// ReadHunkHistory is like ReadHunks, but lists the first-parent history of the
+// analyzed branch, newest first, so its diffs can be replayed oldest first. Merges
+// are diffed against their first parent: their hunks hold the lines the merged
+// commits and any conflict resolution changed, which ReadMergeGroups attributes.
+func (r *HistoryReader) ReadHunkHistory(ctx context.Context, pathspecs ...string) ([]CommitHunks, error) {
+	return r.readHunkLog(ctx, []string{"--first-parent", "--diff-merges=first-parent"}, pathspecs)
+}
+
+// readHunkLog runs "git log -p -U0" with the walk options of the caller.
+func (r *HistoryReader) readHunkLog(ctx context.Context, walk, pathspecs []string) ([]CommitHunks, error) {
+	args := []string{
+		"-C", r.opts.RepoPath,
+		"-c", "core.quotePath=false",
+		"log",
+		"--no-color",
+	}
+	args = append(args, walk...)
+	args = append(args,
+		"--no-ext-diff",
+		"--no-textconv",
+		"--src-prefix=a/", "--dst-prefix=b/",
+		"--pretty=format:%x1e%H%x00%P",
+		"-p", "-U0",
+	)
+
+	switch r.opts.RenameDetect {
+	case RenameDetectOff:
+		args = append(args, "--no-renames")
+	case RenameDetectSimple:
+		args = append(args, "-M100%")
+	case RenameDetectAggressive:
+		args = append(args, "-M60%")
+	}
+
+	if r.opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=@%d", r.opts.Since.Unix()))
+	}
+	if r.opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=@%d", r.opts.Until.Unix()))
+	}
+
+	rev := strings.TrimSpace(r.opts.Branch)
+	if rev != "" && !strings.EqualFold(rev, "HEAD") {
+		args = append(args, rev)
+	}
+	args = append(args, "--")
+	args = append(args, pathspecs...)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	result, parseErr := r.parseHunks(bufio.NewReaderSize(stdout, 64*1024))
+	if parseErr != nil {
+		// Drain so git can exit before Wait
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return result, nil
+}
+
+// parseHunks parses "git log -p -U0" output with the record-separated header of
+// readHunkLog. Commits without parents are skipped, as in ReadChanges.
+func (r *HistoryReader) parseHunks(in *bufio.Reader) ([]CommitHunks, error) {
+	var result []CommitHunks
+	var sha string // Commit being read; empty while skipping one
+	var file *FileHunks
+
+	flush := func() error {
+		if file == nil || sha == "" {
+			file = nil
+			return nil
+		}
+		f := file
+		file = nil
+		if f.Path == "" {
+			return nil
+		}
+		matches, err := r.matchesFilters(f.Path)
+		if err != nil || !matches {
+			return err
+		}
+		if len(result) == 0 || result[len(result)-1].SHA != sha {
+			result = append(result, CommitHunks{SHA: sha})
+		}
+		last := &result[len(result)-1]
+		last.Files = append(last.Files, *f)
+		return nil
+	}
+
+	for {
+		line, err := in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line == "" && errors.Is(err, io.EOF) {
+			break
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case strings.HasPrefix(line, "\x1e"):
+			if ferr := flush(); ferr != nil {
+				return nil, ferr
+			}
+			fields := strings.SplitN(line[1:], "\x00", 2)
+			sha = fields[0]
+			if len(fields) < 2 || strings.TrimSpace(fields[1]) == "" {
+				sha = ""
+			}
+		case strings.HasPrefix(line, "diff --git "):
+			if ferr := flush(); ferr != nil {
+				return nil, ferr
+			}
+			file = &FileHunks{}
+		case file == nil:
+			// Blank line between commits
+		case strings.HasPrefix(line, "new file mode "):
+			file.Added = true
+		case strings.HasPrefix(line, "deleted file mode "):
+			file.Deleted = true
+		case strings.HasPrefix(line, "rename from "):
+			file.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			file.Path = unquoteDiffPath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "--- "):
+			if p := diffSidePath(strings.TrimPrefix(line, "--- "), "a/"); p != "" && file.Path == "" {
+				file.Path = p
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if p := diffSidePath(strings.TrimPrefix(line, "+++ "), "b/"); p != "" {
+				file.Path = p
+			}
+		case strings.HasPrefix(line, "@@ "):
+			h, ok := parseHunkHeader(line)
+			if !ok {
+				return nil, fmt.Errorf("unexpected hunk header %q", line)
+			}
+			file.Hunks = append(file.Hunks, h)
+			if err := skipHunkLines(in, h.OldLines+h.NewLines); err != nil {
+				return nil, err
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// skipHunkLines consumes the removed and added lines of a hunk, with the
+// "\ No newline at end of file" markers among them.
+func skipHunkLines(in *bufio.Reader, n int) error {
+	for n > 0 {
+		line, err := in.ReadString('\n')
+		if line == "" && err != nil {
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("unexpected end of diff")
+			}
+			return err
+		}
+		if !strings.HasPrefix(line, "\\") {
+			n--
+		}
+	}
+	return nil
+}
+
+// parseHunkHeader parses "@@ -a[,b] +c[,d] @@ ...". Omitted counts are 1.
+func parseHunkHeader(line string) (Hunk, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return Hunk{}, false
+	}
+	oldStart, oldLines, ok1 := parseHunkRange(fields[1][1:])
+	newStart, newLines, ok2 := parseHunkRange(fields[2][1:])
+	if !ok1 || !ok2 {
+		return Hunk{}, false
+	}
+	return Hunk{OldStart: oldStart, OldLines: oldLines, NewStart: newStart, NewLines: newLines}, true
+}
+
+func parseHunkRange(s string) (start, lines int, ok bool) {
+	startText, linesText, hasLines := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !hasLines {
+		return start, 1, true
+	}
+	lines, err = strconv.Atoi(linesText)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, lines, true
+}
+
+// diffSidePath returns the path of a "---" or "+++" line without its prefix, or ""
+// for /dev/null.
+func diffSidePath(s, prefix string) string {
+	s = unquoteDiffPath(strings.TrimSuffix(s, "\t"))
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// unquoteDiffPath decodes a path git quoted for containing special characters.
+func unquoteDiffPath(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	return s
+}
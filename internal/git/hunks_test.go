@@ -0,0 +1,200 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		line string
+		want Hunk
+		ok   bool
+	}{
+		{"@@ -10,2 +10,3 @@ func main() {", Hunk{OldStart: 10, OldLines: 2, NewStart: 10, NewLines: 3}, true},
+		{"@@ -5 +5 @@", Hunk{OldStart: 5, OldLines: 1, NewStart: 5, NewLines: 1}, true},
+		{"@@ -0,0 +1,4 @@", Hunk{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 4}, true},
+		{"@@ -7,3 +6,0 @@", Hunk{OldStart: 7, OldLines: 3, NewStart: 6, NewLines: 0}, true},
+		{"@@@ -1 -1 +1 @@@", Hunk{}, false},
+		{"@@ -a +1 @@", Hunk{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseHunkHeader(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseHunkHeader(%q) = %+v, %v, want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestHistoryReader_ParseHunks(t *testing.T) {
+	out := strings.Join([]string{
+		"\x1eaaa\x00ppp",
+		"diff --git a/a.go b/a.go",
+		"index 1..2 100644",
+		"--- a/a.go",
+		"+++ b/a.go",
+		"@@ -3,2 +3,2 @@ func A() {",
+		"-old",
+		"---- a decremented line that looks like a header",
+		"+new",
+		"++++ b/an added line that looks like a header",
+		"@@ -9,0 +10 @@",
+		"+tail",
+		"\\ No newline at end of file",
+		"diff --git a/old.go b/new.go",
+		"similarity index 100%",
+		"rename from old.go",
+		"rename to new.go",
+		"diff --git \"a/sp\\303\\251cial.go\" \"b/sp\\303\\251cial.go\"",
+		"deleted file mode 100644",
+		"--- \"a/sp\\303\\251cial.go\"",
+		"+++ /dev/null",
+		"@@ -1 +0,0 @@",
+		"-package x",
+		"diff --git a/vendor/v.go b/vendor/v.go",
+		"new file mode 100644",
+		"--- /dev/null",
+		"+++ b/vendor/v.go",
+		"@@ -0,0 +1 @@",
+		"+package v",
+		"",
+		"\x1eroot\x00",
+		"diff --git a/a.go b/a.go",
+		"new file mode 100644",
+		"--- /dev/null",
+		"+++ b/a.go",
+		"@@ -0,0 +1 @@",
+		"+package a",
+		"",
+	}, "\n")
+
+	r := &HistoryReader{opts: ReadOptions{Exclude: []string{"vendor/**"}}, filterCache: make(map[string]bool)}
+	got, err := r.parseHunks(bufio.NewReader(strings.NewReader(out)))
+	if err != nil {
+		t.Fatalf("parseHunks: %v", err)
+	}
+	want := []CommitHunks{{
+		SHA: "aaa",
+		Files: []FileHunks{
+			{Path: "a.go", Hunks: []Hunk{{3, 2, 3, 2}, {9, 0, 10, 1}}},
+			{Path: "new.go", OldPath: "old.go"},
+			{Path: "spécial.go", Deleted: true, Hunks: []Hunk{{1, 1, 0, 0}}},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseHunks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHistoryReader_ReadHunks(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	write("a.go", "package a\n\nfunc A() {\n}\n")
+	write("notes.txt", "one\n")
+	testRunGit(t, repoDir, "add", ".")
+	testRunGit(t, repoDir, "commit", "-m", "initial")
+
+	write("a.go", "package a\n\nfunc A() {\n\tprintln()\n}\n")
+	write("notes.txt", "one\ntwo\n")
+	testRunGit(t, repoDir, "commit", "-am", "change")
+	sha := strings.TrimSpace(testGitOutput(t, repoDir, "rev-parse", "HEAD"))
+
+	reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir, RenameDetect: RenameDetectSimple})
+	if err != nil {
+		t.Fatalf("NewHistoryReader: %v", err)
+	}
+	hunks, err := reader.ReadHunks(context.Background(), "*.go")
+	if err != nil {
+		t.Fatalf("ReadHunks: %v", err)
+	}
+	want := map[string][]FileHunks{
+		sha: {{Path: "a.go", Hunks: []Hunk{{OldStart: 3, OldLines: 0, NewStart: 4, NewLines: 1}}}},
+	}
+	if !reflect.DeepEqual(hunks, want) {
+		t.Errorf("ReadHunks() = %+v, want %+v", hunks, want)
+	}
+
+	history, err := reader.ReadHunkHistory(context.Background())
+	if err != nil {
+		t.Fatalf("ReadHunkHistory: %v", err)
+	}
+	wantHistory := []CommitHunks{{
+		SHA: sha,
+		Files: []FileHunks{
+			{Path: "a.go", Hunks: []Hunk{{OldStart: 3, OldLines: 0, NewStart: 4, NewLines: 1}}},
+			{Path: "notes.txt", Hunks: []Hunk{{OldStart: 1, OldLines: 0, NewStart: 2, NewLines: 1}}},
+		},
+	}}
+	if !reflect.DeepEqual(history, wantHistory) {
+		t.Errorf("ReadHunkHistory() = %+v, want %+v", history, wantHistory)
+	}
+}
+
+func TestHistoryReader_ReadHunkHistory_Merge(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = "line " + strconv.Itoa(i+1)
+	}
+	write := func(lines []string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, "f.txt"), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	write(lines)
+	testRunGit(t, repoDir, "add", ".")
+	testRunGit(t, repoDir, "commit", "-m", "initial")
+
+	// The side branch edits line 25 twice while the mainline inserts lines above it
+	testRunGit(t, repoDir, "checkout", "-q", "-b", "side")
+	lines[24] = "side one"
+	write(lines)
+	testRunGit(t, repoDir, "commit", "-am", "side one")
+	lines[24] = "side two"
+	write(lines)
+	testRunGit(t, repoDir, "commit", "-am", "side two")
+	testRunGit(t, repoDir, "checkout", "-q", "-")
+	lines[24] = "line 25"
+	write(append([]string{"top 1", "top 2", "top 3", "top 4", "top 5"}, lines...))
+	testRunGit(t, repoDir, "commit", "-am", "insert")
+	insert := strings.TrimSpace(testGitOutput(t, repoDir, "rev-parse", "HEAD"))
+	testRunGit(t, repoDir, "merge", "-q", "--no-ff", "-m", "merge side", "side")
+	merge := strings.TrimSpace(testGitOutput(t, repoDir, "rev-parse", "HEAD"))
+
+	reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir})
+	if err != nil {
+		t.Fatalf("NewHistoryReader: %v", err)
+	}
+	history, err := reader.ReadHunkHistory(context.Background())
+	if err != nil {
+		t.Fatalf("ReadHunkHistory: %v", err)
+	}
+	// The merge is diffed against the mainline, where line 25 is now line 30
+	want := []CommitHunks{
+		{SHA: merge, Files: []FileHunks{{Path: "f.txt", Hunks: []Hunk{{OldStart: 30, OldLines: 1, NewStart: 30, NewLines: 1}}}}},
+		{SHA: insert, Files: []FileHunks{{Path: "f.txt", Hunks: []Hunk{{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 5}}}}},
+	}
+	if !reflect.DeepEqual(history, want) {
+		t.Errorf("ReadHunkHistory() = %+v, want %+v", history, want)
+	}
+}
@@ -0,0 +1,100 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ReadMergeGroups maps each commit brought in by a merge on the first-parent history
+// of the analyzed branch to the SHA of that merge commit. Commits made directly on the
+// mainline (including squash merges) are not included.
+func (r *HistoryReader) ReadMergeGroups(ctx context.Context) (map[string]string, error) {
+	args := []string{
+		"-C", r.opts.RepoPath,
+		"log",
+		"--no-color",
+		"--topo-order",
+		"--format=%H %P",
+	}
+	if r.opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=@%d", r.opts.Since.Unix()))
+	}
+	if r.opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=@%d", r.opts.Until.Unix()))
+	}
+	rev := strings.TrimSpace(r.opts.Branch)
+	if rev != "" && !strings.EqualFold(rev, "HEAD") {
+		args = append(args, rev)
+	}
+
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	return parseMergeGroups(string(out)), nil
+}
+
+// parseMergeGroups computes merge membership from "<sha> <parents...>" lines in
+// topological order (children before parents, tip first).
+func parseMergeGroups(log string) map[string]string {
+	parents := make(map[string][]string)
+	var tip string
+	for _, line := range strings.Split(log, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if tip == "" {
+			tip = fields[0]
+		}
+		parents[fields[0]] = fields[1:]
+	}
+
+	// Walk the first-parent chain from the tip.
+	var mainline []string
+	onMainline := make(map[string]bool)
+	for sha := tip; sha != ""; {
+		if _, ok := parents[sha]; !ok || onMainline[sha] {
+			break
+		}
+		mainline = append(mainline, sha)
+		onMainline[sha] = true
+		if ps := parents[sha]; len(ps) > 0 {
+			sha = ps[0]
+		} else {
+			sha = ""
+		}
+	}
+
+	// Oldest merges first, so commits merged earlier are attributed to the earlier merge.
+	groups := make(map[string]string)
+	for i := len(mainline) - 1; i >= 0; i-- {
+		merge := mainline[i]
+		ps := parents[merge]
+		if len(ps) < 2 {
+			continue
+		}
+		stack := append([]string(nil), ps[1:]...)
+		for len(stack) > 0 {
+			sha := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if onMainline[sha] {
+				continue
+			}
+			if _, assigned := groups[sha]; assigned {
+				continue
+			}
+			commitParents, known := parents[sha]
+			if !known {
+				continue // Outside the analyzed range
+			}
+			groups[sha] = merge
+			stack = append(stack, commitParents...)
+		}
+	}
+
+	return groups
+}
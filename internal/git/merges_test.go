@@ -0,0 +1,99 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMergeGroups(t *testing.T) {
+	// History (tip first):
+	//   m2 merges f2 (feature branch f1 <- f2) into m1
+	//   m1 merges g1 into c0
+	//   c0 is a direct mainline commit
+	log := "m2 m1 f2\n" +
+		"f2 f1\n" +
+		"m1 c0 g1\n" +
+		"f1 c0\n" +
+		"g1 c0\n" +
+		"c0\n"
+
+	groups := parseMergeGroups(log)
+
+	expected := map[string]string{
+		"f1": "m2",
+		"f2": "m2",
+		"g1": "m1",
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("groups = %v, expected %v", groups, expected)
+	}
+	for sha, merge := range expected {
+		if groups[sha] != merge {
+			t.Errorf("groups[%s] = %q, expected %q", sha, groups[sha], merge)
+		}
+	}
+}
+
+func TestParseMergeGroups_EarlierMergeWins(t *testing.T) {
+	// f1 was merged by m1, then the branch continued with f2 and was merged again by m2.
+	log := "m2 m1 f2\n" +
+		"f2 f1\n" +
+		"m1 c0 f1\n" +
+		"f1 c0\n" +
+		"c0\n"
+
+	groups := parseMergeGroups(log)
+	if groups["f1"] != "m1" {
+		t.Errorf("groups[f1] = %q, expected m1", groups["f1"])
+	}
+	if groups["f2"] != "m2" {
+		t.Errorf("groups[f2] = %q, expected m2", groups["f2"])
+	}
+}
+
+func TestHistoryReader_ReadMergeGroups(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	commit := func(file, msg string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, file), []byte(msg+"\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		testRunGit(t, repoDir, "add", file)
+		testRunGit(t, repoDir, "commit", "-m", msg)
+		return testGitOutput(t, repoDir, "rev-parse", "HEAD")
+	}
+
+	commit("base.txt", "initial")
+	base := testGitOutput(t, repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+
+	testRunGit(t, repoDir, "checkout", "-b", "feature")
+	f1 := commit("a.txt", "feature a")
+	f2 := commit("b.txt", "feature b")
+
+	testRunGit(t, repoDir, "checkout", base)
+	direct := commit("c.txt", "direct")
+	testRunGit(t, repoDir, "merge", "--no-ff", "-m", "Merge feature", "feature")
+	merge := testGitOutput(t, repoDir, "rev-parse", "HEAD")
+
+	reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir})
+	if err != nil {
+		t.Fatalf("NewHistoryReader: %v", err)
+	}
+	groups, err := reader.ReadMergeGroups(context.Background())
+	if err != nil {
+		t.Fatalf("ReadMergeGroups: %v", err)
+	}
+
+	if groups[f1] != merge || groups[f2] != merge {
+		t.Errorf("feature commits not grouped under merge: %v", groups)
+	}
+	if _, ok := groups[direct]; ok {
+		t.Errorf("direct mainline commit should not be grouped")
+	}
+}
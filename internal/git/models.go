@@ -10,7 +10,8 @@ type CommitInfo struct {
 	SHA     string
 	When    time.Time
 	Author  AuthorInfo
-	Message string
+	Message string // Subject line
+	Body    string // Rest of the message, trimmed
 }
 
 // AuthorInfo represents commit author information.
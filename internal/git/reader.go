@@ -34,6 +34,31 @@ func (r *HistoryReader) ReadChanges(ctx context.Context) ([]CommitChangeSet, err
 	return r.readChangesGitCLI(ctx)
 }
 
+// ReadRevision returns the SHA of the analyzed revision: the newest commit on the
+// first-parent history of the branch (HEAD by default) committed before Until. Unlike
+// the newest change set, it can be a merge or a commit filtered from the analysis.
+func (r *HistoryReader) ReadRevision(ctx context.Context) (string, error) {
+	args := []string{"-C", r.opts.RepoPath, "rev-list", "-1", "--first-parent"}
+	if r.opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=@%d", r.opts.Until.Unix()))
+	}
+	rev := strings.TrimSpace(r.opts.Branch)
+	if rev == "" {
+		rev = "HEAD"
+	}
+	args = append(args, rev, "--")
+
+	out, err := exec.CommandContext(ctx, "git", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-list failed: %w", err)
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("no commit on %s before the end of the analyzed range", rev)
+	}
+	return sha, nil
+}
+
 // matchesFilters checks if a path matches the include/exclude filters.
 // Results are cached to avoid repeated pattern matching for the same path.
 func (r *HistoryReader) matchesFilters(path string) (bool, error) {
@@ -129,3 +129,76 @@ func testGitOutput(t testing.TB, dir string, args ...string) string {
 	}
 	return strings.TrimSpace(string(out))
 }
+
+func TestHistoryReader_ReadRevision(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 12, 0, 0, 0, time.UTC) }
+	dated := func(when time.Time, args ...string) {
+		t.Helper()
+		testRunGitWithEnv(t, repoDir, []string{
+			fmt.Sprintf("GIT_AUTHOR_DATE=%s", when.Format(time.RFC3339)),
+			fmt.Sprintf("GIT_COMMITTER_DATE=%s", when.Format(time.RFC3339)),
+		}, args...)
+	}
+	write := func(rel, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, rel), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		testRunGit(t, repoDir, "add", rel)
+	}
+
+	write("a.go", "package a\n")
+	dated(day(1), "commit", "-m", "initial")
+	base := testGitOutput(t, repoDir, "rev-parse", "--abbrev-ref", "HEAD")
+
+	testRunGit(t, repoDir, "checkout", "-q", "-b", "feature")
+	write("b.go", "package a\n")
+	dated(day(5), "commit", "-m", "add B")
+
+	testRunGit(t, repoDir, "checkout", "-q", base)
+	write("a.go", "package a\n\nfunc C() {}\n")
+	dated(day(3), "commit", "-m", "add C")
+	mainline := testGitOutput(t, repoDir, "rev-parse", "HEAD")
+	dated(day(6), "merge", "-q", "--no-ff", "-m", "merge feature", "feature")
+	merge := testGitOutput(t, repoDir, "rev-parse", "HEAD")
+
+	beforeMerge := day(5).Add(time.Hour)
+	beforeHistory := day(1).Add(-time.Hour)
+	tests := []struct {
+		name    string
+		until   *time.Time
+		want    string
+		wantErr bool
+	}{
+		// The newest non-merge commit is the feature commit, which lacks C
+		{name: "MergeAtTip", want: merge},
+		{name: "UntilBeforeMerge", until: &beforeMerge, want: mainline},
+		{name: "UntilBeforeHistory", until: &beforeHistory, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir, Until: tt.until})
+			if err != nil {
+				t.Fatalf("NewHistoryReader: %v", err)
+			}
+			got, err := reader.ReadRevision(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ReadRevision() = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadRevision: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadRevision() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,10 +24,10 @@ type gitNumstat struct {
 }
 
 func (r *HistoryReader) readChangesGitCLI(ctx context.Context) ([]CommitChangeSet, error) {
-	// Each commit header line is prefixed by 0x1e (record separator), then NUL-separated fields,
-	// and ends with a newline. This makes the combined --raw/-z and --numstat/-z output
-	// reliably parseable as "records" split by 0x1e.
-	const format = "%x1e%H%x00%P%x00%cI%x00%an%x00%ae%x00%s%n"
+	// Each commit header is prefixed by 0x1e (record separator), then NUL-separated fields,
+	// and ends with 0x1f and a newline, since the message body spans lines. This makes the
+	// combined --raw/-z and --numstat/-z output reliably parseable as "records" split by 0x1e.
+	const format = "%x1e%H%x00%P%x00%cI%x00%aN%x00%aE%x00%s%x00%b%x1f%n"
 
 	args := []string{
 		"-C", r.opts.RepoPath,
@@ -83,8 +83,8 @@ func (r *HistoryReader) readChangesGitCLI(ctx context.Context) ([]CommitChangeSe
 			continue
 		}
 
-		fields := bytes.SplitN(header, []byte{0x00}, 6)
-		if len(fields) < 6 {
+		fields := bytes.SplitN(header, []byte{0x00}, 7)
+		if len(fields) < 7 {
 			return nil, fmt.Errorf("unexpected git log header format")
 		}
 
@@ -103,6 +103,7 @@ func (r *HistoryReader) readChangesGitCLI(ctx context.Context) ([]CommitChangeSe
 		authorName := string(fields[3])
 		authorEmail := string(fields[4])
 		subject := string(fields[5])
+		messageBody := strings.TrimSpace(string(fields[6]))
 
 		rawEntries, pos, err := parseGitRawEntries(body)
 		if err != nil {
@@ -160,6 +161,7 @@ func (r *HistoryReader) readChangesGitCLI(ctx context.Context) ([]CommitChangeSe
 				When:    when,
 				Author:  AuthorInfo{Name: authorName, Email: authorEmail},
 				Message: subject,
+				Body:    messageBody,
 			},
 			Changes: changes,
 		})
@@ -174,8 +176,8 @@ func (r *HistoryReader) readChangesGitCLI(ctx context.Context) ([]CommitChangeSe
 }
 
 func splitHeaderBody(rec []byte) (header []byte, body []byte) {
-	// The pretty line is followed by '\n', then diff output.
-	if idx := bytes.IndexByte(rec, '\n'); idx != -1 {
+	// The pretty format ends with 0x1f, then a '\n' and diff output.
+	if idx := bytes.IndexByte(rec, 0x1f); idx != -1 {
 		return rec[:idx], rec[idx+1:]
 	}
 	return rec, nil
@@ -1,6 +1,11 @@
 package git
 
-import "testing"
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestParseGitRawAndNumstat_RenameAndModify(t *testing.T) {
 	// Body bytes are what comes after the pretty header line.
@@ -119,3 +124,69 @@ func TestKindFromGitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestHistoryReader_ReadChanges_Mailmap(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "jdoe")
+	testRunGit(t, repoDir, "config", "user.email", "jdoe@old.example.com")
+
+	mailmap := "Jane Doe <jane@example.com> <jdoe@old.example.com>\n"
+	for i, content := range []string{mailmap, mailmap + "\n"} {
+		if err := os.WriteFile(filepath.Join(repoDir, ".mailmap"), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		testRunGit(t, repoDir, "add", ".mailmap")
+		testRunGit(t, repoDir, "commit", "-m", "commit "+string(rune('a'+i)))
+	}
+
+	reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir})
+	if err != nil {
+		t.Fatalf("NewHistoryReader: %v", err)
+	}
+	changeSets, err := reader.ReadChanges(context.Background())
+	if err != nil {
+		t.Fatalf("ReadChanges: %v", err)
+	}
+	if len(changeSets) == 0 {
+		t.Fatal("ReadChanges() returned no commits")
+	}
+	if got := changeSets[0].Commit.Author; got.Name != "Jane Doe" || got.Email != "jane@example.com" {
+		t.Errorf("Author = %+v, expected the identity from .mailmap", got)
+	}
+}
+
+func TestHistoryReader_ReadChanges_Body(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	messages := []string{"initial", "feat(api)!: drop v1\n\nRemoves the old routes.\n\nBREAKING CHANGE: v1 is gone\nRefs: #12"}
+	for i, msg := range messages {
+		if err := os.WriteFile(filepath.Join(repoDir, "file.txt"), []byte{byte('a' + i)}, 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		testRunGit(t, repoDir, "add", "file.txt")
+		testRunGit(t, repoDir, "commit", "-m", msg)
+	}
+
+	reader, err := NewHistoryReader(ReadOptions{RepoPath: repoDir})
+	if err != nil {
+		t.Fatalf("NewHistoryReader: %v", err)
+	}
+	changeSets, err := reader.ReadChanges(context.Background())
+	if err != nil {
+		t.Fatalf("ReadChanges: %v", err)
+	}
+	if len(changeSets) != 1 || len(changeSets[0].Changes) != 1 {
+		t.Fatalf("ReadChanges() = %+v, expected one commit changing file.txt", changeSets)
+	}
+	commit := changeSets[0].Commit
+	if commit.Message != "feat(api)!: drop v1" {
+		t.Errorf("Message = %q, expected the subject", commit.Message)
+	}
+	if want := "Removes the old routes.\n\nBREAKING CHANGE: v1 is gone\nRefs: #12"; commit.Body != want {
+		t.Errorf("Body = %q, want %q", commit.Body, want)
+	}
+}
@@ -0,0 +1,27 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ListFiles returns the paths of the files tracked at ref, or at HEAD when ref is empty.
+func ListFiles(ctx context.Context, repoPath, ref string) ([]string, error) {
+	if strings.TrimSpace(ref) == "" {
+		ref = "HEAD"
+	}
+	out, err := exec.CommandContext(ctx, "git", "-C", repoPath, "ls-tree", "-r", "-z", "--name-only", ref).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree failed: %w", err)
+	}
+
+	var files []string
+	for _, path := range strings.Split(string(out), "\x00") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
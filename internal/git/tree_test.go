@@ -0,0 +1,44 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestListFiles(t *testing.T) {
+	repoDir := t.TempDir()
+	testRunGit(t, repoDir, "init")
+	testRunGit(t, repoDir, "config", "user.name", "Test")
+	testRunGit(t, repoDir, "config", "user.email", "test@example.com")
+
+	for _, file := range []string{"main.go", "vendor/lib/lib.go", "docs/read me.md"} {
+		path := filepath.Join(repoDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(file+"\n"), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	testRunGit(t, repoDir, "add", ".")
+	testRunGit(t, repoDir, "commit", "-m", "initial")
+	if err := os.WriteFile(filepath.Join(repoDir, "untracked.go"), nil, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	files, err := ListFiles(context.Background(), repoDir, "")
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	want := []string{"docs/read me.md", "main.go", "vendor/lib/lib.go"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("ListFiles() = %v, want %v", files, want)
+	}
+
+	if _, err := ListFiles(context.Background(), repoDir, "no-such-ref"); err == nil {
+		t.Error("ListFiles() with an unknown ref should fail")
+	}
+}
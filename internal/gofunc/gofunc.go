@@ -0,0 +1,87 @@
+// Package gofunc locates the functions and methods declared in Go source files.
+package gofunc
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+)
+
+// Func is a function or method declaration.
+type Func struct {
+	Name      string // "Name" for functions, "Type.Name" for methods
+	StartLine int    // First line, including the doc comment
+	EndLine   int    // Line of the closing brace
+}
+
+// Parse returns the functions declared in a Go source file, ordered by position.
+// Source with syntax errors yields the declarations parsed before the error; the
+// error is only returned when nothing could be parsed.
+func Parse(filename string, src []byte) ([]Func, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if file == nil {
+		return nil, err
+	}
+
+	var funcs []Func
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name == nil {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		funcs = append(funcs, Func{
+			Name:      funcName(fn),
+			StartLine: fset.Position(start).Line,
+			EndLine:   fset.Position(fn.End()).Line,
+		})
+	}
+	if len(funcs) == 0 && err != nil {
+		return nil, err
+	}
+	sort.SliceStable(funcs, func(i, j int) bool { return funcs[i].StartLine < funcs[j].StartLine })
+	return funcs, nil
+}
+
+// funcName qualifies methods with their receiver type, without pointer or type
+// parameters, so a method keeps its name when its receiver changes.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+			continue
+		case *ast.ParenExpr:
+			typ = t.X
+			continue
+		case *ast.IndexExpr:
+			typ = t.X
+			continue
+		case *ast.IndexListExpr:
+			typ = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fn.Name.Name
+		}
+		return fn.Name.Name
+	}
+}
+
+// At returns the index of the function spanning line, or -1 when the line is outside
+// every function. funcs must be ordered by position, as returned by Parse.
+func At(funcs []Func, line int) int {
+	i := sort.Search(len(funcs), func(i int) bool { return funcs[i].EndLine >= line })
+	if i < len(funcs) && funcs[i].StartLine <= line {
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,68 @@
+package gofunc
+
+import (
+	"reflect"
+	"testing"
+)
+
+const source = `package p
+
+// Reader reads.
+type Reader[T any] struct{}
+
+// Read reads one item.
+func (r *Reader[T]) Read() T {
+	var zero T
+	return zero
+}
+
+func (Reader[T]) Close() {}
+
+func helper() {
+	_ = func() {}
+}
+`
+
+func TestParse(t *testing.T) {
+	funcs, err := Parse("p.go", []byte(source))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := []Func{
+		{Name: "Reader.Read", StartLine: 6, EndLine: 10},
+		{Name: "Reader.Close", StartLine: 12, EndLine: 12},
+		{Name: "helper", StartLine: 14, EndLine: 16},
+	}
+	if !reflect.DeepEqual(funcs, want) {
+		t.Errorf("Parse() = %+v, want %+v", funcs, want)
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	funcs, err := Parse("p.go", []byte("package p\n\nfunc ok() {}\n\nfunc broken( {\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(funcs) == 0 || funcs[0].Name != "ok" {
+		t.Errorf("Parse() = %+v, want the function before the error", funcs)
+	}
+
+	if _, err := Parse("p.go", []byte("not go")); err == nil {
+		t.Error("Parse() of a file without a package clause should fail")
+	}
+}
+
+func TestAt(t *testing.T) {
+	funcs := []Func{{Name: "a", StartLine: 3, EndLine: 5}, {Name: "b", StartLine: 8, EndLine: 12}}
+	tests := []struct {
+		line int
+		want int
+	}{
+		{1, -1}, {3, 0}, {5, 0}, {6, -1}, {8, 1}, {12, 1}, {13, -1},
+	}
+	for _, tt := range tests {
+		if got := At(funcs, tt.line); got != tt.want {
+			t.Errorf("At(%d) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,635 @@
+// Renders the bugspots-go HTML report from the embedded BUGSPOTS_DATA object.
+// No external libraries or network requests are used.
+(function () {
+  'use strict';
+
+  var data = BUGSPOTS_DATA;
+  var app = document.getElementById('app');
+  var SVG_NS = 'http://www.w3.org/2000/svg';
+
+  var FILE_COMPONENTS = [
+    ['commit', 'Commit'], ['churn', 'Churn'], ['recency', 'Recency'], ['burst', 'Burst'],
+    ['ownership', 'Ownership'], ['bugfix', 'Bugfix'], ['complexity', 'Complexity'], ['coupling', 'Coupling']
+  ];
+  var COMMIT_COMPONENTS = [['diffusion', 'Diffusion'], ['size', 'Size'], ['entropy', 'Entropy']];
+
+  // ---- helpers -------------------------------------------------------------
+
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    setAttrs(node, attrs);
+    appendChildren(node, children);
+    return node;
+  }
+
+  function svg(tag, attrs, children) {
+    var node = document.createElementNS(SVG_NS, tag);
+    setAttrs(node, attrs);
+    appendChildren(node, children);
+    return node;
+  }
+
+  function setAttrs(node, attrs) {
+    Object.keys(attrs || {}).forEach(function (k) {
+      if (attrs[k] !== undefined && attrs[k] !== null) {
+        node.setAttribute(k, attrs[k]);
+      }
+    });
+  }
+
+  function appendChildren(node, children) {
+    (children === undefined ? [] : [].concat(children)).forEach(function (c) {
+      if (c === null || c === undefined) {
+        return;
+      }
+      node.appendChild(typeof c === 'object' ? c : document.createTextNode(String(c)));
+    });
+  }
+
+  function fixed(n, digits) {
+    return typeof n === 'number' ? n.toFixed(digits === undefined ? 4 : digits) : '';
+  }
+
+  function clamp01(v) {
+    return Math.max(0, Math.min(1, v));
+  }
+
+  // riskColor maps a score in [0, 1] from green through amber to red.
+  function riskColor(score) {
+    var hue = (1 - clamp01(score)) * 120;
+    return 'hsl(' + hue.toFixed(0) + ', 65%, 42%)';
+  }
+
+  function levelOf(score) {
+    if (score >= data.thresholds.high) {
+      return 'high';
+    }
+    return score >= data.thresholds.medium ? 'medium' : 'low';
+  }
+
+  function levelBadge(level) {
+    return el('span', { 'class': 'level level-' + level }, level);
+  }
+
+  function basename(path) {
+    var i = path.lastIndexOf('/');
+    return i < 0 ? path : path.slice(i + 1);
+  }
+
+  function firstLine(s) {
+    var i = (s || '').indexOf('\n');
+    return i < 0 ? s : s.slice(0, i);
+  }
+
+  function card(label, value) {
+    return el('div', { 'class': 'card' }, [el('div', { 'class': 'value' }, value), el('div', { 'class': 'label' }, label)]);
+  }
+
+  function legend(components) {
+    return el('div', { 'class': 'legend' }, components.map(function (c, i) {
+      return el('span', null, [el('i', { 'class': 'seg' + i }), c[1]]);
+    }));
+  }
+
+  // breakdownBar draws a stacked bar whose full width corresponds to a score of 1.
+  function breakdownBar(breakdown, components) {
+    if (!breakdown) {
+      return '';
+    }
+    var title = components.map(function (c) {
+      return c[1] + ': ' + fixed(breakdown[c[0]] || 0);
+    }).join('\n');
+    return el('div', { 'class': 'bar', title: title }, components.map(function (c, i) {
+      var v = breakdown[c[0]] || 0;
+      return v > 0 ? el('span', { 'class': 'seg' + i, style: 'width:' + (clamp01(v) * 100).toFixed(2) + '%' }) : null;
+    }));
+  }
+
+  // ---- sortable, filterable table -----------------------------------------
+
+  // dataTable renders rows with clickable column headers for sorting, a text filter
+  // and, when levelOf is given, a risk level filter. It returns the section element
+  // and a setFilter function for other widgets to drive the text filter.
+  function dataTable(opts) {
+    var sortIndex = opts.sortIndex || 0;
+    var sortDesc = opts.sortDesc !== false;
+    var filterInput = el('input', { type: 'search', placeholder: opts.placeholder || 'Filter…' });
+    var levelSelect = opts.levelOf ? el('select', null, ['all', 'high', 'medium', 'low'].map(function (l) {
+      return el('option', { value: l }, l === 'all' ? 'All levels' : l);
+    })) : null;
+    var count = el('span', { 'class': 'hint' });
+    var thead = el('thead');
+    var tbody = el('tbody');
+    var headerRow = el('tr');
+    thead.appendChild(headerRow);
+
+    opts.columns.forEach(function (col, i) {
+      var th = el('th', { 'class': col.numeric ? 'num' : null }, col.label);
+      th.addEventListener('click', function () {
+        if (sortIndex === i) {
+          sortDesc = !sortDesc;
+        } else {
+          sortIndex = i;
+          sortDesc = !!col.numeric;
+        }
+        render();
+      });
+      headerRow.appendChild(th);
+    });
+
+    function render() {
+      var needle = filterInput.value.trim().toLowerCase();
+      var level = levelSelect ? levelSelect.value : 'all';
+      var col = opts.columns[sortIndex];
+      var rows = opts.rows.filter(function (row) {
+        if (level !== 'all' && opts.levelOf(row) !== level) {
+          return false;
+        }
+        return !needle || opts.text(row).toLowerCase().indexOf(needle) >= 0;
+      });
+      rows.sort(function (a, b) {
+        var va = col.value(a);
+        var vb = col.value(b);
+        var cmp = va < vb ? -1 : va > vb ? 1 : 0;
+        return sortDesc ? -cmp : cmp;
+      });
+
+      Array.prototype.forEach.call(headerRow.children, function (th, i) {
+        th.classList.toggle('sorted-asc', i === sortIndex && !sortDesc);
+        th.classList.toggle('sorted-desc', i === sortIndex && sortDesc);
+      });
+
+      tbody.textContent = '';
+      rows.forEach(function (row) {
+        tbody.appendChild(el('tr', null, opts.columns.map(function (c) {
+          var cls = [c.numeric ? 'num' : '', c.className || ''].join(' ').trim();
+          return el('td', { 'class': cls || null }, c.render ? c.render(row) : c.value(row));
+        })));
+      });
+      count.textContent = rows.length + ' of ' + opts.rows.length + ' rows';
+    }
+
+    filterInput.addEventListener('input', render);
+    if (levelSelect) {
+      levelSelect.addEventListener('change', render);
+    }
+    render();
+
+    var node = el('div', null, [
+      el('div', { 'class': 'toolbar' }, [filterInput, levelSelect, count]),
+      el('div', { 'class': 'table-wrap' }, el('table', null, [thead, tbody]))
+    ]);
+    return {
+      node: node,
+      setFilter: function (value) {
+        filterInput.value = value;
+        render();
+        filterInput.scrollIntoView({ behavior: 'smooth', block: 'center' });
+      }
+    };
+  }
+
+  // ---- treemap --------------------------------------------------------------
+
+  // fileWeight sizes a file by its line count, falling back to churn when the
+  // line count was not measured.
+  function fileWeight(f) {
+    return f.metrics.fileSize > 0 ? f.metrics.fileSize : Math.max(f.metrics.churnTotal, 1);
+  }
+
+  function buildTree(files) {
+    var root = { name: '', path: '', children: {} };
+    files.forEach(function (f) {
+      var parts = f.path.split('/');
+      var node = root;
+      parts.forEach(function (part, i) {
+        if (i === parts.length - 1) {
+          node.children[part] = { name: part, path: f.path, size: fileWeight(f), score: f.riskScore, file: f };
+          return;
+        }
+        if (!node.children[part] || node.children[part].file) {
+          node.children[part] = { name: part, path: parts.slice(0, i + 1).join('/'), children: {} };
+        }
+        node = node.children[part];
+      });
+    });
+    finalizeTree(root);
+    return root;
+  }
+
+  // finalizeTree turns child maps into arrays sorted by size and rolls sizes up.
+  // A directory's score is the highest score among its files.
+  function finalizeTree(node) {
+    if (node.file) {
+      return;
+    }
+    node.children = Object.keys(node.children).map(function (k) {
+      return node.children[k];
+    });
+    node.size = 0;
+    node.score = 0;
+    node.children.forEach(function (c) {
+      finalizeTree(c);
+      c.parent = node;
+      node.size += c.size;
+      node.score = Math.max(node.score, c.score);
+    });
+    node.children.sort(function (a, b) {
+      return b.size - a.size;
+    });
+  }
+
+  function worstRatio(row, side) {
+    var sum = 0;
+    var min = Infinity;
+    var max = 0;
+    row.forEach(function (r) {
+      sum += r.area;
+      min = Math.min(min, r.area);
+      max = Math.max(max, r.area);
+    });
+    var s2 = sum * sum;
+    var side2 = side * side;
+    return Math.max(side2 * max / s2, s2 / (side2 * min));
+  }
+
+  // squarify lays out nodes (sorted by size, descending) in the given rectangle
+  // using the squarified treemap algorithm.
+  function squarify(nodes, x, y, w, h) {
+    var total = nodes.reduce(function (s, n) { return s + n.size; }, 0);
+    var out = [];
+    if (total <= 0 || w <= 0 || h <= 0) {
+      return out;
+    }
+    var scale = (w * h) / total;
+    var rest = nodes.map(function (n) { return { node: n, area: n.size * scale }; });
+
+    while (rest.length) {
+      var side = Math.min(w, h);
+      var row = [];
+      var best = Infinity;
+      while (rest.length) {
+        var candidate = row.concat([rest[0]]);
+        var worst = worstRatio(candidate, side);
+        if (row.length && worst > best) {
+          break;
+        }
+        row = candidate;
+        best = worst;
+        rest.shift();
+      }
+
+      var rowArea = row.reduce(function (s, r) { return s + r.area; }, 0);
+      var thickness = rowArea / side;
+      var offset = 0;
+      row.forEach(function (r) {
+        var length = r.area / thickness;
+        if (w >= h) {
+          out.push({ node: r.node, x: x, y: y + offset, w: thickness, h: length });
+        } else {
+          out.push({ node: r.node, x: x + offset, y: y, w: length, h: thickness });
+        }
+        offset += length;
+      });
+      if (w >= h) {
+        x += thickness;
+        w -= thickness;
+      } else {
+        y += thickness;
+        h -= thickness;
+      }
+    }
+    return out;
+  }
+
+  function treemap(files, onFileClick) {
+    var width = 960;
+    var height = 540;
+    var root = buildTree(files);
+    var current = root;
+    var crumbs = el('div', { 'class': 'breadcrumb' });
+    var chart = svg('svg', { 'class': 'chart', viewBox: '0 0 ' + width + ' ' + height, role: 'img' });
+
+    function drawNodes(parent, nodes, x, y, w, h, depth) {
+      squarify(nodes, x, y, w, h).forEach(function (t) {
+        var n = t.node;
+        var isDir = !n.file;
+        var title = n.path + '\nrisk ' + fixed(n.score) + (isDir ? ' (max)' : '') + '\nsize ' + n.size;
+        var g = svg('g', { 'class': 'tile' + (isDir ? ' dir' : '') }, [
+          svg('title', null, title),
+          svg('rect', { x: t.x, y: t.y, width: Math.max(t.w, 0), height: Math.max(t.h, 0), fill: riskColor(n.score) })
+        ]);
+        if (t.w > 48 && t.h > 16) {
+          g.appendChild(svg('text', { x: t.x + 4, y: t.y + 13 }, n.name + (isDir ? '/' : '')));
+        }
+        g.addEventListener('click', function (ev) {
+          ev.stopPropagation();
+          if (isDir) {
+            zoom(n);
+          } else if (onFileClick) {
+            onFileClick(n.path);
+          }
+        });
+        parent.appendChild(g);
+        // Preview one level of nesting inside directory tiles.
+        if (isDir && depth < 1 && t.w > 40 && t.h > 40) {
+          drawNodes(g, n.children, t.x + 3, t.y + 18, t.w - 6, t.h - 21, depth + 1);
+        }
+      });
+    }
+
+    function zoom(node) {
+      current = node;
+      chart.textContent = '';
+      drawNodes(chart, current.children, 0, 0, width, height, 0);
+
+      crumbs.textContent = '';
+      var chain = [];
+      for (var n = current; n; n = n.parent) {
+        chain.unshift(n);
+      }
+      chain.forEach(function (n, i) {
+        if (i > 0) {
+          crumbs.appendChild(document.createTextNode(' / '));
+        }
+        var label = n === root ? '(repository)' : n.name;
+        if (n === current) {
+          crumbs.appendChild(el('strong', null, label));
+        } else {
+          var a = el('a', null, label);
+          a.addEventListener('click', function () { zoom(n); });
+          crumbs.appendChild(a);
+        }
+      });
+    }
+
+    zoom(root);
+    return el('div', null, [
+      el('p', { 'class': 'hint' }, 'Area is proportional to lines of code (churn when line counts were not measured); color shows the risk score. Click a directory to zoom in, a file to find it in the table.'),
+      crumbs,
+      chart
+    ]);
+  }
+
+  // ---- coupling graph ----------------------------------------------------------
+
+  // forceLayout positions nodes with a Fruchterman-Reingold simulation. Initial
+  // positions are on a circle so the layout is the same on every load.
+  function forceLayout(nodes, edges, width, height) {
+    var n = nodes.length;
+    var k = Math.sqrt((width * height) / Math.max(n, 1)) * 0.6;
+    var index = {};
+    nodes.forEach(function (node, i) {
+      var angle = (2 * Math.PI * i) / Math.max(n, 1);
+      node.x = width / 2 + (width / 3) * Math.cos(angle);
+      node.y = height / 2 + (height / 3) * Math.sin(angle);
+      index[node.id] = node;
+    });
+
+    var temperature = width / 8;
+    for (var iter = 0; iter < 300; iter++) {
+      nodes.forEach(function (v) { v.dx = 0; v.dy = 0; });
+      for (var i = 0; i < n; i++) {
+        for (var j = i + 1; j < n; j++) {
+          var a = nodes[i];
+          var b = nodes[j];
+          var dx = a.x - b.x;
+          var dy = a.y - b.y;
+          var dist = Math.max(Math.sqrt(dx * dx + dy * dy), 0.01);
+          var force = (k * k) / dist;
+          a.dx += (dx / dist) * force;
+          a.dy += (dy / dist) * force;
+          b.dx -= (dx / dist) * force;
+          b.dy -= (dy / dist) * force;
+        }
+      }
+      edges.forEach(function (e) {
+        var s = index[e.source];
+        var t = index[e.target];
+        var dx = s.x - t.x;
+        var dy = s.y - t.y;
+        var dist = Math.max(Math.sqrt(dx * dx + dy * dy), 0.01);
+        var force = ((dist * dist) / k) * (0.5 + e.weightNorm);
+        s.dx -= (dx / dist) * force;
+        s.dy -= (dy / dist) * force;
+        t.dx += (dx / dist) * force;
+        t.dy += (dy / dist) * force;
+      });
+      nodes.forEach(function (v) {
+        // Gentle pull towards the center keeps disconnected components on screen.
+        v.dx += (width / 2 - v.x) * 0.02;
+        v.dy += (height / 2 - v.y) * 0.02;
+        var len = Math.max(Math.sqrt(v.dx * v.dx + v.dy * v.dy), 0.01);
+        var step = Math.min(len, temperature);
+        v.x = Math.max(20, Math.min(width - 20, v.x + (v.dx / len) * step));
+        v.y = Math.max(20, Math.min(height - 20, v.y + (v.dy / len) * step));
+      });
+      temperature *= 0.98;
+    }
+  }
+
+  function couplingGraph(graph, onNodeClick) {
+    var width = 960;
+    var height = 600;
+    var nodes = graph.nodes.map(function (n) { return Object.assign({}, n); });
+    var maxWeight = graph.edges.reduce(function (m, e) { return Math.max(m, e.weight); }, 0);
+    var maxSize = nodes.reduce(function (m, n) { return Math.max(m, n.size); }, 0);
+    var edges = graph.edges.map(function (e) {
+      return Object.assign({ weightNorm: maxWeight > 0 ? e.weight / maxWeight : 0 }, e);
+    });
+    forceLayout(nodes, edges, width, height);
+
+    var index = {};
+    nodes.forEach(function (n) { index[n.id] = n; });
+
+    var chart = svg('svg', { 'class': 'chart graph', viewBox: '0 0 ' + width + ' ' + height, role: 'img' });
+    var lines = edges.map(function (e) {
+      var s = index[e.source];
+      var t = index[e.target];
+      var line = svg('line', {
+        x1: s.x, y1: s.y, x2: t.x, y2: t.y,
+        'stroke-width': (1 + 5 * e.weightNorm).toFixed(2),
+        'stroke-opacity': (0.35 + 0.5 * e.weightNorm).toFixed(2)
+      }, svg('title', null, s.path + ' ↔ ' + t.path + '\njaccard ' + fixed(e.jaccard) + ', lift ' + fixed(e.lift, 2) + ', ' + e.coCommitCount + ' co-commits'));
+      chart.appendChild(line);
+      return { edge: e, node: line };
+    });
+
+    nodes.forEach(function (n) {
+      var r = 5 + 12 * (maxSize > 0 ? n.size / maxSize : 0);
+      var fill = typeof n.hotspotScore === 'number' ? riskColor(n.hotspotScore) : '#8c959f';
+      var title = n.path + '\n' + n.commitCount + ' commits' +
+        (typeof n.hotspotScore === 'number' ? '\nrisk ' + fixed(n.hotspotScore) : '');
+      var g = svg('g', null, [
+        svg('circle', { cx: n.x, cy: n.y, r: r.toFixed(1), fill: fill }, svg('title', null, title)),
+        svg('text', { x: n.x + r + 2, y: n.y + 3 }, basename(n.path))
+      ]);
+      g.addEventListener('mouseenter', function () {
+        lines.forEach(function (l) {
+          var touches = l.edge.source === n.id || l.edge.target === n.id;
+          l.node.classList.toggle('active', touches);
+          l.node.classList.toggle('dim', !touches);
+        });
+      });
+      g.addEventListener('mouseleave', function () {
+        lines.forEach(function (l) {
+          l.node.classList.remove('active');
+          l.node.classList.remove('dim');
+        });
+      });
+      g.addEventListener('click', function () {
+        if (onNodeClick) {
+          onNodeClick(n.path);
+        }
+      });
+      chart.appendChild(g);
+    });
+
+    return el('div', null, [
+      el('p', { 'class': 'hint' }, 'Edge thickness shows coupling strength; node size shows ' +
+        (graph.nodeSize === 'hotspot' ? 'the hotspot score' : 'commit count') +
+        ' and color the hotspot risk score when available. Hover a file to highlight its partners, click to find its pairs.'),
+      chart
+    ]);
+  }
+
+  // ---- sections -------------------------------------------------------------
+
+  function renderMeta() {
+    var meta = document.getElementById('meta');
+    meta.textContent = 'Repository: ' + data.repo + ' · ' + data.periodLabel + ': ' + data.period +
+      ' · Generated: ' + data.generatedAt;
+  }
+
+  function renderFiles(files) {
+    var section = el('section');
+    var high = files.filter(function (f) { return f.riskLevel === 'high'; }).length;
+    var medium = files.filter(function (f) { return f.riskLevel === 'medium'; }).length;
+    section.appendChild(el('h2', null, 'File Hotspots'));
+    section.appendChild(el('div', { 'class': 'summary' }, [
+      card('Files analyzed', data.total),
+      card('Files shown', files.length),
+      card('High risk', high),
+      card('Medium risk', medium)
+    ]));
+
+    var table = dataTable({
+      rows: files,
+      placeholder: 'Filter by path…',
+      text: function (f) { return f.path; },
+      levelOf: function (f) { return f.riskLevel; },
+      sortIndex: 2,
+      columns: [
+        { label: '#', numeric: true, value: function (f) { return f.rank; } },
+        { label: 'Path', className: 'path', value: function (f) { return f.path; } },
+        { label: 'Score', numeric: true, value: function (f) { return f.riskScore; }, render: function (f) { return fixed(f.riskScore); } },
+        { label: 'Level', value: function (f) { return f.riskScore; }, render: function (f) { return levelBadge(f.riskLevel); } },
+        { label: 'Commits', numeric: true, value: function (f) { return f.metrics.commitCount; } },
+        { label: 'Churn', numeric: true, value: function (f) { return f.metrics.churnTotal; } },
+        { label: 'Contributors', numeric: true, value: function (f) { return f.metrics.contributors; } },
+        { label: 'Burst', numeric: true, value: function (f) { return f.metrics.burstScore; }, render: function (f) { return fixed(f.metrics.burstScore, 2); } },
+        { label: 'Ownership', numeric: true, value: function (f) { return f.metrics.ownershipRatio; }, render: function (f) { return fixed(f.metrics.ownershipRatio, 2); } },
+        { label: 'Bugfixes', numeric: true, value: function (f) { return f.metrics.bugfixCount; } },
+        { label: 'Lines', numeric: true, value: function (f) { return f.metrics.fileSize; } },
+        { label: 'Last modified', value: function (f) { return f.metrics.lastModified; }, render: function (f) { return f.metrics.lastModified.slice(0, 10); } },
+        { label: 'Breakdown', value: function (f) { return f.riskScore; }, render: function (f) { return breakdownBar(f.breakdown, FILE_COMPONENTS); } }
+      ]
+    });
+
+    if (files.length) {
+      section.appendChild(el('h2', null, 'Repository Treemap'));
+      section.appendChild(treemap(files, table.setFilter));
+    }
+    section.appendChild(el('h2', null, 'Hotspot Table'));
+    section.appendChild(legend(FILE_COMPONENTS));
+    section.appendChild(table.node);
+    app.appendChild(section);
+  }
+
+  function renderCommits(commits) {
+    var section = el('section');
+    section.appendChild(el('h2', null, 'Commit Risk'));
+    section.appendChild(el('div', { 'class': 'summary' }, [
+      card('Commits analyzed', data.total),
+      card('Commits shown', commits.length),
+      card('High risk', commits.filter(function (c) { return c.riskLevel === 'high'; }).length),
+      card('Medium risk', commits.filter(function (c) { return c.riskLevel === 'medium'; }).length)
+    ]));
+    section.appendChild(legend(COMMIT_COMPONENTS));
+    section.appendChild(dataTable({
+      rows: commits,
+      placeholder: 'Filter by SHA, author or message…',
+      text: function (c) { return c.sha + ' ' + c.author + ' ' + c.message; },
+      levelOf: function (c) { return c.riskLevel; },
+      sortIndex: 4,
+      columns: [
+        { label: 'SHA', className: 'path', value: function (c) { return c.sha; }, render: function (c) { return c.sha.slice(0, 7); } },
+        { label: 'Date', value: function (c) { return c.when; }, render: function (c) { return c.when.slice(0, 10); } },
+        { label: 'Author', value: function (c) { return c.author; } },
+        { label: 'Message', className: 'message', value: function (c) { return firstLine(c.message); } },
+        { label: 'Score', numeric: true, value: function (c) { return c.riskScore; }, render: function (c) { return fixed(c.riskScore); } },
+        { label: 'Level', value: function (c) { return c.riskScore; }, render: function (c) { return levelBadge(c.riskLevel); } },
+        { label: 'Files', numeric: true, value: function (c) { return c.metrics.fileCount; } },
+        { label: 'Dirs', numeric: true, value: function (c) { return c.metrics.directoryCount; } },
+        { label: 'Subsystems', numeric: true, value: function (c) { return c.metrics.subsystemCount; } },
+        { label: 'Added', numeric: true, value: function (c) { return c.metrics.linesAdded; } },
+        { label: 'Deleted', numeric: true, value: function (c) { return c.metrics.linesDeleted; } },
+        { label: 'Entropy', numeric: true, value: function (c) { return c.metrics.changeEntropy; }, render: function (c) { return fixed(c.metrics.changeEntropy, 3); } },
+        { label: 'Breakdown', value: function (c) { return c.riskScore; }, render: function (c) { return breakdownBar(c.breakdown, COMMIT_COMPONENTS); } }
+      ]
+    }).node);
+    app.appendChild(section);
+  }
+
+  function renderCoupling(coupling) {
+    var section = el('section');
+    section.appendChild(el('h2', null, 'Change Coupling'));
+    section.appendChild(el('div', { 'class': 'summary' }, [
+      card('Commits analyzed', coupling.totalCommits),
+      card('Files', coupling.totalFiles),
+      card('Pairs', coupling.totalPairs),
+      card('Pairs shown', coupling.pairs.length)
+    ]));
+
+    var table = dataTable({
+      rows: coupling.pairs,
+      placeholder: 'Filter by path…',
+      text: function (p) { return p.fileA + ' ' + p.fileB; },
+      sortIndex: 3,
+      columns: [
+        { label: 'File A', className: 'path', value: function (p) { return p.fileA; } },
+        { label: 'File B', className: 'path', value: function (p) { return p.fileB; } },
+        { label: 'Co-commits', numeric: true, value: function (p) { return p.coCommitCount; } },
+        { label: 'Jaccard', numeric: true, value: function (p) { return p.jaccardCoefficient; }, render: function (p) { return fixed(p.jaccardCoefficient); } },
+        { label: 'Confidence', numeric: true, value: function (p) { return p.confidence; }, render: function (p) { return fixed(p.confidence); } },
+        { label: 'Lift', numeric: true, value: function (p) { return p.lift; }, render: function (p) { return fixed(p.lift, 2); } }
+      ]
+    });
+
+    if (coupling.graph.nodes.length) {
+      section.appendChild(el('h2', null, 'Coupling Graph'));
+      section.appendChild(couplingGraph(coupling.graph, table.setFilter));
+    }
+    section.appendChild(el('h2', null, 'Coupled Pairs'));
+    section.appendChild(table.node);
+    app.appendChild(section);
+  }
+
+  renderMeta();
+  if (data.files) {
+    data.files.forEach(function (f, i) {
+      f.rank = i + 1;
+      f.riskLevel = f.riskLevel || levelOf(f.riskScore);
+    });
+    renderFiles(data.files);
+  }
+  if (data.commits) {
+    renderCommits(data.commits);
+  }
+  if (data.coupling) {
+    renderCoupling(data.coupling);
+  }
+  if (!app.children.length) {
+    app.appendChild(el('p', { 'class': 'empty' }, 'No results.'));
+  }
+})();
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+const badgeLabel = "hotspots"
+
+// Badge colors follow the shields.io palette.
+const (
+	badgeColorRed    = "#e05d44"
+	badgeColorYellow = "#dfb317"
+	badgeColorGreen  = "#4c1"
+)
+
+// badgeTextWidth approximates the rendered width of s in 11px Verdana, which is what
+// shields-style badges are laid out for.
+func badgeTextWidth(s string) float64 {
+	var w float64
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("iIl.,:;|!'", r):
+			w += 3.5
+		case strings.ContainsRune("fjrt ()[]", r):
+			w += 4.5
+		case strings.ContainsRune("mwMW", r):
+			w += 10.5
+		case r >= 'A' && r <= 'Z':
+			w += 7.5
+		default:
+			w += 7
+		}
+	}
+	return w
+}
+
+// badgeMessage summarizes the report for the badge: the number of high-risk files,
+// or medium-risk files when there are none, with the matching color.
+func badgeMessage(report *FileAnalysisReport) (string, string) {
+	var high, medium int
+	for _, item := range report.Items {
+		switch report.riskLevel(item) {
+		case config.RiskLevelHigh:
+			high++
+		case config.RiskLevelMedium:
+			medium++
+		}
+	}
+
+	switch {
+	case high > 0:
+		return fmt.Sprintf("%d high", high), badgeColorRed
+	case medium > 0:
+		return fmt.Sprintf("%d medium", medium), badgeColorYellow
+	default:
+		return "none", badgeColorGreen
+	}
+}
+
+// BadgeFileWriter writes a shields-style SVG badge ("hotspots: 3 high") summarizing
+// a file analysis report. All analyzed files are counted, regardless of --top.
+type BadgeFileWriter struct{}
+
+// Write outputs the badge SVG.
+func (w *BadgeFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	message, color := badgeMessage(report)
+
+	labelWidth := badgeTextWidth(badgeLabel) + 10
+	messageWidth := badgeTextWidth(message) + 10
+	total := labelWidth + messageWidth
+	title := svgEscape(badgeLabel + ": " + message)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"20\" role=\"img\" aria-label=\"%s\">\n", total, title)
+	fmt.Fprintf(&b, "  <title>%s</title>\n", title)
+	b.WriteString("  <linearGradient id=\"s\" x2=\"0\" y2=\"100%\"><stop offset=\"0\" stop-color=\"#bbb\" stop-opacity=\".1\"/><stop offset=\"1\" stop-opacity=\".1\"/></linearGradient>\n")
+	fmt.Fprintf(&b, "  <clipPath id=\"r\"><rect width=\"%.0f\" height=\"20\" rx=\"3\" fill=\"#fff\"/></clipPath>\n", total)
+	b.WriteString("  <g clip-path=\"url(#r)\">\n")
+	fmt.Fprintf(&b, "    <rect width=\"%.0f\" height=\"20\" fill=\"#555\"/>\n", labelWidth)
+	fmt.Fprintf(&b, "    <rect x=\"%.0f\" width=\"%.0f\" height=\"20\" fill=\"%s\"/>\n", labelWidth, messageWidth, color)
+	fmt.Fprintf(&b, "    <rect width=\"%.0f\" height=\"20\" fill=\"url(#s)\"/>\n", total)
+	b.WriteString("  </g>\n")
+	b.WriteString("  <g fill=\"#fff\" text-anchor=\"middle\" font-family=\"Verdana,Geneva,DejaVu Sans,sans-serif\" font-size=\"11\">\n")
+	for _, part := range []struct {
+		x    float64
+		text string
+	}{
+		{labelWidth / 2, badgeLabel},
+		{labelWidth + messageWidth/2, message},
+	} {
+		fmt.Fprintf(&b, "    <text x=\"%.1f\" y=\"15\" fill=\"#010101\" fill-opacity=\".3\">%s</text>\n", part.x, svgEscape(part.text))
+		fmt.Fprintf(&b, "    <text x=\"%.1f\" y=\"14\">%s</text>\n", part.x, svgEscape(part.text))
+	}
+	b.WriteString("  </g>\n</svg>\n")
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	_, err = io.WriteString(out, b.String())
+	return err
+}
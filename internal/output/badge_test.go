@@ -0,0 +1,59 @@
+package output
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func TestBadgeMessage(t *testing.T) {
+	item := func(score float64) scoring.FileRiskItem {
+		return scoring.FileRiskItem{RiskScore: score, Metrics: &aggregation.FileMetrics{}}
+	}
+
+	tests := []struct {
+		name      string
+		items     []scoring.FileRiskItem
+		wantText  string
+		wantColor string
+	}{
+		{"high", []scoring.FileRiskItem{item(0.9), item(0.75), item(0.5)}, "2 high", badgeColorRed},
+		{"medium only", []scoring.FileRiskItem{item(0.5), item(0.1)}, "1 medium", badgeColorYellow},
+		{"low only", []scoring.FileRiskItem{item(0.1)}, "none", badgeColorGreen},
+		{"empty", nil, "none", badgeColorGreen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, color := badgeMessage(&FileAnalysisReport{Items: tt.items})
+			if text != tt.wantText || color != tt.wantColor {
+				t.Errorf("badgeMessage() = %q, %s; expected %q, %s", text, color, tt.wantText, tt.wantColor)
+			}
+		})
+	}
+}
+
+func TestBadgeFileWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/badge.svg"
+	// Top does not limit what the badge counts.
+	if err := (&BadgeFileWriter{}).Write(newTestFileReport(), OutputOptions{OutputPath: tmpFile, Top: 1}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if err := xml.Unmarshal(data, new(struct{})); err != nil {
+		t.Fatalf("output is not well-formed XML: %v", err)
+	}
+	svg := string(data)
+	if !strings.Contains(svg, "<title>hotspots: 1 high</title>") {
+		t.Errorf("badge title missing, got:\n%s", svg)
+	}
+	if !strings.Contains(svg, badgeColorRed) {
+		t.Error("expected the red badge color")
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/xml"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// checkstyleVersion is the Checkstyle report format version emitted.
+const checkstyleVersion = "4.3"
+
+type checkstyleDocument struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleSeverity maps a risk level to a Checkstyle severity.
+func checkstyleSeverity(level config.RiskLevel) string {
+	switch level {
+	case config.RiskLevelHigh:
+		return "warning"
+	case config.RiskLevelMedium:
+		return "info"
+	default:
+		return "ignore"
+	}
+}
+
+// CheckstyleFileWriter writes file analysis reports as Checkstyle XML.
+type CheckstyleFileWriter struct{}
+
+// Write outputs the file analysis report as Checkstyle XML, one <file> per hotspot.
+func (w *CheckstyleFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	doc := checkstyleDocument{
+		Version: checkstyleVersion,
+		Files:   make([]checkstyleFile, 0, len(items)),
+	}
+	for i, item := range items {
+		level := report.riskLevel(item)
+		doc.Files = append(doc.Files, checkstyleFile{
+			Name: item.Path,
+			Errors: []checkstyleError{{
+				Line:     1,
+				Severity: checkstyleSeverity(level),
+				Message:  fileHotspotMessage(i+1, item, level),
+				Source:   "bugspots.file-risk-" + string(level),
+			}},
+		})
+	}
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	return writeXML(out, doc)
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestCheckstyleFileWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/checkstyle.xml"
+	if err := (&CheckstyleFileWriter{}).Write(newTestFileReport(), OutputOptions{OutputPath: tmpFile, Top: 2}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Error("expected an XML declaration")
+	}
+
+	var doc checkstyleDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if doc.Version != checkstyleVersion {
+		t.Errorf("version = %q", doc.Version)
+	}
+	if len(doc.Files) != 2 {
+		t.Fatalf("expected 2 files with Top=2, got %d", len(doc.Files))
+	}
+
+	tests := []struct {
+		name     string
+		severity string
+		source   string
+	}{
+		{"src/hot.go", "warning", "bugspots.file-risk-high"},
+		{"src/warm.go", "info", "bugspots.file-risk-medium"},
+	}
+	for i, tt := range tests {
+		f := doc.Files[i]
+		if f.Name != tt.name || len(f.Errors) != 1 {
+			t.Fatalf("file %d = %+v", i, f)
+		}
+		e := f.Errors[0]
+		if e.Severity != tt.severity || e.Source != tt.source || e.Line != 1 {
+			t.Errorf("file %d error = %+v", i, e)
+		}
+	}
+}
@@ -40,14 +40,11 @@ func (w *CIFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 		defer file.Close()
 	}
 
-	thresholds := config.DefaultRiskThresholds()
-
 	// Classify and count risk levels
 	var highCount, mediumCount int
 	var maxScore float64
 	for _, item := range items {
-		level := thresholds.Classify(item.RiskScore)
-		switch level {
+		switch report.riskLevel(item) {
 		case config.RiskLevelHigh:
 			highCount++
 		case config.RiskLevelMedium:
@@ -72,12 +69,150 @@ func (w *CIFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 
 	// Write file entries
 	for _, item := range items {
-		level := thresholds.Classify(item.RiskScore)
 		entry := CIFileEntry{
 			Type:      "file",
 			Path:      item.Path,
 			RiskScore: item.RiskScore,
-			RiskLevel: string(level),
+			RiskLevel: string(report.riskLevel(item)),
+		}
+		if err := writeNDJSONLine(out, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CICommitWriter writes commit analysis reports as NDJSON for CI pipelines.
+type CICommitWriter struct{}
+
+// CICommitSummary is the first line of commit CI output.
+type CICommitSummary struct {
+	Type            string  `json:"type"`
+	TotalCommits    int     `json:"totalCommits"`
+	HighRiskCount   int     `json:"highRiskCount"`
+	MediumRiskCount int     `json:"mediumRiskCount"`
+	MaxRiskScore    float64 `json:"maxRiskScore"`
+}
+
+// CICommitEntry represents a single commit entry in CI output.
+type CICommitEntry struct {
+	Type         string  `json:"type"`
+	SHA          string  `json:"sha"`
+	RiskScore    float64 `json:"riskScore"`
+	RiskLevel    string  `json:"riskLevel"`
+	FileCount    int     `json:"fileCount"`
+	LinesAdded   int     `json:"linesAdded"`
+	LinesDeleted int     `json:"linesDeleted"`
+}
+
+// Write outputs the commit analysis report as NDJSON.
+func (w *CICommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	summary := CICommitSummary{Type: "summary", TotalCommits: len(items)}
+	for _, item := range items {
+		switch item.RiskLevel {
+		case config.RiskLevelHigh:
+			summary.HighRiskCount++
+		case config.RiskLevelMedium:
+			summary.MediumRiskCount++
+		}
+		if item.RiskScore > summary.MaxRiskScore {
+			summary.MaxRiskScore = item.RiskScore
+		}
+	}
+	if err := writeNDJSONLine(out, summary); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		entry := CICommitEntry{
+			Type:         "commit",
+			SHA:          item.Metrics.SHA,
+			RiskScore:    item.RiskScore,
+			RiskLevel:    string(item.RiskLevel),
+			FileCount:    item.Metrics.FileCount,
+			LinesAdded:   item.Metrics.LinesAdded,
+			LinesDeleted: item.Metrics.LinesDeleted,
+		}
+		if err := writeNDJSONLine(out, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CICouplingWriter writes coupling analysis reports as NDJSON for CI pipelines.
+type CICouplingWriter struct{}
+
+// CICouplingSummary is the first line of coupling CI output.
+type CICouplingSummary struct {
+	Type          string  `json:"type"`
+	TotalCommits  int     `json:"totalCommits"`
+	TotalFiles    int     `json:"totalFiles"`
+	TotalPairs    int     `json:"totalPairs"`
+	ReportedPairs int     `json:"reportedPairs"`
+	MaxJaccard    float64 `json:"maxJaccard"`
+}
+
+// CICouplingEntry represents a single coupled pair in CI output.
+type CICouplingEntry struct {
+	Type          string  `json:"type"`
+	FileA         string  `json:"fileA"`
+	FileB         string  `json:"fileB"`
+	CoCommitCount int     `json:"coCommitCount"`
+	Jaccard       float64 `json:"jaccard"`
+	Confidence    float64 `json:"confidence"`
+	Lift          float64 `json:"lift"`
+}
+
+// Write outputs the coupling analysis report as NDJSON.
+func (w *CICouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	couplings := limitTop(report.Result.Couplings, options.Top)
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	summary := CICouplingSummary{
+		Type:          "summary",
+		TotalCommits:  report.Result.TotalCommits,
+		TotalFiles:    report.Result.TotalFiles,
+		TotalPairs:    report.Result.TotalPairs,
+		ReportedPairs: len(couplings),
+	}
+	for _, c := range couplings {
+		if c.JaccardCoefficient > summary.MaxJaccard {
+			summary.MaxJaccard = c.JaccardCoefficient
+		}
+	}
+	if err := writeNDJSONLine(out, summary); err != nil {
+		return err
+	}
+
+	for _, c := range couplings {
+		entry := CICouplingEntry{
+			Type:          "coupling",
+			FileA:         c.FileA,
+			FileB:         c.FileB,
+			CoCommitCount: c.CoCommitCount,
+			Jaccard:       c.JaccardCoefficient,
+			Confidence:    c.Confidence,
+			Lift:          c.Lift,
 		}
 		if err := writeNDJSONLine(out, entry); err != nil {
 			return err
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/masmgr/bugspots-go/config"
 	"github.com/masmgr/bugspots-go/internal/aggregation"
 	"github.com/masmgr/bugspots-go/internal/scoring"
 )
@@ -150,6 +151,36 @@ func TestCIFileWriter_RiskLevelClassification(t *testing.T) {
 	}
 }
 
+func TestCIFileWriter_ConfiguredThresholds(t *testing.T) {
+	report := newTestFileReport()
+	report.Thresholds = config.RiskThresholds{High: 0.9, Medium: 0.45}
+
+	tmpFile := t.TempDir() + "/ci_thresholds.ndjson"
+	if err := (&CIFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	var summary CISummary
+	if err := json.Unmarshal([]byte(lines[0]), &summary); err != nil {
+		t.Fatalf("Failed to parse summary: %v", err)
+	}
+	if summary.HighRiskCount != 0 || summary.MediumRiskCount != 2 {
+		t.Errorf("summary counts = high %d, medium %d; want 0, 2", summary.HighRiskCount, summary.MediumRiskCount)
+	}
+	var entry CIFileEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("Failed to parse entry: %v", err)
+	}
+	if entry.RiskLevel != "medium" {
+		t.Errorf("hot.go RiskLevel = %q, want medium", entry.RiskLevel)
+	}
+}
+
 func TestCIFileWriter_TopOption(t *testing.T) {
 	now := time.Now()
 
@@ -183,6 +214,85 @@ func TestCIFileWriter_TopOption(t *testing.T) {
 	}
 }
 
+func TestCICommitWriter_Write(t *testing.T) {
+	now := time.Now()
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.CommitRiskItem{
+			{Metrics: aggregation.CommitMetrics{SHA: "aaa", FileCount: 12, LinesAdded: 300}, RiskScore: 0.8, RiskLevel: config.RiskLevelHigh},
+			{Metrics: aggregation.CommitMetrics{SHA: "bbb", FileCount: 3}, RiskScore: 0.5, RiskLevel: config.RiskLevelMedium},
+			{Metrics: aggregation.CommitMetrics{SHA: "ccc", FileCount: 1}, RiskScore: 0.1, RiskLevel: config.RiskLevelLow},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/ci_commits.ndjson"
+	if err := (&CICommitWriter{}).Write(report, OutputOptions{Format: FormatCI, OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 { // 1 summary + 3 commits
+		t.Fatalf("expected 4 lines, got %d: %s", len(lines), string(data))
+	}
+
+	var summary CICommitSummary
+	if err := json.Unmarshal([]byte(lines[0]), &summary); err != nil {
+		t.Fatalf("Failed to parse summary: %v", err)
+	}
+	want := CICommitSummary{Type: "summary", TotalCommits: 3, HighRiskCount: 1, MediumRiskCount: 1, MaxRiskScore: 0.8}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	var entry CICommitEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("Failed to parse entry: %v", err)
+	}
+	if entry.Type != "commit" || entry.SHA != "aaa" || entry.RiskLevel != "high" || entry.FileCount != 12 || entry.LinesAdded != 300 {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestCICouplingWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/ci_coupling.ndjson"
+	options := OutputOptions{Format: FormatCI, OutputPath: tmpFile, Top: 1}
+	if err := (&CICouplingWriter{}).Write(newTestCouplingReport(), options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 { // 1 summary + 1 pair with Top=1
+		t.Fatalf("expected 2 lines, got %d: %s", len(lines), string(data))
+	}
+
+	var summary CICouplingSummary
+	if err := json.Unmarshal([]byte(lines[0]), &summary); err != nil {
+		t.Fatalf("Failed to parse summary: %v", err)
+	}
+	want := CICouplingSummary{Type: "summary", TotalCommits: 10, TotalFiles: 3, TotalPairs: 2, ReportedPairs: 1, MaxJaccard: 0.8}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	var entry CICouplingEntry
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("Failed to parse entry: %v", err)
+	}
+	if entry.Type != "coupling" || entry.FileA != "src/a.go" || entry.FileB != "src/b.go" || entry.CoCommitCount != 4 || entry.Lift != 2.0 {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
 func readTestFile(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
@@ -1,9 +1,15 @@
 package output
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
 )
 
 const (
@@ -33,6 +39,40 @@ func formatSinceDate(since *time.Time) *string {
 	return &formatted
 }
 
+// riskThresholds returns the thresholds the report's items were classified with, or
+// the defaults for reports built without them.
+func (r *FileAnalysisReport) riskThresholds() config.RiskThresholds {
+	return thresholdsOrDefault(r.Thresholds)
+}
+
+// riskThresholds returns the thresholds the report's commits were classified with,
+// or the defaults for reports built without them.
+func (r *CommitAnalysisReport) riskThresholds() config.RiskThresholds {
+	return thresholdsOrDefault(r.Thresholds)
+}
+
+// riskThresholds returns the file thresholds for the report's hotspot scores, or the
+// defaults for reports built without them.
+func (r *CouplingAnalysisReport) riskThresholds() config.RiskThresholds {
+	return thresholdsOrDefault(r.Thresholds)
+}
+
+func thresholdsOrDefault(t config.RiskThresholds) config.RiskThresholds {
+	if t == (config.RiskThresholds{}) {
+		return config.DefaultRiskThresholds()
+	}
+	return t
+}
+
+// riskLevel returns the item's risk level, classifying its score with the report
+// thresholds when the item was built without one.
+func (r *FileAnalysisReport) riskLevel(item scoring.FileRiskItem) config.RiskLevel {
+	if item.RiskLevel != "" {
+		return item.RiskLevel
+	}
+	return r.riskThresholds().Classify(item.RiskScore)
+}
+
 func openOutputWriter(outputPath string) (io.Writer, *os.File, error) {
 	if outputPath == "" {
 		return os.Stdout, nil, nil
@@ -43,3 +83,48 @@ func openOutputWriter(outputPath string) (io.Writer, *os.File, error) {
 	}
 	return file, file, nil
 }
+
+// fileHotspotMessage describes a ranked file hotspot in one line, for formats that
+// attach a message to each finding (SARIF, Code Quality, Checkstyle).
+func fileHotspotMessage(rank int, item scoring.FileRiskItem, level config.RiskLevel) string {
+	return fmt.Sprintf("Hotspot #%d: risk score %.4f (%s) from %d commits, %d bugfixes and %d lines of churn.",
+		rank, item.RiskScore, level, item.Metrics.CommitCount, item.Metrics.BugfixCount, item.Metrics.ChurnTotal())
+}
+
+// overrideText describes the path overrides that matched a file, e.g.
+// "overrides vendor/** (score x0.50, level low)", or "" when none matched.
+func overrideText(b *scoring.ScoreBreakdown) string {
+	if b == nil || len(b.Overrides) == 0 {
+		return ""
+	}
+	var effects []string
+	if b.ScoreFactor != 1 {
+		effects = append(effects, fmt.Sprintf("score x%.2f", b.ScoreFactor))
+	}
+	if b.FixedLevel != "" {
+		effects = append(effects, "level "+string(b.FixedLevel))
+	}
+	text := "overrides " + strings.Join(b.Overrides, ", ")
+	if len(effects) > 0 {
+		text += " (" + strings.Join(effects, ", ") + ")"
+	}
+	return text
+}
+
+// scopeLabel names a Conventional Commits scope, including the empty one.
+func scopeLabel(scope string) string {
+	if scope == "" {
+		return "(none)"
+	}
+	return scope
+}
+
+// functionLocation returns "path:start-end" for a function.
+func functionLocation(m *aggregation.FunctionMetrics) string {
+	return fmt.Sprintf("%s:%d-%d", m.Path, m.StartLine, m.EndLine)
+}
+
+// rangeLocation returns "path:start-end" for a line range.
+func rangeLocation(m *aggregation.RangeMetrics) string {
+	return fmt.Sprintf("%s:%d-%d", m.Path, m.StartLine, m.EndLine)
+}
@@ -3,6 +3,9 @@ package output
 import (
 	"testing"
 	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
 )
 
 func TestLimitTop(t *testing.T) {
@@ -70,3 +73,49 @@ func TestFormatSinceDate(t *testing.T) {
 		t.Fatalf("formatSinceDate(...) = %v, want %q", got, "2026-02-01")
 	}
 }
+
+func TestFileAnalysisReport_RiskLevel(t *testing.T) {
+	strict := config.RiskThresholds{High: 0.9, Medium: 0.6}
+	tests := []struct {
+		name       string
+		thresholds config.RiskThresholds
+		item       scoring.FileRiskItem
+		want       config.RiskLevel
+	}{
+		{"DefaultThresholds", config.RiskThresholds{}, scoring.FileRiskItem{RiskScore: 0.85}, config.RiskLevelHigh},
+		{"ConfiguredThresholds", strict, scoring.FileRiskItem{RiskScore: 0.85}, config.RiskLevelMedium},
+		{"ItemLevelWins", strict, scoring.FileRiskItem{RiskScore: 0.85, RiskLevel: config.RiskLevelLow}, config.RiskLevelLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &FileAnalysisReport{Thresholds: tt.thresholds}
+			if got := report.riskLevel(tt.item); got != tt.want {
+				t.Errorf("riskLevel() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverrideText(t *testing.T) {
+	tests := []struct {
+		name      string
+		breakdown *scoring.ScoreBreakdown
+		want      string
+	}{
+		{"NoBreakdown", nil, ""},
+		{"NoOverrides", &scoring.ScoreBreakdown{ScoreFactor: 1}, ""},
+		{"WeightsOnly", &scoring.ScoreBreakdown{Overrides: []string{"src/**"}, ScoreFactor: 1}, "overrides src/**"},
+		{
+			"FactorAndLevel",
+			&scoring.ScoreBreakdown{Overrides: []string{"vendor/**", "**/*.pb.go"}, ScoreFactor: 0.25, FixedLevel: config.RiskLevelLow},
+			"overrides vendor/**, **/*.pb.go (score x0.25, level low)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overrideText(tt.breakdown); got != tt.want {
+				t.Errorf("overrideText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package output
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/fatih/color"
+
+	"github.com/masmgr/bugspots-go/internal/coupling"
 )
 
 // ConsoleFileWriter writes file analysis reports to the console.
@@ -25,18 +28,21 @@ func (w *ConsoleFileWriter) Write(report *FileAnalysisReport, options OutputOpti
 
 	// Write header
 	if options.Explain {
-		fmt.Fprintln(tw, "#\tPath\tScore\tCommits\tChurn\tContributors\tBurst\tBugfixes\tLines\tC\tCh\tR\tB\tO\tBf\tCx")
+		fmt.Fprintln(tw, "#\tPath\tScore\tLevel\tCommits\tChurn\tContributors\tBurst\tBugfixes\tLines\tC\tCh\tR\tB\tO\tBf\tCx\tCp")
 	} else {
-		fmt.Fprintln(tw, "#\tPath\tScore\tCommits\tChurn\tContributors\tBurst\tBugfixes\tLines")
+		fmt.Fprintln(tw, "#\tPath\tScore\tLevel\tCommits\tChurn\tContributors\tBurst\tBugfixes\tLines")
 	}
 
 	// Write rows
 	for i, item := range items {
+		level := string(report.riskLevel(item))
+		levelColor := getLevelColor(level)
 		if options.Explain && item.Breakdown != nil {
-			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%d\t%d\t%d\t%.2f\t%d\t%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\n",
+			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%s\t%d\t%d\t%d\t%.2f\t%d\t%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\n",
 				i+1,
 				item.Path,
 				item.RiskScore,
+				levelColor(level),
 				item.Metrics.CommitCount,
 				item.Metrics.ChurnTotal(),
 				item.Metrics.ContributorCount(),
@@ -50,12 +56,14 @@ func (w *ConsoleFileWriter) Write(report *FileAnalysisReport, options OutputOpti
 				item.Breakdown.OwnershipComponent,
 				item.Breakdown.BugfixComponent,
 				item.Breakdown.ComplexityComponent,
+				item.Breakdown.CouplingComponent,
 			)
 		} else {
-			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%d\t%d\t%d\t%.2f\t%d\t%d\n",
+			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%s\t%d\t%d\t%d\t%.2f\t%d\t%d\n",
 				i+1,
 				item.Path,
 				item.RiskScore,
+				levelColor(level),
 				item.Metrics.CommitCount,
 				item.Metrics.ChurnTotal(),
 				item.Metrics.ContributorCount(),
@@ -69,7 +77,12 @@ func (w *ConsoleFileWriter) Write(report *FileAnalysisReport, options OutputOpti
 	tw.Flush()
 
 	if options.Explain {
-		fmt.Println("\nScore breakdown: C=Commit, Ch=Churn, R=Recency, B=Burst, O=Ownership, Bf=Bugfix, Cx=Complexity")
+		fmt.Println("\nScore breakdown: C=Commit, Ch=Churn, R=Recency, B=Burst, O=Ownership, Bf=Bugfix, Cx=Complexity, Cp=Coupling")
+		for i, item := range items {
+			if text := overrideText(item.Breakdown); text != "" {
+				fmt.Printf("  #%d %s: %s\n", i+1, item.Path, text)
+			}
+		}
 	}
 
 	return nil
@@ -92,7 +105,7 @@ func (w *ConsoleCommitWriter) Write(report *CommitAnalysisReport, options Output
 
 	// Write header
 	if options.Explain {
-		fmt.Fprintln(tw, "#\tSHA\tScore\tLevel\tFiles\tChurn\tEntropy\tMessage\tD\tS\tE")
+		fmt.Fprintln(tw, "#\tSHA\tScore\tLevel\tFiles\tChurn\tEntropy\tMessage\tD\tS\tE\tB")
 	} else {
 		fmt.Fprintln(tw, "#\tSHA\tScore\tLevel\tFiles\tChurn\tEntropy\tMessage")
 	}
@@ -101,7 +114,7 @@ func (w *ConsoleCommitWriter) Write(report *CommitAnalysisReport, options Output
 	for i, item := range items {
 		levelColor := getLevelColor(string(item.RiskLevel))
 		if options.Explain && item.Breakdown != nil {
-			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%s\t%d\t%d\t%.2f\t%s\t%.3f\t%.3f\t%.3f\n",
+			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%s\t%d\t%d\t%.2f\t%s\t%.3f\t%.3f\t%.3f\t%.3f\n",
 				i+1,
 				item.Metrics.SHA[:8],
 				item.RiskScore,
@@ -113,6 +126,7 @@ func (w *ConsoleCommitWriter) Write(report *CommitAnalysisReport, options Output
 				item.Breakdown.DiffusionComponent,
 				item.Breakdown.SizeComponent,
 				item.Breakdown.EntropyComponent,
+				item.Breakdown.BreakingComponent,
 			)
 		} else {
 			fmt.Fprintf(tw, "%d\t%s\t%.4f\t%s\t%d\t%d\t%.2f\t%s\n",
@@ -131,7 +145,119 @@ func (w *ConsoleCommitWriter) Write(report *CommitAnalysisReport, options Output
 	tw.Flush()
 
 	if options.Explain {
-		fmt.Println("\nScore breakdown: D=Diffusion, S=Size, E=Entropy")
+		fmt.Println("\nScore breakdown: D=Diffusion, S=Size, E=Entropy, B=Breaking")
+	}
+
+	if len(report.Scopes) > 0 {
+		fmt.Println()
+		color.Green("Commits by Scope")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Scope\tCommits\tHigh\tBreaking\tMax\tMean")
+		for _, g := range report.Scopes {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.4f\t%.4f\n",
+				scopeLabel(g.Scope), g.Commits, g.HighRisk, g.Breaking, g.MaxScore, g.MeanScore)
+		}
+		tw.Flush()
+	}
+
+	return nil
+}
+
+// ConsoleFunctionWriter writes function analysis reports to the console.
+type ConsoleFunctionWriter struct{}
+
+// Write outputs the function analysis report to the console.
+func (w *ConsoleFunctionWriter) Write(report *FunctionAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	color.Green("Function Hotspot Analysis Results")
+	fmt.Printf("Repository: %s\n", report.RepoPath)
+	label, value := dateRangeLabelAndValue(report.Since, report.Until)
+	fmt.Printf("%s: %s\n", label, value)
+	fmt.Printf("Total functions analyzed: %d\n\n", len(report.Items))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	// Write header
+	if options.Explain {
+		fmt.Fprintln(tw, "#\tFunction\tLocation\tScore\tLevel\tCommits\tChurn\tBugfixes\tC\tCh\tBf\tR")
+	} else {
+		fmt.Fprintln(tw, "#\tFunction\tLocation\tScore\tLevel\tCommits\tChurn\tBugfixes")
+	}
+
+	// Write rows
+	for i, item := range items {
+		m := item.Metrics
+		levelColor := getLevelColor(string(item.RiskLevel))
+		if options.Explain && item.Breakdown != nil {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%.4f\t%s\t%d\t%d\t%d\t%.3f\t%.3f\t%.3f\t%.3f\n",
+				i+1, m.Name, functionLocation(m), item.RiskScore, levelColor(string(item.RiskLevel)),
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+				item.Breakdown.CommitComponent, item.Breakdown.ChurnComponent,
+				item.Breakdown.BugfixComponent, item.Breakdown.RecencyComponent,
+			)
+		} else {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%.4f\t%s\t%d\t%d\t%d\n",
+				i+1, m.Name, functionLocation(m), item.RiskScore, levelColor(string(item.RiskLevel)),
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+			)
+		}
+	}
+
+	tw.Flush()
+
+	if options.Explain {
+		fmt.Println("\nScore breakdown: C=Commit, Ch=Churn, Bf=Bugfix, R=Recency")
+	}
+
+	return nil
+}
+
+// ConsoleRangeWriter writes line range analysis reports to the console.
+type ConsoleRangeWriter struct{}
+
+// Write outputs the line range analysis report to the console.
+func (w *ConsoleRangeWriter) Write(report *RangeAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	color.Green("Line Range Hotspot Analysis Results")
+	fmt.Printf("Repository: %s\n", report.RepoPath)
+	label, value := dateRangeLabelAndValue(report.Since, report.Until)
+	fmt.Printf("%s: %s\n", label, value)
+	fmt.Printf("Total ranges analyzed: %d\n\n", len(report.Items))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	// Write header
+	if options.Explain {
+		fmt.Fprintln(tw, "#\tLocation\tLines\tScore\tLevel\tCommits\tChurn\tBugfixes\tC\tCh\tBf\tR")
+	} else {
+		fmt.Fprintln(tw, "#\tLocation\tLines\tScore\tLevel\tCommits\tChurn\tBugfixes")
+	}
+
+	// Write rows
+	for i, item := range items {
+		m := item.Metrics
+		levelColor := getLevelColor(string(item.RiskLevel))
+		if options.Explain && item.Breakdown != nil {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%.4f\t%s\t%d\t%d\t%d\t%.3f\t%.3f\t%.3f\t%.3f\n",
+				i+1, rangeLocation(m), m.LineCount(), item.RiskScore, levelColor(string(item.RiskLevel)),
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+				item.Breakdown.CommitComponent, item.Breakdown.ChurnComponent,
+				item.Breakdown.BugfixComponent, item.Breakdown.RecencyComponent,
+			)
+		} else {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%.4f\t%s\t%d\t%d\t%d\n",
+				i+1, rangeLocation(m), m.LineCount(), item.RiskScore, levelColor(string(item.RiskLevel)),
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+			)
+		}
+	}
+
+	tw.Flush()
+
+	if options.Explain {
+		fmt.Println("\nScore breakdown: C=Commit, Ch=Churn, Bf=Bugfix, R=Recency")
 	}
 
 	return nil
@@ -178,11 +304,92 @@ func (w *ConsoleCouplingWriter) Write(report *CouplingAnalysisReport, options Ou
 
 	tw.Flush()
 
+	if result.Clusters != nil {
+		writeConsoleClusters(result.Clusters, options.Top)
+	}
+	if result.CrossBoundary != nil {
+		writeConsoleCrossBoundary(result.CrossBoundary, options.Top)
+	}
+
 	return nil
 }
 
+func writeConsoleCrossBoundary(cross *coupling.CrossBoundaryResult, top int) {
+	fmt.Println()
+	color.Green("Cross-Boundary Coupling (%d of %d pairs)", len(cross.Pairs), cross.TotalPairs)
+	if len(cross.Pairs) == 0 {
+		fmt.Println("No cross-boundary couplings found.")
+	} else {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "#\tModule A\tFile A\tModule B\tFile B\tCo-Commits\tJaccard")
+		for i, p := range limitTop(cross.Pairs, top) {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%.3f\n",
+				i+1, p.BoundaryA, p.FileA, p.BoundaryB, p.FileB, p.CoCommitCount, p.JaccardCoefficient)
+		}
+		tw.Flush()
+	}
+
+	if len(cross.Matrix.Boundaries) < 2 {
+		return
+	}
+
+	fmt.Println()
+	color.Green("Boundary Matrix (coupled pairs)")
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\t"+strings.Join(cross.Matrix.Boundaries, "\t"))
+	for i, name := range cross.Matrix.Boundaries {
+		cells := make([]string, len(cross.Matrix.Counts[i]))
+		for j, n := range cross.Matrix.Counts[i] {
+			cells[j] = fmt.Sprintf("%d", n)
+		}
+		fmt.Fprintln(tw, name+"\t"+strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+}
+
+func writeConsoleClusters(clusters *coupling.ClusterAnalysisResult, top int) {
+	fmt.Println()
+	color.Green("Change Clusters (modularity %.3f)", clusters.Modularity)
+	if len(clusters.Clusters) == 0 {
+		fmt.Println("No clusters found.")
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tFiles\tCohesion\tDirs\tMembers")
+	for _, cl := range limitTop(clusters.Clusters, top) {
+		dirs := fmt.Sprintf("%d", len(cl.Directories))
+		if cl.SpansDirectories() {
+			dirs = color.YellowString(dirs)
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%.3f\t%s\t%s\n",
+			cl.ID, len(cl.Files), cl.Cohesion, dirs, strings.Join(cl.Files, ", "))
+	}
+	tw.Flush()
+
+	if len(clusters.Bridges) == 0 {
+		return
+	}
+
+	fmt.Println()
+	color.Green("Bridge Files")
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Path\tCluster\tLinked Clusters\tExternal")
+	for _, b := range limitTop(clusters.Bridges, top) {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.3f\n", b.Path, clusterLabel(b.ClusterID), b.ConnectedClusters, b.ExternalRatio)
+	}
+	tw.Flush()
+}
+
 // Helper functions
 
+func clusterLabel(id int) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", id)
+}
+
 func truncateMessage(msg string, maxLen int) string {
 	if len(msg) <= maxLen {
 		return msg
@@ -22,11 +22,11 @@ func (w *CSVFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 	}
 
 	// Write header
-	headers := []string{"Path", "RiskScore", "CommitCount", "ChurnAdded", "ChurnDeleted", "ChurnTotal",
-		"LastModified", "Contributors", "BurstScore", "OwnershipRatio", "BugfixCount", "FileSize"}
+	headers := []string{"Path", "RiskScore", "RiskLevel", "CommitCount", "ChurnAdded", "ChurnDeleted", "ChurnTotal",
+		"LastModified", "Contributors", "BurstScore", "OwnershipRatio", "BugfixCount", "FileSize", "CouplingPartners", "CouplingDegree"}
 	if options.Explain {
 		headers = append(headers, "CommitComponent", "ChurnComponent", "RecencyComponent",
-			"BurstComponent", "OwnershipComponent", "BugfixComponent", "ComplexityComponent")
+			"BurstComponent", "OwnershipComponent", "BugfixComponent", "ComplexityComponent", "CouplingComponent")
 	}
 	if err := writer.Write(headers); err != nil {
 		return err
@@ -37,6 +37,7 @@ func (w *CSVFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 		row := []string{
 			item.Path,
 			fmt.Sprintf("%.6f", item.RiskScore),
+			string(report.riskLevel(item)),
 			fmt.Sprintf("%d", item.Metrics.CommitCount),
 			fmt.Sprintf("%d", item.Metrics.AddedLines),
 			fmt.Sprintf("%d", item.Metrics.DeletedLines),
@@ -47,6 +48,8 @@ func (w *CSVFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 			fmt.Sprintf("%.6f", item.Metrics.OwnershipRatio()),
 			fmt.Sprintf("%d", item.Metrics.BugfixCount),
 			fmt.Sprintf("%d", item.Metrics.FileSize),
+			fmt.Sprintf("%d", item.Metrics.CouplingPartners),
+			fmt.Sprintf("%.6f", item.Metrics.CouplingDegree),
 		}
 		if options.Explain && item.Breakdown != nil {
 			row = append(row,
@@ -57,6 +60,7 @@ func (w *CSVFileWriter) Write(report *FileAnalysisReport, options OutputOptions)
 				fmt.Sprintf("%.6f", item.Breakdown.OwnershipComponent),
 				fmt.Sprintf("%.6f", item.Breakdown.BugfixComponent),
 				fmt.Sprintf("%.6f", item.Breakdown.ComplexityComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.CouplingComponent),
 			)
 		}
 		if err := writer.Write(row); err != nil {
@@ -88,7 +92,7 @@ func (w *CSVCommitWriter) Write(report *CommitAnalysisReport, options OutputOpti
 		"FileCount", "DirectoryCount", "SubsystemCount", "LinesAdded", "LinesDeleted",
 		"TotalChurn", "ChangeEntropy"}
 	if options.Explain {
-		headers = append(headers, "DiffusionComponent", "SizeComponent", "EntropyComponent")
+		headers = append(headers, "DiffusionComponent", "SizeComponent", "EntropyComponent", "BreakingComponent")
 	}
 	if err := writer.Write(headers); err != nil {
 		return err
@@ -116,6 +120,124 @@ func (w *CSVCommitWriter) Write(report *CommitAnalysisReport, options OutputOpti
 				fmt.Sprintf("%.6f", item.Breakdown.DiffusionComponent),
 				fmt.Sprintf("%.6f", item.Breakdown.SizeComponent),
 				fmt.Sprintf("%.6f", item.Breakdown.EntropyComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.BreakingComponent),
+			)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// CSVFunctionWriter writes function analysis reports as CSV.
+type CSVFunctionWriter struct{}
+
+// Write outputs the function analysis report as CSV.
+func (w *CSVFunctionWriter) Write(report *FunctionAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	writer, file, err := createCSVWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	// Write header
+	headers := []string{"Path", "Function", "StartLine", "EndLine", "RiskScore", "RiskLevel", "CommitCount",
+		"ChurnAdded", "ChurnDeleted", "ChurnTotal", "BugfixCount", "LastModified"}
+	if options.Explain {
+		headers = append(headers, "CommitComponent", "ChurnComponent", "BugfixComponent", "RecencyComponent")
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for _, item := range items {
+		m := item.Metrics
+		row := []string{
+			m.Path,
+			m.Name,
+			fmt.Sprintf("%d", m.StartLine),
+			fmt.Sprintf("%d", m.EndLine),
+			fmt.Sprintf("%.6f", item.RiskScore),
+			string(item.RiskLevel),
+			fmt.Sprintf("%d", m.CommitCount),
+			fmt.Sprintf("%d", m.AddedLines),
+			fmt.Sprintf("%d", m.DeletedLines),
+			fmt.Sprintf("%d", m.ChurnTotal()),
+			fmt.Sprintf("%d", m.BugfixCount),
+			m.LastModifiedAt.Format(reportDateTimeLayout),
+		}
+		if options.Explain && item.Breakdown != nil {
+			row = append(row,
+				fmt.Sprintf("%.6f", item.Breakdown.CommitComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.ChurnComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.BugfixComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.RecencyComponent),
+			)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// CSVRangeWriter writes line range analysis reports as CSV.
+type CSVRangeWriter struct{}
+
+// Write outputs the line range analysis report as CSV.
+func (w *CSVRangeWriter) Write(report *RangeAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	writer, file, err := createCSVWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	// Write header
+	headers := []string{"Path", "StartLine", "EndLine", "RiskScore", "RiskLevel", "CommitCount",
+		"ChurnAdded", "ChurnDeleted", "ChurnTotal", "BugfixCount", "LastModified"}
+	if options.Explain {
+		headers = append(headers, "CommitComponent", "ChurnComponent", "BugfixComponent", "RecencyComponent")
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	// Write data
+	for _, item := range items {
+		m := item.Metrics
+		row := []string{
+			m.Path,
+			fmt.Sprintf("%d", m.StartLine),
+			fmt.Sprintf("%d", m.EndLine),
+			fmt.Sprintf("%.6f", item.RiskScore),
+			string(item.RiskLevel),
+			fmt.Sprintf("%d", m.CommitCount),
+			fmt.Sprintf("%d", m.AddedLines),
+			fmt.Sprintf("%d", m.DeletedLines),
+			fmt.Sprintf("%d", m.ChurnTotal()),
+			fmt.Sprintf("%d", m.BugfixCount),
+			m.LastModifiedAt.Format(reportDateTimeLayout),
+		}
+		if options.Explain && item.Breakdown != nil {
+			row = append(row,
+				fmt.Sprintf("%.6f", item.Breakdown.CommitComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.ChurnComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.BugfixComponent),
+				fmt.Sprintf("%.6f", item.Breakdown.RecencyComponent),
 			)
 		}
 		if err := writer.Write(row); err != nil {
@@ -1,9 +1,13 @@
 package output
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/masmgr/bugspots-go/config"
 	"github.com/masmgr/bugspots-go/internal/coupling"
+	"github.com/masmgr/bugspots-go/internal/git"
 	"github.com/masmgr/bugspots-go/internal/scoring"
 )
 
@@ -16,37 +20,91 @@ var (
 	_ FileReportWriter = (*CSVFileWriter)(nil)
 	_ FileReportWriter = (*MarkdownFileWriter)(nil)
 	_ FileReportWriter = (*CIFileWriter)(nil)
+	_ FileReportWriter = (*SARIFFileWriter)(nil)
+	_ FileReportWriter = (*GitLabFileWriter)(nil)
+	_ FileReportWriter = (*CheckstyleFileWriter)(nil)
+	_ FileReportWriter = (*JUnitFileWriter)(nil)
+	_ FileReportWriter = (*HTMLFileWriter)(nil)
+	_ FileReportWriter = (*SVGFileWriter)(nil)
+	_ FileReportWriter = (*BadgeFileWriter)(nil)
+	_ FileReportWriter = (*OpenMetricsFileWriter)(nil)
+	_ FileReportWriter = (*SQLiteFileWriter)(nil)
+	_ FileReportWriter = (*TemplateFileWriter)(nil)
 
 	// CommitReportWriter implementations
 	_ CommitReportWriter = (*ConsoleCommitWriter)(nil)
 	_ CommitReportWriter = (*JSONCommitWriter)(nil)
 	_ CommitReportWriter = (*CSVCommitWriter)(nil)
 	_ CommitReportWriter = (*MarkdownCommitWriter)(nil)
+	_ CommitReportWriter = (*CICommitWriter)(nil)
+	_ CommitReportWriter = (*SARIFCommitWriter)(nil)
+	_ CommitReportWriter = (*JUnitCommitWriter)(nil)
+	_ CommitReportWriter = (*HTMLCommitWriter)(nil)
+	_ CommitReportWriter = (*OpenMetricsCommitWriter)(nil)
+	_ CommitReportWriter = (*SQLiteCommitWriter)(nil)
+	_ CommitReportWriter = (*TemplateCommitWriter)(nil)
 
 	// CouplingReportWriter implementations
 	_ CouplingReportWriter = (*ConsoleCouplingWriter)(nil)
 	_ CouplingReportWriter = (*JSONCouplingWriter)(nil)
 	_ CouplingReportWriter = (*CSVCouplingWriter)(nil)
 	_ CouplingReportWriter = (*MarkdownCouplingWriter)(nil)
+	_ CouplingReportWriter = (*CICouplingWriter)(nil)
+	_ CouplingReportWriter = (*DOTCouplingWriter)(nil)
+	_ CouplingReportWriter = (*GraphMLCouplingWriter)(nil)
+	_ CouplingReportWriter = (*MermaidCouplingWriter)(nil)
+	_ CouplingReportWriter = (*GraphJSONCouplingWriter)(nil)
+	_ CouplingReportWriter = (*HTMLCouplingWriter)(nil)
+	_ CouplingReportWriter = (*SQLiteCouplingWriter)(nil)
+	_ CouplingReportWriter = (*TemplateCouplingWriter)(nil)
+
+	// FunctionReportWriter implementations
+	_ FunctionReportWriter = (*ConsoleFunctionWriter)(nil)
+	_ FunctionReportWriter = (*JSONFunctionWriter)(nil)
+	_ FunctionReportWriter = (*CSVFunctionWriter)(nil)
+	_ FunctionReportWriter = (*MarkdownFunctionWriter)(nil)
+
+	// RangeReportWriter implementations
+	_ RangeReportWriter = (*ConsoleRangeWriter)(nil)
+	_ RangeReportWriter = (*JSONRangeWriter)(nil)
+	_ RangeReportWriter = (*CSVRangeWriter)(nil)
+	_ RangeReportWriter = (*MarkdownRangeWriter)(nil)
 )
 
 // OutputFormat represents the output format type.
 type OutputFormat string
 
 const (
-	FormatConsole  OutputFormat = "console"
-	FormatJSON     OutputFormat = "json"
-	FormatCSV      OutputFormat = "csv"
-	FormatMarkdown OutputFormat = "markdown"
-	FormatCI       OutputFormat = "ci"
+	FormatConsole     OutputFormat = "console"
+	FormatJSON        OutputFormat = "json"
+	FormatCSV         OutputFormat = "csv"
+	FormatMarkdown    OutputFormat = "markdown"
+	FormatCI          OutputFormat = "ci"
+	FormatDOT         OutputFormat = "dot"
+	FormatGraphML     OutputFormat = "graphml"
+	FormatMermaid     OutputFormat = "mermaid"
+	FormatGraph       OutputFormat = "graph-json"
+	FormatSARIF       OutputFormat = "sarif"
+	FormatGitLab      OutputFormat = "gitlab"
+	FormatCheckstyle  OutputFormat = "checkstyle"
+	FormatJUnit       OutputFormat = "junit"
+	FormatHTML        OutputFormat = "html"
+	FormatSVG         OutputFormat = "svg"
+	FormatBadge       OutputFormat = "badge"
+	FormatOpenMetrics OutputFormat = "openmetrics"
+	FormatSQLite      OutputFormat = "sqlite"
+	FormatTemplate    OutputFormat = "template"
 )
 
 // OutputOptions controls output behavior.
 type OutputOptions struct {
-	Format     OutputFormat
-	Top        int
-	OutputPath string
-	Explain    bool
+	Format       OutputFormat
+	Top          int
+	OutputPath   string
+	TemplatePath string // User template for the template format
+	Explain      bool
+	Threshold    float64      // Failure threshold for JUnit file reports; 0 uses the high risk threshold
+	Graph        GraphOptions // Graph export options (coupling graph formats only)
 }
 
 // FileAnalysisReport holds the results of file hotspot analysis.
@@ -56,6 +114,8 @@ type FileAnalysisReport struct {
 	Until       time.Time
 	GeneratedAt time.Time
 	Items       []scoring.FileRiskItem
+	Thresholds  config.RiskThresholds // Thresholds behind each item's RiskLevel; zero means the defaults
+	ChangeSets  []git.CommitChangeSet // Optional analyzed history, exported by the SQLite writer
 }
 
 // CommitAnalysisReport holds the results of commit risk analysis.
@@ -65,6 +125,9 @@ type CommitAnalysisReport struct {
 	Until       time.Time
 	GeneratedAt time.Time
 	Items       []scoring.CommitRiskItem
+	Thresholds  config.RiskThresholds // Thresholds behind each item's RiskLevel; zero means the defaults
+	Scopes      []scoring.ScopeGroup  // Optional per-scope summary, from --by-scope
+	ChangeSets  []git.CommitChangeSet // Optional analyzed history, exported by the SQLite writer
 }
 
 // CouplingAnalysisReport holds the results of coupling analysis.
@@ -74,6 +137,27 @@ type CouplingAnalysisReport struct {
 	Until       time.Time
 	GeneratedAt time.Time
 	Result      coupling.CouplingAnalysisResult
+	FileScores  map[string]float64    // Optional hotspot scores by path, used for graph node sizing
+	Thresholds  config.RiskThresholds // File thresholds classifying FileScores; zero means the defaults
+	ChangeSets  []git.CommitChangeSet // Optional analyzed history, exported by the SQLite writer
+}
+
+// FunctionAnalysisReport holds the results of Go function hotspot analysis.
+type FunctionAnalysisReport struct {
+	RepoPath    string
+	Since       *time.Time
+	Until       time.Time
+	GeneratedAt time.Time
+	Items       []scoring.FunctionRiskItem
+}
+
+// RangeAnalysisReport holds the results of line range hotspot analysis.
+type RangeAnalysisReport struct {
+	RepoPath    string
+	Since       *time.Time
+	Until       time.Time
+	GeneratedAt time.Time
+	Items       []scoring.RangeRiskItem
 }
 
 // FileReportWriter writes file analysis reports.
@@ -91,46 +175,154 @@ type CouplingReportWriter interface {
 	Write(report *CouplingAnalysisReport, options OutputOptions) error
 }
 
-// NewFileReportWriter creates a report writer for the specified format.
-func NewFileReportWriter(format OutputFormat) FileReportWriter {
+// FunctionReportWriter writes function analysis reports.
+type FunctionReportWriter interface {
+	Write(report *FunctionAnalysisReport, options OutputOptions) error
+}
+
+// RangeReportWriter writes line range analysis reports.
+type RangeReportWriter interface {
+	Write(report *RangeAnalysisReport, options OutputOptions) error
+}
+
+// ErrUnsupportedFormat is returned by the writer constructors when a format has no
+// writer for the requested report type.
+var ErrUnsupportedFormat = errors.New("unsupported output format")
+
+func unsupportedFormat(format OutputFormat, report string) error {
+	return fmt.Errorf("%w %q for %s reports", ErrUnsupportedFormat, format, report)
+}
+
+// NewFileReportWriter creates a report writer for the specified format. The empty
+// format selects console output; formats without a file writer return an error
+// wrapping ErrUnsupportedFormat.
+func NewFileReportWriter(format OutputFormat) (FileReportWriter, error) {
 	switch format {
 	case FormatJSON:
-		return &JSONFileWriter{}
+		return &JSONFileWriter{}, nil
 	case FormatCSV:
-		return &CSVFileWriter{}
+		return &CSVFileWriter{}, nil
 	case FormatMarkdown:
-		return &MarkdownFileWriter{}
+		return &MarkdownFileWriter{}, nil
 	case FormatCI:
-		return &CIFileWriter{}
+		return &CIFileWriter{}, nil
+	case FormatSARIF:
+		return &SARIFFileWriter{}, nil
+	case FormatGitLab:
+		return &GitLabFileWriter{}, nil
+	case FormatCheckstyle:
+		return &CheckstyleFileWriter{}, nil
+	case FormatJUnit:
+		return &JUnitFileWriter{}, nil
+	case FormatHTML:
+		return &HTMLFileWriter{}, nil
+	case FormatSVG:
+		return &SVGFileWriter{}, nil
+	case FormatBadge:
+		return &BadgeFileWriter{}, nil
+	case FormatOpenMetrics:
+		return &OpenMetricsFileWriter{}, nil
+	case FormatSQLite:
+		return &SQLiteFileWriter{}, nil
+	case FormatTemplate:
+		return &TemplateFileWriter{}, nil
+	case FormatConsole, "":
+		return &ConsoleFileWriter{}, nil
 	default:
-		return &ConsoleFileWriter{}
+		return nil, unsupportedFormat(format, "file")
 	}
 }
 
 // NewCommitReportWriter creates a commit report writer for the specified format.
-func NewCommitReportWriter(format OutputFormat) CommitReportWriter {
+func NewCommitReportWriter(format OutputFormat) (CommitReportWriter, error) {
 	switch format {
 	case FormatJSON:
-		return &JSONCommitWriter{}
+		return &JSONCommitWriter{}, nil
 	case FormatCSV:
-		return &CSVCommitWriter{}
+		return &CSVCommitWriter{}, nil
 	case FormatMarkdown:
-		return &MarkdownCommitWriter{}
+		return &MarkdownCommitWriter{}, nil
+	case FormatCI:
+		return &CICommitWriter{}, nil
+	case FormatSARIF:
+		return &SARIFCommitWriter{}, nil
+	case FormatJUnit:
+		return &JUnitCommitWriter{}, nil
+	case FormatHTML:
+		return &HTMLCommitWriter{}, nil
+	case FormatOpenMetrics:
+		return &OpenMetricsCommitWriter{}, nil
+	case FormatSQLite:
+		return &SQLiteCommitWriter{}, nil
+	case FormatTemplate:
+		return &TemplateCommitWriter{}, nil
+	case FormatConsole, "":
+		return &ConsoleCommitWriter{}, nil
 	default:
-		return &ConsoleCommitWriter{}
+		return nil, unsupportedFormat(format, "commit")
 	}
 }
 
 // NewCouplingReportWriter creates a coupling report writer for the specified format.
-func NewCouplingReportWriter(format OutputFormat) CouplingReportWriter {
+func NewCouplingReportWriter(format OutputFormat) (CouplingReportWriter, error) {
+	switch format {
+	case FormatJSON:
+		return &JSONCouplingWriter{}, nil
+	case FormatCSV:
+		return &CSVCouplingWriter{}, nil
+	case FormatMarkdown:
+		return &MarkdownCouplingWriter{}, nil
+	case FormatCI:
+		return &CICouplingWriter{}, nil
+	case FormatDOT:
+		return &DOTCouplingWriter{}, nil
+	case FormatGraphML:
+		return &GraphMLCouplingWriter{}, nil
+	case FormatMermaid:
+		return &MermaidCouplingWriter{}, nil
+	case FormatGraph:
+		return &GraphJSONCouplingWriter{}, nil
+	case FormatHTML:
+		return &HTMLCouplingWriter{}, nil
+	case FormatSQLite:
+		return &SQLiteCouplingWriter{}, nil
+	case FormatTemplate:
+		return &TemplateCouplingWriter{}, nil
+	case FormatConsole, "":
+		return &ConsoleCouplingWriter{}, nil
+	default:
+		return nil, unsupportedFormat(format, "coupling")
+	}
+}
+
+// NewFunctionReportWriter creates a function report writer for the specified format.
+func NewFunctionReportWriter(format OutputFormat) (FunctionReportWriter, error) {
+	switch format {
+	case FormatJSON:
+		return &JSONFunctionWriter{}, nil
+	case FormatCSV:
+		return &CSVFunctionWriter{}, nil
+	case FormatMarkdown:
+		return &MarkdownFunctionWriter{}, nil
+	case FormatConsole, "":
+		return &ConsoleFunctionWriter{}, nil
+	default:
+		return nil, unsupportedFormat(format, "function")
+	}
+}
+
+// NewRangeReportWriter creates a line range report writer for the specified format.
+func NewRangeReportWriter(format OutputFormat) (RangeReportWriter, error) {
 	switch format {
 	case FormatJSON:
-		return &JSONCouplingWriter{}
+		return &JSONRangeWriter{}, nil
 	case FormatCSV:
-		return &CSVCouplingWriter{}
+		return &CSVRangeWriter{}, nil
 	case FormatMarkdown:
-		return &MarkdownCouplingWriter{}
+		return &MarkdownRangeWriter{}, nil
+	case FormatConsole, "":
+		return &ConsoleRangeWriter{}, nil
 	default:
-		return &ConsoleCouplingWriter{}
+		return nil, unsupportedFormat(format, "line range")
 	}
 }
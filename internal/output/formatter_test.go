@@ -1,6 +1,10 @@
 package output
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestNewFileReportWriter(t *testing.T) {
 	tests := []struct {
@@ -9,37 +13,31 @@ func TestNewFileReportWriter(t *testing.T) {
 		expectedType string
 	}{
 		{name: "Console", format: FormatConsole, expectedType: "*output.ConsoleFileWriter"},
+		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleFileWriter"},
 		{name: "JSON", format: FormatJSON, expectedType: "*output.JSONFileWriter"},
 		{name: "CSV", format: FormatCSV, expectedType: "*output.CSVFileWriter"},
 		{name: "Markdown", format: FormatMarkdown, expectedType: "*output.MarkdownFileWriter"},
-		{name: "Unknown defaults to Console", format: "unknown", expectedType: "*output.ConsoleFileWriter"},
-		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleFileWriter"},
+		{name: "CI", format: FormatCI, expectedType: "*output.CIFileWriter"},
+		{name: "SARIF", format: FormatSARIF, expectedType: "*output.SARIFFileWriter"},
+		{name: "GitLab", format: FormatGitLab, expectedType: "*output.GitLabFileWriter"},
+		{name: "Checkstyle", format: FormatCheckstyle, expectedType: "*output.CheckstyleFileWriter"},
+		{name: "JUnit", format: FormatJUnit, expectedType: "*output.JUnitFileWriter"},
+		{name: "HTML", format: FormatHTML, expectedType: "*output.HTMLFileWriter"},
+		{name: "SVG", format: FormatSVG, expectedType: "*output.SVGFileWriter"},
+		{name: "Badge", format: FormatBadge, expectedType: "*output.BadgeFileWriter"},
+		{name: "OpenMetrics", format: FormatOpenMetrics, expectedType: "*output.OpenMetricsFileWriter"},
+		{name: "SQLite", format: FormatSQLite, expectedType: "*output.SQLiteFileWriter"},
+		{name: "Template", format: FormatTemplate, expectedType: "*output.TemplateFileWriter"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			writer := NewFileReportWriter(tt.format)
-			if writer == nil {
-				t.Fatal("NewFileReportWriter returned nil")
+			writer, err := NewFileReportWriter(tt.format)
+			if err != nil {
+				t.Fatalf("NewFileReportWriter(%q) error: %v", tt.format, err)
 			}
-
-			switch tt.format {
-			case FormatJSON:
-				if _, ok := writer.(*JSONFileWriter); !ok {
-					t.Errorf("Expected *JSONFileWriter for format %q", tt.format)
-				}
-			case FormatCSV:
-				if _, ok := writer.(*CSVFileWriter); !ok {
-					t.Errorf("Expected *CSVFileWriter for format %q", tt.format)
-				}
-			case FormatMarkdown:
-				if _, ok := writer.(*MarkdownFileWriter); !ok {
-					t.Errorf("Expected *MarkdownFileWriter for format %q", tt.format)
-				}
-			default:
-				if _, ok := writer.(*ConsoleFileWriter); !ok {
-					t.Errorf("Expected *ConsoleFileWriter for format %q", tt.format)
-				}
+			if got := fmt.Sprintf("%T", writer); got != tt.expectedType {
+				t.Errorf("NewFileReportWriter(%q) = %s, want %s", tt.format, got, tt.expectedType)
 			}
 		})
 	}
@@ -47,40 +45,32 @@ func TestNewFileReportWriter(t *testing.T) {
 
 func TestNewCommitReportWriter(t *testing.T) {
 	tests := []struct {
-		name   string
-		format OutputFormat
+		name         string
+		format       OutputFormat
+		expectedType string
 	}{
-		{name: "Console", format: FormatConsole},
-		{name: "JSON", format: FormatJSON},
-		{name: "CSV", format: FormatCSV},
-		{name: "Markdown", format: FormatMarkdown},
-		{name: "Unknown defaults to Console", format: "unknown"},
+		{name: "Console", format: FormatConsole, expectedType: "*output.ConsoleCommitWriter"},
+		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleCommitWriter"},
+		{name: "JSON", format: FormatJSON, expectedType: "*output.JSONCommitWriter"},
+		{name: "CSV", format: FormatCSV, expectedType: "*output.CSVCommitWriter"},
+		{name: "Markdown", format: FormatMarkdown, expectedType: "*output.MarkdownCommitWriter"},
+		{name: "CI", format: FormatCI, expectedType: "*output.CICommitWriter"},
+		{name: "SARIF", format: FormatSARIF, expectedType: "*output.SARIFCommitWriter"},
+		{name: "JUnit", format: FormatJUnit, expectedType: "*output.JUnitCommitWriter"},
+		{name: "HTML", format: FormatHTML, expectedType: "*output.HTMLCommitWriter"},
+		{name: "OpenMetrics", format: FormatOpenMetrics, expectedType: "*output.OpenMetricsCommitWriter"},
+		{name: "SQLite", format: FormatSQLite, expectedType: "*output.SQLiteCommitWriter"},
+		{name: "Template", format: FormatTemplate, expectedType: "*output.TemplateCommitWriter"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			writer := NewCommitReportWriter(tt.format)
-			if writer == nil {
-				t.Fatal("NewCommitReportWriter returned nil")
+			writer, err := NewCommitReportWriter(tt.format)
+			if err != nil {
+				t.Fatalf("NewCommitReportWriter(%q) error: %v", tt.format, err)
 			}
-
-			switch tt.format {
-			case FormatJSON:
-				if _, ok := writer.(*JSONCommitWriter); !ok {
-					t.Errorf("Expected *JSONCommitWriter for format %q", tt.format)
-				}
-			case FormatCSV:
-				if _, ok := writer.(*CSVCommitWriter); !ok {
-					t.Errorf("Expected *CSVCommitWriter for format %q", tt.format)
-				}
-			case FormatMarkdown:
-				if _, ok := writer.(*MarkdownCommitWriter); !ok {
-					t.Errorf("Expected *MarkdownCommitWriter for format %q", tt.format)
-				}
-			default:
-				if _, ok := writer.(*ConsoleCommitWriter); !ok {
-					t.Errorf("Expected *ConsoleCommitWriter for format %q", tt.format)
-				}
+			if got := fmt.Sprintf("%T", writer); got != tt.expectedType {
+				t.Errorf("NewCommitReportWriter(%q) = %s, want %s", tt.format, got, tt.expectedType)
 			}
 		})
 	}
@@ -88,40 +78,136 @@ func TestNewCommitReportWriter(t *testing.T) {
 
 func TestNewCouplingReportWriter(t *testing.T) {
 	tests := []struct {
-		name   string
-		format OutputFormat
+		name         string
+		format       OutputFormat
+		expectedType string
 	}{
-		{name: "Console", format: FormatConsole},
-		{name: "JSON", format: FormatJSON},
-		{name: "CSV", format: FormatCSV},
-		{name: "Markdown", format: FormatMarkdown},
-		{name: "Unknown defaults to Console", format: "unknown"},
+		{name: "Console", format: FormatConsole, expectedType: "*output.ConsoleCouplingWriter"},
+		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleCouplingWriter"},
+		{name: "JSON", format: FormatJSON, expectedType: "*output.JSONCouplingWriter"},
+		{name: "CSV", format: FormatCSV, expectedType: "*output.CSVCouplingWriter"},
+		{name: "Markdown", format: FormatMarkdown, expectedType: "*output.MarkdownCouplingWriter"},
+		{name: "CI", format: FormatCI, expectedType: "*output.CICouplingWriter"},
+		{name: "DOT", format: FormatDOT, expectedType: "*output.DOTCouplingWriter"},
+		{name: "GraphML", format: FormatGraphML, expectedType: "*output.GraphMLCouplingWriter"},
+		{name: "Mermaid", format: FormatMermaid, expectedType: "*output.MermaidCouplingWriter"},
+		{name: "GraphJSON", format: FormatGraph, expectedType: "*output.GraphJSONCouplingWriter"},
+		{name: "HTML", format: FormatHTML, expectedType: "*output.HTMLCouplingWriter"},
+		{name: "SQLite", format: FormatSQLite, expectedType: "*output.SQLiteCouplingWriter"},
+		{name: "Template", format: FormatTemplate, expectedType: "*output.TemplateCouplingWriter"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			writer := NewCouplingReportWriter(tt.format)
-			if writer == nil {
-				t.Fatal("NewCouplingReportWriter returned nil")
+			writer, err := NewCouplingReportWriter(tt.format)
+			if err != nil {
+				t.Fatalf("NewCouplingReportWriter(%q) error: %v", tt.format, err)
 			}
+			if got := fmt.Sprintf("%T", writer); got != tt.expectedType {
+				t.Errorf("NewCouplingReportWriter(%q) = %s, want %s", tt.format, got, tt.expectedType)
+			}
+		})
+	}
+}
 
-			switch tt.format {
-			case FormatJSON:
-				if _, ok := writer.(*JSONCouplingWriter); !ok {
-					t.Errorf("Expected *JSONCouplingWriter for format %q", tt.format)
-				}
-			case FormatCSV:
-				if _, ok := writer.(*CSVCouplingWriter); !ok {
-					t.Errorf("Expected *CSVCouplingWriter for format %q", tt.format)
-				}
-			case FormatMarkdown:
-				if _, ok := writer.(*MarkdownCouplingWriter); !ok {
-					t.Errorf("Expected *MarkdownCouplingWriter for format %q", tt.format)
-				}
-			default:
-				if _, ok := writer.(*ConsoleCouplingWriter); !ok {
-					t.Errorf("Expected *ConsoleCouplingWriter for format %q", tt.format)
-				}
+func TestNewFunctionReportWriter(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       OutputFormat
+		expectedType string
+	}{
+		{name: "Console", format: FormatConsole, expectedType: "*output.ConsoleFunctionWriter"},
+		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleFunctionWriter"},
+		{name: "JSON", format: FormatJSON, expectedType: "*output.JSONFunctionWriter"},
+		{name: "CSV", format: FormatCSV, expectedType: "*output.CSVFunctionWriter"},
+		{name: "Markdown", format: FormatMarkdown, expectedType: "*output.MarkdownFunctionWriter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, err := NewFunctionReportWriter(tt.format)
+			if err != nil {
+				t.Fatalf("NewFunctionReportWriter(%q) error: %v", tt.format, err)
+			}
+			if got := fmt.Sprintf("%T", writer); got != tt.expectedType {
+				t.Errorf("NewFunctionReportWriter(%q) = %s, want %s", tt.format, got, tt.expectedType)
+			}
+		})
+	}
+}
+
+func TestNewRangeReportWriter(t *testing.T) {
+	tests := []struct {
+		name         string
+		format       OutputFormat
+		expectedType string
+	}{
+		{name: "Console", format: FormatConsole, expectedType: "*output.ConsoleRangeWriter"},
+		{name: "Empty defaults to Console", format: "", expectedType: "*output.ConsoleRangeWriter"},
+		{name: "JSON", format: FormatJSON, expectedType: "*output.JSONRangeWriter"},
+		{name: "CSV", format: FormatCSV, expectedType: "*output.CSVRangeWriter"},
+		{name: "Markdown", format: FormatMarkdown, expectedType: "*output.MarkdownRangeWriter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer, err := NewRangeReportWriter(tt.format)
+			if err != nil {
+				t.Fatalf("NewRangeReportWriter(%q) error: %v", tt.format, err)
+			}
+			if got := fmt.Sprintf("%T", writer); got != tt.expectedType {
+				t.Errorf("NewRangeReportWriter(%q) = %s, want %s", tt.format, got, tt.expectedType)
+			}
+		})
+	}
+}
+
+func TestNewReportWriter_UnsupportedFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		newFunc func() (interface{}, error)
+		wantMsg string
+	}{
+		{
+			name:    "file report as DOT",
+			newFunc: func() (interface{}, error) { return NewFileReportWriter(FormatDOT) },
+			wantMsg: `unsupported output format "dot" for file reports`,
+		},
+		{
+			name:    "commit report as GitLab",
+			newFunc: func() (interface{}, error) { return NewCommitReportWriter(FormatGitLab) },
+			wantMsg: `unsupported output format "gitlab" for commit reports`,
+		},
+		{
+			name:    "coupling report as SARIF",
+			newFunc: func() (interface{}, error) { return NewCouplingReportWriter(FormatSARIF) },
+			wantMsg: `unsupported output format "sarif" for coupling reports`,
+		},
+		{
+			name:    "function report as HTML",
+			newFunc: func() (interface{}, error) { return NewFunctionReportWriter(FormatHTML) },
+			wantMsg: `unsupported output format "html" for function reports`,
+		},
+		{
+			name:    "line range report as SARIF",
+			newFunc: func() (interface{}, error) { return NewRangeReportWriter(FormatSARIF) },
+			wantMsg: `unsupported output format "sarif" for line range reports`,
+		},
+		{
+			name:    "unknown format",
+			newFunc: func() (interface{}, error) { return NewFileReportWriter("yaml") },
+			wantMsg: `unsupported output format "yaml" for file reports`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.newFunc()
+			if !errors.Is(err, ErrUnsupportedFormat) {
+				t.Fatalf("err = %v, want ErrUnsupportedFormat", err)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("err = %q, want %q", err.Error(), tt.wantMsg)
 			}
 		})
 	}
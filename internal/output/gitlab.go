@@ -0,0 +1,75 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// codeQualityCheckName identifies bugspots findings in GitLab's Code Quality widget.
+const codeQualityCheckName = "bugspots/file-risk"
+
+// CodeQualityIssue is a single entry of a GitLab Code Quality report.
+type CodeQualityIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation points a Code Quality issue at a file.
+type CodeQualityLocation struct {
+	Path  string           `json:"path"`
+	Lines CodeQualityLines `json:"lines"`
+}
+
+// CodeQualityLines is the line range of a Code Quality issue.
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// codeQualitySeverity maps a risk level to a Code Quality severity.
+func codeQualitySeverity(level config.RiskLevel) string {
+	switch level {
+	case config.RiskLevelHigh:
+		return "major"
+	case config.RiskLevelMedium:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// pathFingerprint derives a fingerprint from the file path only, so the same hotspot
+// keeps its identity across runs even when its score or risk level changes.
+func pathFingerprint(path string) string {
+	sum := sha256.Sum256([]byte("bugspots-go:file:" + path))
+	return hex.EncodeToString(sum[:])
+}
+
+// GitLabFileWriter writes file analysis reports as GitLab Code Quality JSON.
+type GitLabFileWriter struct{}
+
+// Write outputs the file analysis report as a Code Quality issue array.
+func (w *GitLabFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	issues := make([]CodeQualityIssue, 0, len(items))
+	for i, item := range items {
+		level := report.riskLevel(item)
+		issues = append(issues, CodeQualityIssue{
+			Description: fileHotspotMessage(i+1, item, level),
+			CheckName:   codeQualityCheckName,
+			Fingerprint: pathFingerprint(item.Path),
+			Severity:    codeQualitySeverity(level),
+			Location: CodeQualityLocation{
+				Path:  item.Path,
+				Lines: CodeQualityLines{Begin: 1},
+			},
+		})
+	}
+
+	return writeJSON(issues, options.OutputPath)
+}
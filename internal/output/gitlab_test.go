@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func newTestFileReport() *FileAnalysisReport {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	return &FileAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.FileRiskItem{
+			{Path: "src/hot.go", RiskScore: 0.85, Metrics: &aggregation.FileMetrics{CommitCount: 10, BugfixCount: 3}},
+			{Path: "src/warm.go", RiskScore: 0.50, Metrics: &aggregation.FileMetrics{CommitCount: 5}},
+			{Path: "src/cold.go", RiskScore: 0.20, Metrics: &aggregation.FileMetrics{CommitCount: 2}},
+		},
+	}
+}
+
+func TestGitLabFileWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/gl-code-quality.json"
+	if err := (&GitLabFileWriter{}).Write(newTestFileReport(), OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var issues []CodeQualityIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		t.Fatalf("output is not a JSON array: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	tests := []struct {
+		path     string
+		severity string
+	}{
+		{"src/hot.go", "major"},
+		{"src/warm.go", "minor"},
+		{"src/cold.go", "info"},
+	}
+	for i, tt := range tests {
+		issue := issues[i]
+		if issue.Location.Path != tt.path || issue.Location.Lines.Begin != 1 {
+			t.Errorf("issue %d: location = %+v", i, issue.Location)
+		}
+		if issue.Severity != tt.severity {
+			t.Errorf("issue %d: severity = %q, expected %q", i, issue.Severity, tt.severity)
+		}
+		if issue.CheckName != codeQualityCheckName || issue.Description == "" {
+			t.Errorf("issue %d: check/description = %q/%q", i, issue.CheckName, issue.Description)
+		}
+		if issue.Fingerprint != pathFingerprint(tt.path) {
+			t.Errorf("issue %d: fingerprint = %q", i, issue.Fingerprint)
+		}
+	}
+}
+
+func TestPathFingerprint(t *testing.T) {
+	if pathFingerprint("a.go") != pathFingerprint("a.go") {
+		t.Error("fingerprint is not deterministic")
+	}
+	if pathFingerprint("a.go") == pathFingerprint("b.go") {
+		t.Error("different paths share a fingerprint")
+	}
+	if len(pathFingerprint("a.go")) != 64 {
+		t.Errorf("expected a hex SHA-256 fingerprint, got %q", pathFingerprint("a.go"))
+	}
+}
+
+func TestGitLabFileWriter_EmptyReport(t *testing.T) {
+	tmpFile := t.TempDir() + "/gl-code-quality.json"
+	if err := (&GitLabFileWriter{}).Write(&FileAnalysisReport{}, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	// GitLab rejects null; an empty report must still be an array.
+	if string(data) != "[]\n" {
+		t.Errorf("expected an empty array, got %q", data)
+	}
+}
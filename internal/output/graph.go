@@ -0,0 +1,492 @@
+package output
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GraphEdgeWeight selects the coupling metric used as edge weight in graph exports.
+type GraphEdgeWeight string
+
+const (
+	EdgeWeightJaccard GraphEdgeWeight = "jaccard"
+	EdgeWeightLift    GraphEdgeWeight = "lift"
+)
+
+// GraphNodeSize selects the file metric used as node size in graph exports.
+type GraphNodeSize string
+
+const (
+	NodeSizeCommits GraphNodeSize = "commits"
+	NodeSizeHotspot GraphNodeSize = "hotspot"
+)
+
+// GraphOptions controls how coupling results are rendered as a graph.
+type GraphOptions struct {
+	EdgeWeight   GraphEdgeWeight
+	NodeSize     GraphNodeSize
+	ClusterByDir bool // Group nodes into clusters by parent directory
+}
+
+// couplingGraph is the format-independent node/edge view of a coupling report.
+type couplingGraph struct {
+	Nodes    []graphNode
+	Edges    []graphEdge
+	Clusters []graphCluster // Populated only when clustering by directory
+}
+
+type graphNode struct {
+	ID           string
+	Path         string
+	Directory    string
+	CommitCount  int
+	HotspotScore *float64
+	Size         float64 // Raw size value selected by GraphOptions.NodeSize
+	SizeNorm     float64 // Size normalized to [0, 1] across all nodes
+}
+
+type graphEdge struct {
+	Source     string
+	Target     string
+	Weight     float64 // Raw weight value selected by GraphOptions.EdgeWeight
+	WeightNorm float64 // Weight normalized to [0, 1] across all edges
+	CoCommits  int
+	Jaccard    float64
+	Confidence float64
+	Lift       float64
+}
+
+type graphCluster struct {
+	Directory string
+	NodeIDs   []string
+}
+
+// buildCouplingGraph converts the top coupling pairs of a report into a graph.
+// Nodes are ordered by path and assigned stable IDs (n0, n1, ...).
+func buildCouplingGraph(report *CouplingAnalysisReport, options OutputOptions) couplingGraph {
+	couplings := limitTop(report.Result.Couplings, options.Top)
+	graphOpts := options.Graph
+
+	commitCounts := make(map[string]int)
+	for _, c := range couplings {
+		commitCounts[c.FileA] = c.FileACommitCount
+		commitCounts[c.FileB] = c.FileBCommitCount
+	}
+
+	paths := make([]string, 0, len(commitCounts))
+	for p := range commitCounts {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	g := couplingGraph{Nodes: make([]graphNode, 0, len(paths))}
+	ids := make(map[string]string, len(paths))
+	var maxSize float64
+	for i, p := range paths {
+		node := graphNode{
+			ID:          fmt.Sprintf("n%d", i),
+			Path:        p,
+			Directory:   path.Dir(p),
+			CommitCount: commitCounts[p],
+		}
+		if score, ok := report.FileScores[p]; ok {
+			s := score
+			node.HotspotScore = &s
+		}
+		switch graphOpts.NodeSize {
+		case NodeSizeHotspot:
+			if node.HotspotScore != nil {
+				node.Size = *node.HotspotScore
+			}
+		default:
+			node.Size = float64(node.CommitCount)
+		}
+		if node.Size > maxSize {
+			maxSize = node.Size
+		}
+		ids[p] = node.ID
+		g.Nodes = append(g.Nodes, node)
+	}
+	if maxSize > 0 {
+		for i := range g.Nodes {
+			g.Nodes[i].SizeNorm = g.Nodes[i].Size / maxSize
+		}
+	}
+
+	var maxWeight float64
+	g.Edges = make([]graphEdge, 0, len(couplings))
+	for _, c := range couplings {
+		edge := graphEdge{
+			Source:     ids[c.FileA],
+			Target:     ids[c.FileB],
+			CoCommits:  c.CoCommitCount,
+			Jaccard:    c.JaccardCoefficient,
+			Confidence: c.Confidence,
+			Lift:       c.Lift,
+		}
+		switch graphOpts.EdgeWeight {
+		case EdgeWeightLift:
+			edge.Weight = c.Lift
+		default:
+			edge.Weight = c.JaccardCoefficient
+		}
+		if edge.Weight > maxWeight {
+			maxWeight = edge.Weight
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+	if maxWeight > 0 {
+		for i := range g.Edges {
+			g.Edges[i].WeightNorm = g.Edges[i].Weight / maxWeight
+		}
+	}
+
+	if graphOpts.ClusterByDir {
+		byDir := make(map[string][]string)
+		for _, n := range g.Nodes {
+			byDir[n.Directory] = append(byDir[n.Directory], n.ID)
+		}
+		dirs := make([]string, 0, len(byDir))
+		for d := range byDir {
+			dirs = append(dirs, d)
+		}
+		sort.Strings(dirs)
+		for _, d := range dirs {
+			g.Clusters = append(g.Clusters, graphCluster{Directory: d, NodeIDs: byDir[d]})
+		}
+	}
+
+	return g
+}
+
+func edgeWeightName(w GraphEdgeWeight) string {
+	if w == EdgeWeightLift {
+		return string(EdgeWeightLift)
+	}
+	return string(EdgeWeightJaccard)
+}
+
+func nodeSizeName(s GraphNodeSize) string {
+	if s == NodeSizeHotspot {
+		return string(NodeSizeHotspot)
+	}
+	return string(NodeSizeCommits)
+}
+
+// DOTCouplingWriter writes coupling analysis reports as a Graphviz DOT graph.
+type DOTCouplingWriter struct{}
+
+// Write outputs the coupling graph in DOT format.
+func (w *DOTCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	g := buildCouplingGraph(report, options)
+
+	dst, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	// bufio.Writer keeps the first write error for Flush to report
+	out := bufio.NewWriter(dst)
+
+	fmt.Fprintln(out, "graph coupling {")
+	fmt.Fprintf(out, "  label=%s;\n", dotQuote("Change coupling: "+report.RepoPath))
+	fmt.Fprintln(out, "  node [shape=box, style=filled, fillcolor=\"#f5f5f5\"];")
+
+	writeNode := func(indent string, n graphNode) {
+		width := 0.75 + 1.25*n.SizeNorm
+		fmt.Fprintf(out, "%s%s [label=%s, tooltip=%s, width=%.2f];\n",
+			indent, n.ID, dotQuote(n.Path), dotQuote(fmt.Sprintf("%s=%.4g", nodeSizeName(options.Graph.NodeSize), n.Size)), width)
+	}
+
+	if len(g.Clusters) > 0 {
+		nodesByID := make(map[string]graphNode, len(g.Nodes))
+		for _, n := range g.Nodes {
+			nodesByID[n.ID] = n
+		}
+		for i, cl := range g.Clusters {
+			fmt.Fprintf(out, "  subgraph cluster_%d {\n", i)
+			fmt.Fprintf(out, "    label=%s;\n", dotQuote(cl.Directory))
+			for _, id := range cl.NodeIDs {
+				writeNode("    ", nodesByID[id])
+			}
+			fmt.Fprintln(out, "  }")
+		}
+	} else {
+		for _, n := range g.Nodes {
+			writeNode("  ", n)
+		}
+	}
+
+	for _, e := range g.Edges {
+		penWidth := 1 + 4*e.WeightNorm
+		fmt.Fprintf(out, "  %s -- %s [weight=%.4f, penwidth=%.2f, label=\"%.2f\"];\n",
+			e.Source, e.Target, e.Weight, penWidth, e.Weight)
+	}
+
+	fmt.Fprintln(out, "}")
+	return out.Flush()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// MermaidCouplingWriter writes coupling analysis reports as a Mermaid flowchart.
+type MermaidCouplingWriter struct{}
+
+// Write outputs the coupling graph as a Mermaid flowchart definition.
+func (w *MermaidCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	g := buildCouplingGraph(report, options)
+
+	dst, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	out := bufio.NewWriter(dst)
+
+	fmt.Fprintln(out, "graph LR")
+
+	writeNode := func(indent string, n graphNode) {
+		label := fmt.Sprintf("%s (%.4g)", n.Path, n.Size)
+		fmt.Fprintf(out, "%s%s[%s]\n", indent, n.ID, mermaidQuote(label))
+	}
+
+	if len(g.Clusters) > 0 {
+		nodesByID := make(map[string]graphNode, len(g.Nodes))
+		for _, n := range g.Nodes {
+			nodesByID[n.ID] = n
+		}
+		for i, cl := range g.Clusters {
+			fmt.Fprintf(out, "  subgraph c%d[%s]\n", i, mermaidQuote(cl.Directory))
+			for _, id := range cl.NodeIDs {
+				writeNode("    ", nodesByID[id])
+			}
+			fmt.Fprintln(out, "  end")
+		}
+	} else {
+		for _, n := range g.Nodes {
+			writeNode("  ", n)
+		}
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(out, "  %s ---|%.2f| %s\n", e.Source, e.Weight, e.Target)
+	}
+
+	return out.Flush()
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// GraphMLCouplingWriter writes coupling analysis reports as GraphML.
+type GraphMLCouplingWriter struct{}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Write outputs the coupling graph in GraphML format.
+func (w *GraphMLCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	g := buildCouplingGraph(report, options)
+
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "path", For: "node", AttrName: "path", AttrType: "string"},
+			{ID: "cluster", For: "node", AttrName: "cluster", AttrType: "string"},
+			{ID: "commits", For: "node", AttrName: "commitCount", AttrType: "int"},
+			{ID: "size", For: "node", AttrName: "size", AttrType: "double"},
+			{ID: "weight", For: "edge", AttrName: "weight", AttrType: "double"},
+			{ID: "coCommits", For: "edge", AttrName: "coCommitCount", AttrType: "int"},
+			{ID: "jaccard", For: "edge", AttrName: "jaccard", AttrType: "double"},
+			{ID: "confidence", For: "edge", AttrName: "confidence", AttrType: "double"},
+			{ID: "lift", For: "edge", AttrName: "lift", AttrType: "double"},
+		},
+		Graph: graphMLGraph{ID: "coupling", EdgeDefault: "undirected"},
+	}
+
+	for _, n := range g.Nodes {
+		data := []graphMLData{
+			{Key: "path", Value: n.Path},
+			{Key: "commits", Value: fmt.Sprintf("%d", n.CommitCount)},
+			{Key: "size", Value: fmt.Sprintf("%.6f", n.Size)},
+		}
+		if options.Graph.ClusterByDir {
+			data = append(data, graphMLData{Key: "cluster", Value: n.Directory})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: n.ID, Data: data})
+	}
+
+	for i, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     fmt.Sprintf("e%d", i),
+			Source: e.Source,
+			Target: e.Target,
+			Data: []graphMLData{
+				{Key: "weight", Value: fmt.Sprintf("%.6f", e.Weight)},
+				{Key: "coCommits", Value: fmt.Sprintf("%d", e.CoCommits)},
+				{Key: "jaccard", Value: fmt.Sprintf("%.6f", e.Jaccard)},
+				{Key: "confidence", Value: fmt.Sprintf("%.6f", e.Confidence)},
+				{Key: "lift", Value: fmt.Sprintf("%.6f", e.Lift)},
+			},
+		})
+	}
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	return writeXML(out, doc)
+}
+
+func writeXML(out io.Writer, v interface{}) error {
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode XML: %w", err)
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+// GraphJSONCouplingWriter writes coupling analysis reports as a nodes/edges JSON graph.
+type GraphJSONCouplingWriter struct{}
+
+// JSONGraphReport is the JSON output structure for the coupling graph.
+type JSONGraphReport struct {
+	RepoPath    string          `json:"repo"`
+	Since       *string         `json:"since,omitempty"`
+	Until       string          `json:"until"`
+	GeneratedAt string          `json:"generatedAt"`
+	EdgeWeight  string          `json:"edgeWeight"`
+	NodeSize    string          `json:"nodeSize"`
+	Nodes       []JSONGraphNode `json:"nodes"`
+	Edges       []JSONGraphEdge `json:"edges"`
+}
+
+// JSONGraphNode is a single file node in the JSON graph.
+type JSONGraphNode struct {
+	ID           string   `json:"id"`
+	Path         string   `json:"path"`
+	Group        string   `json:"group,omitempty"`
+	Size         float64  `json:"size"`
+	CommitCount  int      `json:"commitCount"`
+	HotspotScore *float64 `json:"hotspotScore,omitempty"`
+}
+
+// JSONGraphEdge is a single coupling edge in the JSON graph.
+type JSONGraphEdge struct {
+	Source        string  `json:"source"`
+	Target        string  `json:"target"`
+	Weight        float64 `json:"weight"`
+	CoCommitCount int     `json:"coCommitCount"`
+	Jaccard       float64 `json:"jaccard"`
+	Confidence    float64 `json:"confidence"`
+	Lift          float64 `json:"lift"`
+}
+
+// Write outputs the coupling graph as JSON.
+func (w *GraphJSONCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	g := buildCouplingGraph(report, options)
+
+	nodes, edges := toJSONGraph(g, options.Graph)
+
+	jsonReport := JSONGraphReport{
+		RepoPath:    report.RepoPath,
+		Since:       formatSinceDate(report.Since),
+		Until:       report.Until.Format(reportDateLayout),
+		GeneratedAt: report.GeneratedAt.Format(time.RFC3339),
+		EdgeWeight:  edgeWeightName(options.Graph.EdgeWeight),
+		NodeSize:    nodeSizeName(options.Graph.NodeSize),
+		Nodes:       nodes,
+		Edges:       edges,
+	}
+
+	return writeJSON(jsonReport, options.OutputPath)
+}
+
+// toJSONGraph converts a coupling graph into its JSON node and edge lists.
+func toJSONGraph(g couplingGraph, graphOpts GraphOptions) ([]JSONGraphNode, []JSONGraphEdge) {
+	nodes := make([]JSONGraphNode, len(g.Nodes))
+	for i, n := range g.Nodes {
+		nodes[i] = JSONGraphNode{
+			ID:           n.ID,
+			Path:         n.Path,
+			Size:         n.Size,
+			CommitCount:  n.CommitCount,
+			HotspotScore: n.HotspotScore,
+		}
+		if graphOpts.ClusterByDir {
+			nodes[i].Group = n.Directory
+		}
+	}
+
+	edges := make([]JSONGraphEdge, len(g.Edges))
+	for i, e := range g.Edges {
+		edges[i] = JSONGraphEdge{
+			Source:        e.Source,
+			Target:        e.Target,
+			Weight:        e.Weight,
+			CoCommitCount: e.CoCommits,
+			Jaccard:       e.Jaccard,
+			Confidence:    e.Confidence,
+			Lift:          e.Lift,
+		}
+	}
+
+	return nodes, edges
+}
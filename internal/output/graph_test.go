@@ -0,0 +1,219 @@
+package output
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/internal/coupling"
+)
+
+func newTestCouplingReport() *CouplingAnalysisReport {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	return &CouplingAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Result: coupling.CouplingAnalysisResult{
+			Couplings: []coupling.ChangeCoupling{
+				{FileA: "src/a.go", FileB: "src/b.go", CoCommitCount: 4, FileACommitCount: 5, FileBCommitCount: 4,
+					JaccardCoefficient: 0.8, Confidence: 0.8, Lift: 2.0},
+				{FileA: "src/a.go", FileB: "docs/a.md", CoCommitCount: 2, FileACommitCount: 5, FileBCommitCount: 2,
+					JaccardCoefficient: 0.4, Confidence: 0.4, Lift: 4.0},
+			},
+			TotalCommits: 10,
+			TotalFiles:   3,
+			TotalPairs:   2,
+		},
+		FileScores: map[string]float64{"src/a.go": 0.9, "src/b.go": 0.45},
+	}
+}
+
+func TestBuildCouplingGraph(t *testing.T) {
+	report := newTestCouplingReport()
+
+	t.Run("JaccardAndCommits", func(t *testing.T) {
+		g := buildCouplingGraph(report, OutputOptions{})
+		if len(g.Nodes) != 3 {
+			t.Fatalf("len(Nodes) = %d, want 3", len(g.Nodes))
+		}
+		if len(g.Edges) != 2 {
+			t.Fatalf("len(Edges) = %d, want 2", len(g.Edges))
+		}
+		// Nodes are sorted by path
+		if g.Nodes[0].Path != "docs/a.md" || g.Nodes[1].Path != "src/a.go" {
+			t.Errorf("unexpected node order: %q, %q", g.Nodes[0].Path, g.Nodes[1].Path)
+		}
+		if g.Nodes[1].Size != 5 || g.Nodes[1].SizeNorm != 1.0 {
+			t.Errorf("src/a.go size = %f (norm %f), want 5 (norm 1)", g.Nodes[1].Size, g.Nodes[1].SizeNorm)
+		}
+		if g.Edges[0].Weight != 0.8 || g.Edges[0].WeightNorm != 1.0 {
+			t.Errorf("edge[0] weight = %f (norm %f), want 0.8 (norm 1)", g.Edges[0].Weight, g.Edges[0].WeightNorm)
+		}
+		if len(g.Clusters) != 0 {
+			t.Errorf("expected no clusters, got %d", len(g.Clusters))
+		}
+	})
+
+	t.Run("LiftAndHotspot", func(t *testing.T) {
+		g := buildCouplingGraph(report, OutputOptions{Graph: GraphOptions{
+			EdgeWeight: EdgeWeightLift,
+			NodeSize:   NodeSizeHotspot,
+		}})
+		if g.Edges[1].Weight != 4.0 {
+			t.Errorf("edge[1] weight = %f, want 4.0 (lift)", g.Edges[1].Weight)
+		}
+		if g.Nodes[1].Size != 0.9 {
+			t.Errorf("src/a.go size = %f, want 0.9 (hotspot)", g.Nodes[1].Size)
+		}
+		if g.Nodes[0].HotspotScore != nil || g.Nodes[0].Size != 0 {
+			t.Errorf("docs/a.md should have no hotspot score")
+		}
+	})
+
+	t.Run("ClusterByDir", func(t *testing.T) {
+		g := buildCouplingGraph(report, OutputOptions{Graph: GraphOptions{ClusterByDir: true}})
+		if len(g.Clusters) != 2 {
+			t.Fatalf("len(Clusters) = %d, want 2", len(g.Clusters))
+		}
+		if g.Clusters[1].Directory != "src" || len(g.Clusters[1].NodeIDs) != 2 {
+			t.Errorf("cluster[1] = %+v, want src with 2 nodes", g.Clusters[1])
+		}
+	})
+
+	t.Run("TopLimitsEdges", func(t *testing.T) {
+		g := buildCouplingGraph(report, OutputOptions{Top: 1})
+		if len(g.Edges) != 1 || len(g.Nodes) != 2 {
+			t.Errorf("Top=1: got %d edges, %d nodes; want 1, 2", len(g.Edges), len(g.Nodes))
+		}
+	})
+}
+
+func TestDOTCouplingWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/coupling.dot"
+	options := OutputOptions{Format: FormatDOT, OutputPath: tmpFile, Graph: GraphOptions{ClusterByDir: true}}
+
+	if err := (&DOTCouplingWriter{}).Write(newTestCouplingReport(), options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{"graph coupling {", "subgraph cluster_0", `label="src"`, `n1 -- n2`, `label="src/a.go"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMermaidCouplingWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/coupling.mmd"
+	options := OutputOptions{Format: FormatMermaid, OutputPath: tmpFile}
+
+	if err := (&MermaidCouplingWriter{}).Write(newTestCouplingReport(), options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	out := string(data)
+
+	if !strings.HasPrefix(out, "graph LR\n") {
+		t.Errorf("Mermaid output should start with graph LR:\n%s", out)
+	}
+	if !strings.Contains(out, `n1["src/a.go (5)"]`) {
+		t.Errorf("Mermaid output missing labelled node:\n%s", out)
+	}
+	if !strings.Contains(out, "n1 ---|0.80| n2") {
+		t.Errorf("Mermaid output missing weighted edge:\n%s", out)
+	}
+	if strings.Contains(out, "subgraph") {
+		t.Errorf("Mermaid output should not contain subgraphs without clustering:\n%s", out)
+	}
+}
+
+func TestGraphMLCouplingWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/coupling.graphml"
+	options := OutputOptions{Format: FormatGraphML, OutputPath: tmpFile, Graph: GraphOptions{EdgeWeight: EdgeWeightLift}}
+
+	if err := (&GraphMLCouplingWriter{}).Write(newTestCouplingReport(), options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	var doc graphMLDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 2 {
+		t.Fatalf("got %d nodes, %d edges; want 3, 2", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+	if got := doc.Graph.Edges[1].Data[0]; got.Key != "weight" || got.Value != "4.000000" {
+		t.Errorf("edge[1] weight data = %+v, want lift 4.000000", got)
+	}
+}
+
+func TestGraphJSONCouplingWriter_Write(t *testing.T) {
+	tmpFile := t.TempDir() + "/coupling.json"
+	options := OutputOptions{
+		Format:     FormatGraph,
+		OutputPath: tmpFile,
+		Graph:      GraphOptions{NodeSize: NodeSizeHotspot, ClusterByDir: true},
+	}
+
+	if err := (&GraphJSONCouplingWriter{}).Write(newTestCouplingReport(), options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+
+	var report JSONGraphReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if report.EdgeWeight != "jaccard" || report.NodeSize != "hotspot" {
+		t.Errorf("edgeWeight/nodeSize = %q/%q, want jaccard/hotspot", report.EdgeWeight, report.NodeSize)
+	}
+	if len(report.Nodes) != 3 || len(report.Edges) != 2 {
+		t.Fatalf("got %d nodes, %d edges; want 3, 2", len(report.Nodes), len(report.Edges))
+	}
+	if report.Nodes[1].Group != "src" {
+		t.Errorf("nodes[1].Group = %q, want %q", report.Nodes[1].Group, "src")
+	}
+	if report.Nodes[1].HotspotScore == nil || *report.Nodes[1].HotspotScore != 0.9 {
+		t.Errorf("nodes[1].HotspotScore = %v, want 0.9", report.Nodes[1].HotspotScore)
+	}
+	if report.Edges[0].Source != "n1" || report.Edges[0].Target != "n2" {
+		t.Errorf("edges[0] = %s -> %s, want n1 -> n2", report.Edges[0].Source, report.Edges[0].Target)
+	}
+}
+
+func TestGraphCouplingWriters_WriteError(t *testing.T) {
+	// Writes to /dev/full fail with ENOSPC
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full is not available")
+	}
+	writers := map[string]CouplingReportWriter{
+		"DOT":     &DOTCouplingWriter{},
+		"Mermaid": &MermaidCouplingWriter{},
+	}
+	for name, writer := range writers {
+		t.Run(name, func(t *testing.T) {
+			if err := writer.Write(newTestCouplingReport(), OutputOptions{OutputPath: "/dev/full"}); err == nil {
+				t.Error("Write to a full device returned nil, want the write error")
+			}
+		})
+	}
+}
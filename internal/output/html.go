@@ -0,0 +1,168 @@
+package output
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// The HTML report is a single file: the stylesheet, script and report data are
+// inlined into the page so it opens offline without any network requests.
+var (
+	//go:embed assets/report.html.tmpl
+	htmlReportTemplate string
+	//go:embed assets/report.css
+	htmlReportCSS string
+	//go:embed assets/report.js
+	htmlReportJS string
+
+	htmlReport = template.Must(template.New("report").Parse(htmlReportTemplate))
+)
+
+// htmlPage is the template input for the HTML report.
+type htmlPage struct {
+	Title string
+	CSS   template.CSS
+	JS    template.JS
+	Data  template.JS
+}
+
+// htmlReportData is serialized into the page and rendered client-side.
+type htmlReportData struct {
+	Repo        string            `json:"repo"`
+	PeriodLabel string            `json:"periodLabel"`
+	Period      string            `json:"period"`
+	GeneratedAt string            `json:"generatedAt"`
+	Thresholds  htmlThresholds    `json:"thresholds"`
+	Total       int               `json:"total"`
+	Files       []JSONFileItem    `json:"files"`
+	Commits     []JSONCommitItem  `json:"commits"`
+	Coupling    *htmlCouplingData `json:"coupling"`
+}
+
+type htmlThresholds struct {
+	High   float64 `json:"high"`
+	Medium float64 `json:"medium"`
+}
+
+type htmlCouplingData struct {
+	TotalCommits int                `json:"totalCommits"`
+	TotalFiles   int                `json:"totalFiles"`
+	TotalPairs   int                `json:"totalPairs"`
+	Pairs        []JSONCouplingItem `json:"pairs"`
+	Graph        htmlGraph          `json:"graph"`
+}
+
+type htmlGraph struct {
+	NodeSize string          `json:"nodeSize"`
+	Nodes    []JSONGraphNode `json:"nodes"`
+	Edges    []JSONGraphEdge `json:"edges"`
+}
+
+func newHTMLReportData(repoPath string, since *time.Time, until, generatedAt time.Time, thresholds config.RiskThresholds) htmlReportData {
+	label, value := dateRangeLabelAndValue(since, until)
+	return htmlReportData{
+		Repo:        repoPath,
+		PeriodLabel: label,
+		Period:      value,
+		GeneratedAt: generatedAt.Format(time.RFC3339),
+		Thresholds:  htmlThresholds{High: thresholds.High, Medium: thresholds.Medium},
+	}
+}
+
+func writeHTML(title string, data htmlReportData, outputPath string) error {
+	// json.Marshal escapes <, > and &, so the payload cannot close the script element.
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode report data: %w", err)
+	}
+
+	out, file, err := openOutputWriter(outputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	page := htmlPage{
+		Title: title,
+		CSS:   template.CSS(htmlReportCSS),
+		JS:    template.JS(htmlReportJS),
+		Data:  template.JS(payload),
+	}
+	if err := htmlReport.Execute(out, page); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+// HTMLFileWriter writes file analysis reports as a self-contained HTML page with a
+// sortable hotspot table, a repository treemap and per-file score breakdowns.
+type HTMLFileWriter struct{}
+
+// Write outputs the file analysis report as HTML.
+func (w *HTMLFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	data := newHTMLReportData(report.RepoPath, report.Since, report.Until, report.GeneratedAt, report.riskThresholds())
+	data.Total = len(report.Items)
+	data.Files = make([]JSONFileItem, len(items))
+	for i, item := range items {
+		data.Files[i] = toJSONFileItem(item, report.riskLevel(item), true)
+	}
+
+	return writeHTML("File Hotspot Report", data, options.OutputPath)
+}
+
+// HTMLCommitWriter writes commit analysis reports as a self-contained HTML page.
+type HTMLCommitWriter struct{}
+
+// Write outputs the commit analysis report as HTML.
+func (w *HTMLCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	data := newHTMLReportData(report.RepoPath, report.Since, report.Until, report.GeneratedAt, report.riskThresholds())
+	data.Total = len(report.Items)
+	data.Commits = make([]JSONCommitItem, len(items))
+	for i, item := range items {
+		data.Commits[i] = toJSONCommitItem(item, true)
+	}
+
+	return writeHTML("Commit Risk Report", data, options.OutputPath)
+}
+
+// HTMLCouplingWriter writes coupling analysis reports as a self-contained HTML page
+// with the coupled pairs table and an interactive coupling graph.
+type HTMLCouplingWriter struct{}
+
+// Write outputs the coupling analysis report as HTML.
+func (w *HTMLCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	couplings := limitTop(report.Result.Couplings, options.Top)
+
+	pairs := make([]JSONCouplingItem, len(couplings))
+	for i, c := range couplings {
+		pairs[i] = toJSONCouplingItem(c)
+	}
+	nodes, edges := toJSONGraph(buildCouplingGraph(report, options), options.Graph)
+
+	data := newHTMLReportData(report.RepoPath, report.Since, report.Until, report.GeneratedAt, report.riskThresholds())
+	data.Total = report.Result.TotalPairs
+	data.Coupling = &htmlCouplingData{
+		TotalCommits: report.Result.TotalCommits,
+		TotalFiles:   report.Result.TotalFiles,
+		TotalPairs:   report.Result.TotalPairs,
+		Pairs:        pairs,
+		Graph: htmlGraph{
+			NodeSize: nodeSizeName(options.Graph.NodeSize),
+			Nodes:    nodes,
+			Edges:    edges,
+		},
+	}
+
+	return writeHTML("Change Coupling Report", data, options.OutputPath)
+}
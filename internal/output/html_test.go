@@ -0,0 +1,154 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// readHTMLReportData extracts and decodes the embedded report data from an HTML report.
+func readHTMLReportData(t *testing.T, path string) (string, htmlReportData) {
+	t.Helper()
+	raw, err := readTestFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	page := string(raw)
+
+	const prefix = "const BUGSPOTS_DATA = "
+	start := strings.Index(page, prefix)
+	if start < 0 {
+		t.Fatal("report data not found in page")
+	}
+	rest := page[start+len(prefix):]
+	end := strings.Index(rest, ";</script>")
+	if end < 0 {
+		t.Fatal("report data is not terminated")
+	}
+
+	var data htmlReportData
+	if err := json.Unmarshal([]byte(rest[:end]), &data); err != nil {
+		t.Fatalf("report data is not valid JSON: %v", err)
+	}
+	return page, data
+}
+
+func TestHTMLFileWriter_Write(t *testing.T) {
+	report := newTestFileReport()
+	report.Items[0].Breakdown = &scoring.ScoreBreakdown{CommitComponent: 0.3}
+	report.Items = append(report.Items, scoring.FileRiskItem{
+		Path: "web/</script><b>x.go", RiskScore: 0.1, Metrics: &aggregation.FileMetrics{},
+	})
+
+	tmpFile := t.TempDir() + "/report.html"
+	if err := (&HTMLFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	page, data := readHTMLReportData(t, tmpFile)
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") {
+		t.Error("expected an HTML5 document")
+	}
+	for _, external := range []string{"<script src", "<link ", "@import"} {
+		if strings.Contains(page, external) {
+			t.Errorf("page references an external resource (%q)", external)
+		}
+	}
+	if strings.Contains(page, "</script><b>") {
+		t.Error("file path was not escaped inside the script element")
+	}
+
+	if data.Total != 4 || len(data.Files) != 4 {
+		t.Fatalf("total/files = %d/%d, expected 4/4", data.Total, len(data.Files))
+	}
+	if data.Files[0].RiskLevel != string(config.RiskLevelHigh) || data.Files[1].RiskLevel != string(config.RiskLevelMedium) {
+		t.Errorf("risk levels = %s, %s", data.Files[0].RiskLevel, data.Files[1].RiskLevel)
+	}
+	if data.Files[0].Breakdown == nil || data.Files[0].Breakdown.Commit != 0.3 {
+		t.Error("expected breakdown to be included without Explain")
+	}
+	if data.Files[3].Path != "web/</script><b>x.go" {
+		t.Errorf("path did not round-trip: %q", data.Files[3].Path)
+	}
+	if data.Commits != nil || data.Coupling != nil {
+		t.Error("file report should not carry commit or coupling sections")
+	}
+}
+
+func TestHTMLFileWriter_EmptyReport(t *testing.T) {
+	tmpFile := t.TempDir() + "/report.html"
+	report := &FileAnalysisReport{Until: time.Now(), GeneratedAt: time.Now()}
+	if err := (&HTMLFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if _, data := readHTMLReportData(t, tmpFile); data.Files == nil {
+		t.Error("expected an empty file list, not null, so the section still renders")
+	}
+}
+
+func TestHTMLCommitWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.CommitRiskItem{
+			{Metrics: aggregation.CommitMetrics{SHA: "abc123", When: now}, RiskScore: 0.8, RiskLevel: config.RiskLevelHigh},
+			{Metrics: aggregation.CommitMetrics{SHA: "def456", When: now}, RiskScore: 0.2, RiskLevel: config.RiskLevelLow},
+		},
+		Thresholds: config.RiskThresholds{High: 0.75, Medium: 0.35},
+	}
+
+	tmpFile := t.TempDir() + "/report.html"
+	if err := (&HTMLCommitWriter{}).Write(report, OutputOptions{OutputPath: tmpFile, Top: 1}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	_, data := readHTMLReportData(t, tmpFile)
+	if data.Total != 2 || len(data.Commits) != 1 {
+		t.Errorf("total/commits = %d/%d, expected 2/1", data.Total, len(data.Commits))
+	}
+	if data.Commits[0].SHA != "abc123" || data.Commits[0].RiskLevel != "high" {
+		t.Errorf("commit = %+v", data.Commits[0])
+	}
+	if data.Thresholds.High != 0.75 || data.Thresholds.Medium != 0.35 {
+		t.Errorf("thresholds = %+v, expected the report's 0.75/0.35", data.Thresholds)
+	}
+}
+
+func TestHTMLCouplingWriter_Write(t *testing.T) {
+	report := newTestCouplingReport()
+	report.Thresholds = config.RiskThresholds{High: 0.8, Medium: 0.4}
+	tmpFile := t.TempDir() + "/report.html"
+	if err := (&HTMLCouplingWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	_, data := readHTMLReportData(t, tmpFile)
+	if data.Coupling == nil {
+		t.Fatal("expected a coupling section")
+	}
+	if len(data.Coupling.Pairs) != 2 || data.Coupling.TotalPairs != 2 {
+		t.Errorf("pairs = %d (total %d), expected 2", len(data.Coupling.Pairs), data.Coupling.TotalPairs)
+	}
+	if len(data.Coupling.Graph.Nodes) != 3 || len(data.Coupling.Graph.Edges) != 2 {
+		t.Errorf("graph = %d nodes, %d edges; expected 3 and 2", len(data.Coupling.Graph.Nodes), len(data.Coupling.Graph.Edges))
+	}
+	hotspots := 0
+	for _, n := range data.Coupling.Graph.Nodes {
+		if n.HotspotScore != nil {
+			hotspots++
+		}
+	}
+	if hotspots != 2 {
+		t.Errorf("expected hotspot scores on 2 nodes, got %d", hotspots)
+	}
+	if data.Thresholds.High != 0.8 || data.Thresholds.Medium != 0.4 {
+		t.Errorf("thresholds = %+v, expected the report's 0.8/0.4", data.Thresholds)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/coupling"
+	"github.com/masmgr/bugspots-go/internal/scoring"
 )
 
 // JSONFileWriter writes file analysis reports as JSON.
@@ -23,22 +27,25 @@ type JSONFileReport struct {
 type JSONFileItem struct {
 	Path      string             `json:"path"`
 	RiskScore float64            `json:"riskScore"`
+	RiskLevel string             `json:"riskLevel"`
 	Metrics   JSONFileMetrics    `json:"metrics"`
 	Breakdown *JSONFileBreakdown `json:"breakdown,omitempty"`
 }
 
 // JSONFileMetrics holds the metrics for a file in JSON format.
 type JSONFileMetrics struct {
-	CommitCount    int     `json:"commitCount"`
-	ChurnAdded     int     `json:"churnAdded"`
-	ChurnDeleted   int     `json:"churnDeleted"`
-	ChurnTotal     int     `json:"churnTotal"`
-	LastModified   string  `json:"lastModified"`
-	Contributors   int     `json:"contributors"`
-	BurstScore     float64 `json:"burstScore"`
-	OwnershipRatio float64 `json:"ownershipRatio"`
-	BugfixCount    int     `json:"bugfixCount"`
-	FileSize       int     `json:"fileSize"`
+	CommitCount      int     `json:"commitCount"`
+	ChurnAdded       int     `json:"churnAdded"`
+	ChurnDeleted     int     `json:"churnDeleted"`
+	ChurnTotal       int     `json:"churnTotal"`
+	LastModified     string  `json:"lastModified"`
+	Contributors     int     `json:"contributors"`
+	BurstScore       float64 `json:"burstScore"`
+	OwnershipRatio   float64 `json:"ownershipRatio"`
+	BugfixCount      int     `json:"bugfixCount"`
+	FileSize         int     `json:"fileSize"`
+	CouplingPartners int     `json:"couplingPartners"`
+	CouplingDegree   float64 `json:"couplingDegree"`
 }
 
 // JSONFileBreakdown holds the score breakdown for a file in JSON format.
@@ -50,6 +57,12 @@ type JSONFileBreakdown struct {
 	Ownership  float64 `json:"ownership"`
 	Bugfix     float64 `json:"bugfix"`
 	Complexity float64 `json:"complexity"`
+	Coupling   float64 `json:"coupling"`
+
+	// Path overrides that matched the file, from the configuration
+	Overrides   []string `json:"overrides,omitempty"`
+	ScoreFactor float64  `json:"scoreFactor,omitempty"`
+	FixedLevel  string   `json:"fixedLevel,omitempty"`
 }
 
 // Write outputs the file analysis report as JSON.
@@ -58,34 +71,7 @@ func (w *JSONFileWriter) Write(report *FileAnalysisReport, options OutputOptions
 
 	jsonItems := make([]JSONFileItem, len(items))
 	for i, item := range items {
-		jsonItem := JSONFileItem{
-			Path:      item.Path,
-			RiskScore: item.RiskScore,
-			Metrics: JSONFileMetrics{
-				CommitCount:    item.Metrics.CommitCount,
-				ChurnAdded:     item.Metrics.AddedLines,
-				ChurnDeleted:   item.Metrics.DeletedLines,
-				ChurnTotal:     item.Metrics.ChurnTotal(),
-				LastModified:   item.Metrics.LastModifiedAt.Format(time.RFC3339),
-				Contributors:   item.Metrics.ContributorCount(),
-				BurstScore:     item.Metrics.BurstScore,
-				OwnershipRatio: item.Metrics.OwnershipRatio(),
-				BugfixCount:    item.Metrics.BugfixCount,
-				FileSize:       item.Metrics.FileSize,
-			},
-		}
-		if options.Explain && item.Breakdown != nil {
-			jsonItem.Breakdown = &JSONFileBreakdown{
-				Commit:     item.Breakdown.CommitComponent,
-				Churn:      item.Breakdown.ChurnComponent,
-				Recency:    item.Breakdown.RecencyComponent,
-				Burst:      item.Breakdown.BurstComponent,
-				Ownership:  item.Breakdown.OwnershipComponent,
-				Bugfix:     item.Breakdown.BugfixComponent,
-				Complexity: item.Breakdown.ComplexityComponent,
-			}
-		}
-		jsonItems[i] = jsonItem
+		jsonItems[i] = toJSONFileItem(item, report.riskLevel(item), options.Explain)
 	}
 
 	jsonReport := JSONFileReport{
@@ -111,6 +97,17 @@ type JSONCommitReport struct {
 	GeneratedAt  string           `json:"generatedAt"`
 	TotalCommits int              `json:"totalCommits"`
 	Items        []JSONCommitItem `json:"items"`
+	Scopes       []JSONScopeGroup `json:"scopes,omitempty"`
+}
+
+// JSONScopeGroup is the JSON output structure for the commits of one scope.
+type JSONScopeGroup struct {
+	Scope     string  `json:"scope"`
+	Commits   int     `json:"commits"`
+	HighRisk  int     `json:"highRisk"`
+	Breaking  int     `json:"breaking"`
+	MaxScore  float64 `json:"maxScore"`
+	MeanScore float64 `json:"meanScore"`
 }
 
 // JSONCommitItem is the JSON output structure for a single commit.
@@ -119,6 +116,9 @@ type JSONCommitItem struct {
 	When      string               `json:"when"`
 	Author    string               `json:"author"`
 	Message   string               `json:"message"`
+	Type      string               `json:"type,omitempty"`
+	Scope     string               `json:"scope,omitempty"`
+	Breaking  bool                 `json:"breaking,omitempty"`
 	RiskScore float64              `json:"riskScore"`
 	RiskLevel string               `json:"riskLevel"`
 	Metrics   JSONCommitMetrics    `json:"metrics"`
@@ -141,6 +141,7 @@ type JSONCommitBreakdown struct {
 	Diffusion float64 `json:"diffusion"`
 	Size      float64 `json:"size"`
 	Entropy   float64 `json:"entropy"`
+	Breaking  float64 `json:"breaking"`
 }
 
 // Write outputs the commit analysis report as JSON.
@@ -149,31 +150,7 @@ func (w *JSONCommitWriter) Write(report *CommitAnalysisReport, options OutputOpt
 
 	jsonItems := make([]JSONCommitItem, len(items))
 	for i, item := range items {
-		jsonItem := JSONCommitItem{
-			SHA:       item.Metrics.SHA,
-			When:      item.Metrics.When.Format(time.RFC3339),
-			Author:    item.Metrics.Author.Name,
-			Message:   item.Metrics.Message,
-			RiskScore: item.RiskScore,
-			RiskLevel: string(item.RiskLevel),
-			Metrics: JSONCommitMetrics{
-				FileCount:      item.Metrics.FileCount,
-				DirectoryCount: item.Metrics.DirectoryCount,
-				SubsystemCount: item.Metrics.SubsystemCount,
-				LinesAdded:     item.Metrics.LinesAdded,
-				LinesDeleted:   item.Metrics.LinesDeleted,
-				TotalChurn:     item.Metrics.TotalChurn(),
-				ChangeEntropy:  item.Metrics.ChangeEntropy,
-			},
-		}
-		if options.Explain && item.Breakdown != nil {
-			jsonItem.Breakdown = &JSONCommitBreakdown{
-				Diffusion: item.Breakdown.DiffusionComponent,
-				Size:      item.Breakdown.SizeComponent,
-				Entropy:   item.Breakdown.EntropyComponent,
-			}
-		}
-		jsonItems[i] = jsonItem
+		jsonItems[i] = toJSONCommitItem(item, options.Explain)
 	}
 
 	jsonReport := JSONCommitReport{
@@ -184,23 +161,237 @@ func (w *JSONCommitWriter) Write(report *CommitAnalysisReport, options OutputOpt
 		TotalCommits: len(report.Items),
 		Items:        jsonItems,
 	}
+	for _, g := range report.Scopes {
+		jsonReport.Scopes = append(jsonReport.Scopes, JSONScopeGroup(g))
+	}
 
 	return writeJSON(jsonReport, options.OutputPath)
 }
 
+// JSONFunctionWriter writes function analysis reports as JSON.
+type JSONFunctionWriter struct{}
+
+// JSONFunctionReport is the JSON output structure for function analysis.
+type JSONFunctionReport struct {
+	RepoPath       string             `json:"repo"`
+	Since          *string            `json:"since,omitempty"`
+	Until          string             `json:"until"`
+	GeneratedAt    string             `json:"generatedAt"`
+	TotalFunctions int                `json:"totalFunctions"`
+	Items          []JSONFunctionItem `json:"items"`
+}
+
+// JSONFunctionItem is the JSON output structure for a single function.
+type JSONFunctionItem struct {
+	Path      string                 `json:"path"`
+	Name      string                 `json:"name"`
+	StartLine int                    `json:"startLine"`
+	EndLine   int                    `json:"endLine"`
+	RiskScore float64                `json:"riskScore"`
+	RiskLevel string                 `json:"riskLevel"`
+	Metrics   JSONFunctionMetrics    `json:"metrics"`
+	Breakdown *JSONFunctionBreakdown `json:"breakdown,omitempty"`
+}
+
+// JSONFunctionMetrics holds the metrics for a function in JSON format.
+type JSONFunctionMetrics struct {
+	CommitCount  int    `json:"commitCount"`
+	AddedLines   int    `json:"addedLines"`
+	DeletedLines int    `json:"deletedLines"`
+	BugfixCount  int    `json:"bugfixCount"`
+	LastModified string `json:"lastModified"`
+}
+
+// JSONFunctionBreakdown holds the score breakdown for a function in JSON format.
+type JSONFunctionBreakdown struct {
+	Commit  float64 `json:"commit"`
+	Churn   float64 `json:"churn"`
+	Bugfix  float64 `json:"bugfix"`
+	Recency float64 `json:"recency"`
+}
+
+// Write outputs the function analysis report as JSON.
+func (w *JSONFunctionWriter) Write(report *FunctionAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	jsonItems := make([]JSONFunctionItem, 0, len(items))
+	for _, item := range items {
+		m := item.Metrics
+		jsonItem := JSONFunctionItem{
+			Path:      m.Path,
+			Name:      m.Name,
+			StartLine: m.StartLine,
+			EndLine:   m.EndLine,
+			RiskScore: item.RiskScore,
+			RiskLevel: string(item.RiskLevel),
+			Metrics: JSONFunctionMetrics{
+				CommitCount:  m.CommitCount,
+				AddedLines:   m.AddedLines,
+				DeletedLines: m.DeletedLines,
+				BugfixCount:  m.BugfixCount,
+				LastModified: m.LastModifiedAt.Format(time.RFC3339),
+			},
+		}
+		if options.Explain && item.Breakdown != nil {
+			jsonItem.Breakdown = &JSONFunctionBreakdown{
+				Commit:  item.Breakdown.CommitComponent,
+				Churn:   item.Breakdown.ChurnComponent,
+				Bugfix:  item.Breakdown.BugfixComponent,
+				Recency: item.Breakdown.RecencyComponent,
+			}
+		}
+		jsonItems = append(jsonItems, jsonItem)
+	}
+
+	return writeJSON(JSONFunctionReport{
+		RepoPath:       report.RepoPath,
+		Since:          formatSinceDate(report.Since),
+		Until:          report.Until.Format(reportDateLayout),
+		GeneratedAt:    report.GeneratedAt.Format(time.RFC3339),
+		TotalFunctions: len(report.Items),
+		Items:          jsonItems,
+	}, options.OutputPath)
+}
+
+// JSONRangeWriter writes line range analysis reports as JSON.
+type JSONRangeWriter struct{}
+
+// JSONRangeReport is the JSON output structure for line range analysis.
+type JSONRangeReport struct {
+	RepoPath    string          `json:"repo"`
+	Since       *string         `json:"since,omitempty"`
+	Until       string          `json:"until"`
+	GeneratedAt string          `json:"generatedAt"`
+	TotalRanges int             `json:"totalRanges"`
+	Items       []JSONRangeItem `json:"items"`
+}
+
+// JSONRangeItem is the JSON output structure for a single line range.
+type JSONRangeItem struct {
+	Path      string              `json:"path"`
+	StartLine int                 `json:"startLine"`
+	EndLine   int                 `json:"endLine"`
+	RiskScore float64             `json:"riskScore"`
+	RiskLevel string              `json:"riskLevel"`
+	Metrics   JSONRangeMetrics    `json:"metrics"`
+	Breakdown *JSONRangeBreakdown `json:"breakdown,omitempty"`
+}
+
+// JSONRangeMetrics holds the metrics for a line range in JSON format.
+type JSONRangeMetrics struct {
+	CommitCount  int    `json:"commitCount"`
+	AddedLines   int    `json:"addedLines"`
+	DeletedLines int    `json:"deletedLines"`
+	BugfixCount  int    `json:"bugfixCount"`
+	LastModified string `json:"lastModified"`
+}
+
+// JSONRangeBreakdown holds the score breakdown for a line range in JSON format.
+type JSONRangeBreakdown struct {
+	Commit  float64 `json:"commit"`
+	Churn   float64 `json:"churn"`
+	Bugfix  float64 `json:"bugfix"`
+	Recency float64 `json:"recency"`
+}
+
+// Write outputs the line range analysis report as JSON.
+func (w *JSONRangeWriter) Write(report *RangeAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	jsonItems := make([]JSONRangeItem, 0, len(items))
+	for _, item := range items {
+		m := item.Metrics
+		jsonItem := JSONRangeItem{
+			Path:      m.Path,
+			StartLine: m.StartLine,
+			EndLine:   m.EndLine,
+			RiskScore: item.RiskScore,
+			RiskLevel: string(item.RiskLevel),
+			Metrics: JSONRangeMetrics{
+				CommitCount:  m.CommitCount,
+				AddedLines:   m.AddedLines,
+				DeletedLines: m.DeletedLines,
+				BugfixCount:  m.BugfixCount,
+				LastModified: m.LastModifiedAt.Format(time.RFC3339),
+			},
+		}
+		if options.Explain && item.Breakdown != nil {
+			jsonItem.Breakdown = &JSONRangeBreakdown{
+				Commit:  item.Breakdown.CommitComponent,
+				Churn:   item.Breakdown.ChurnComponent,
+				Bugfix:  item.Breakdown.BugfixComponent,
+				Recency: item.Breakdown.RecencyComponent,
+			}
+		}
+		jsonItems = append(jsonItems, jsonItem)
+	}
+
+	return writeJSON(JSONRangeReport{
+		RepoPath:    report.RepoPath,
+		Since:       formatSinceDate(report.Since),
+		Until:       report.Until.Format(reportDateLayout),
+		GeneratedAt: report.GeneratedAt.Format(time.RFC3339),
+		TotalRanges: len(report.Items),
+		Items:       jsonItems,
+	}, options.OutputPath)
+}
+
 // JSONCouplingWriter writes coupling analysis reports as JSON.
 type JSONCouplingWriter struct{}
 
 // JSONCouplingReport is the JSON output structure for coupling analysis.
 type JSONCouplingReport struct {
-	RepoPath     string             `json:"repo"`
-	Since        *string            `json:"since,omitempty"`
-	Until        string             `json:"until"`
-	GeneratedAt  string             `json:"generatedAt"`
-	TotalCommits int                `json:"totalCommits"`
-	TotalFiles   int                `json:"totalFiles"`
-	TotalPairs   int                `json:"totalPairs"`
-	Items        []JSONCouplingItem `json:"items"`
+	RepoPath      string                   `json:"repo"`
+	Since         *string                  `json:"since,omitempty"`
+	Until         string                   `json:"until"`
+	GeneratedAt   string                   `json:"generatedAt"`
+	TotalCommits  int                      `json:"totalCommits"`
+	TotalFiles    int                      `json:"totalFiles"`
+	TotalPairs    int                      `json:"totalPairs"`
+	Items         []JSONCouplingItem       `json:"items"`
+	Clusters      *JSONClusterReport       `json:"clusters,omitempty"`
+	CrossBoundary *JSONCrossBoundaryReport `json:"crossBoundary,omitempty"`
+}
+
+// JSONCrossBoundaryReport is the JSON output structure for cross-boundary coupling.
+type JSONCrossBoundaryReport struct {
+	TotalPairs int                     `json:"totalPairs"`
+	Pairs      []JSONCrossBoundaryItem `json:"pairs"`
+	Boundaries []string                `json:"boundaries"`
+	Matrix     [][]int                 `json:"matrix"`
+}
+
+// JSONCrossBoundaryItem is the JSON output structure for a coupled pair spanning two modules.
+type JSONCrossBoundaryItem struct {
+	JSONCouplingItem
+	BoundaryA string `json:"boundaryA"`
+	BoundaryB string `json:"boundaryB"`
+}
+
+// JSONClusterReport is the JSON output structure for change clusters.
+type JSONClusterReport struct {
+	Modularity float64          `json:"modularity"`
+	Clusters   []JSONCluster    `json:"clusters"`
+	Bridges    []JSONBridgeFile `json:"bridges"`
+}
+
+// JSONCluster is the JSON output structure for a single change cluster.
+type JSONCluster struct {
+	ID               int      `json:"id"`
+	Files            []string `json:"files"`
+	Directories      []string `json:"directories"`
+	SpansDirectories bool     `json:"spansDirectories"`
+	InternalWeight   float64  `json:"internalWeight"`
+	ExternalWeight   float64  `json:"externalWeight"`
+	Cohesion         float64  `json:"cohesion"`
+}
+
+// JSONBridgeFile is the JSON output structure for a file bridging clusters.
+type JSONBridgeFile struct {
+	Path              string  `json:"path"`
+	ClusterID         int     `json:"clusterId"`
+	ConnectedClusters int     `json:"connectedClusters"`
+	ExternalRatio     float64 `json:"externalRatio"`
 }
 
 // JSONCouplingItem is the JSON output structure for a single coupling pair.
@@ -221,16 +412,7 @@ func (w *JSONCouplingWriter) Write(report *CouplingAnalysisReport, options Outpu
 
 	jsonItems := make([]JSONCouplingItem, len(couplings))
 	for i, c := range couplings {
-		jsonItems[i] = JSONCouplingItem{
-			FileA:              c.FileA,
-			FileB:              c.FileB,
-			CoCommitCount:      c.CoCommitCount,
-			FileACommitCount:   c.FileACommitCount,
-			FileBCommitCount:   c.FileBCommitCount,
-			JaccardCoefficient: c.JaccardCoefficient,
-			Confidence:         c.Confidence,
-			Lift:               c.Lift,
-		}
+		jsonItems[i] = toJSONCouplingItem(c)
 	}
 
 	jsonReport := JSONCouplingReport{
@@ -244,9 +426,139 @@ func (w *JSONCouplingWriter) Write(report *CouplingAnalysisReport, options Outpu
 		Items:        jsonItems,
 	}
 
+	if clusters := report.Result.Clusters; clusters != nil {
+		jsonClusters := &JSONClusterReport{
+			Modularity: clusters.Modularity,
+			Clusters:   make([]JSONCluster, 0, len(clusters.Clusters)),
+			Bridges:    make([]JSONBridgeFile, 0, len(clusters.Bridges)),
+		}
+		for _, cl := range limitTop(clusters.Clusters, options.Top) {
+			jsonClusters.Clusters = append(jsonClusters.Clusters, JSONCluster{
+				ID:               cl.ID,
+				Files:            cl.Files,
+				Directories:      cl.Directories,
+				SpansDirectories: cl.SpansDirectories(),
+				InternalWeight:   cl.InternalWeight,
+				ExternalWeight:   cl.ExternalWeight,
+				Cohesion:         cl.Cohesion,
+			})
+		}
+		for _, b := range limitTop(clusters.Bridges, options.Top) {
+			jsonClusters.Bridges = append(jsonClusters.Bridges, JSONBridgeFile{
+				Path:              b.Path,
+				ClusterID:         b.ClusterID,
+				ConnectedClusters: b.ConnectedClusters,
+				ExternalRatio:     b.ExternalRatio,
+			})
+		}
+		jsonReport.Clusters = jsonClusters
+	}
+
+	if cross := report.Result.CrossBoundary; cross != nil {
+		jsonCross := &JSONCrossBoundaryReport{
+			TotalPairs: cross.TotalPairs,
+			Pairs:      make([]JSONCrossBoundaryItem, 0, len(cross.Pairs)),
+			Boundaries: cross.Matrix.Boundaries,
+			Matrix:     cross.Matrix.Counts,
+		}
+		for _, p := range limitTop(cross.Pairs, options.Top) {
+			jsonCross.Pairs = append(jsonCross.Pairs, JSONCrossBoundaryItem{
+				JSONCouplingItem: toJSONCouplingItem(p.ChangeCoupling),
+				BoundaryA:        p.BoundaryA,
+				BoundaryB:        p.BoundaryB,
+			})
+		}
+		jsonReport.CrossBoundary = jsonCross
+	}
+
 	return writeJSON(jsonReport, options.OutputPath)
 }
 
+func toJSONFileItem(item scoring.FileRiskItem, level config.RiskLevel, explain bool) JSONFileItem {
+	jsonItem := JSONFileItem{
+		Path:      item.Path,
+		RiskScore: item.RiskScore,
+		RiskLevel: string(level),
+		Metrics: JSONFileMetrics{
+			CommitCount:      item.Metrics.CommitCount,
+			ChurnAdded:       item.Metrics.AddedLines,
+			ChurnDeleted:     item.Metrics.DeletedLines,
+			ChurnTotal:       item.Metrics.ChurnTotal(),
+			LastModified:     item.Metrics.LastModifiedAt.Format(time.RFC3339),
+			Contributors:     item.Metrics.ContributorCount(),
+			BurstScore:       item.Metrics.BurstScore,
+			OwnershipRatio:   item.Metrics.OwnershipRatio(),
+			BugfixCount:      item.Metrics.BugfixCount,
+			FileSize:         item.Metrics.FileSize,
+			CouplingPartners: item.Metrics.CouplingPartners,
+			CouplingDegree:   item.Metrics.CouplingDegree,
+		},
+	}
+	if explain && item.Breakdown != nil {
+		jsonItem.Breakdown = &JSONFileBreakdown{
+			Commit:     item.Breakdown.CommitComponent,
+			Churn:      item.Breakdown.ChurnComponent,
+			Recency:    item.Breakdown.RecencyComponent,
+			Burst:      item.Breakdown.BurstComponent,
+			Ownership:  item.Breakdown.OwnershipComponent,
+			Bugfix:     item.Breakdown.BugfixComponent,
+			Complexity: item.Breakdown.ComplexityComponent,
+			Coupling:   item.Breakdown.CouplingComponent,
+		}
+		if len(item.Breakdown.Overrides) > 0 {
+			jsonItem.Breakdown.Overrides = item.Breakdown.Overrides
+			jsonItem.Breakdown.ScoreFactor = item.Breakdown.ScoreFactor
+			jsonItem.Breakdown.FixedLevel = string(item.Breakdown.FixedLevel)
+		}
+	}
+	return jsonItem
+}
+
+func toJSONCommitItem(item scoring.CommitRiskItem, explain bool) JSONCommitItem {
+	jsonItem := JSONCommitItem{
+		SHA:       item.Metrics.SHA,
+		When:      item.Metrics.When.Format(time.RFC3339),
+		Author:    item.Metrics.Author.Name,
+		Message:   item.Metrics.Message,
+		Type:      item.Metrics.Type,
+		Scope:     item.Metrics.Scope,
+		Breaking:  item.Metrics.Breaking,
+		RiskScore: item.RiskScore,
+		RiskLevel: string(item.RiskLevel),
+		Metrics: JSONCommitMetrics{
+			FileCount:      item.Metrics.FileCount,
+			DirectoryCount: item.Metrics.DirectoryCount,
+			SubsystemCount: item.Metrics.SubsystemCount,
+			LinesAdded:     item.Metrics.LinesAdded,
+			LinesDeleted:   item.Metrics.LinesDeleted,
+			TotalChurn:     item.Metrics.TotalChurn(),
+			ChangeEntropy:  item.Metrics.ChangeEntropy,
+		},
+	}
+	if explain && item.Breakdown != nil {
+		jsonItem.Breakdown = &JSONCommitBreakdown{
+			Diffusion: item.Breakdown.DiffusionComponent,
+			Size:      item.Breakdown.SizeComponent,
+			Entropy:   item.Breakdown.EntropyComponent,
+			Breaking:  item.Breakdown.BreakingComponent,
+		}
+	}
+	return jsonItem
+}
+
+func toJSONCouplingItem(c coupling.ChangeCoupling) JSONCouplingItem {
+	return JSONCouplingItem{
+		FileA:              c.FileA,
+		FileB:              c.FileB,
+		CoCommitCount:      c.CoCommitCount,
+		FileACommitCount:   c.FileACommitCount,
+		FileBCommitCount:   c.FileBCommitCount,
+		JaccardCoefficient: c.JaccardCoefficient,
+		Confidence:         c.Confidence,
+		Lift:               c.Lift,
+	}
+}
+
 func writeJSON(data interface{}, outputPath string) error {
 	out, file, err := openOutputWriter(outputPath)
 	if err != nil {
@@ -0,0 +1,206 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+const junitFailureType = "bugspots.RiskThresholdExceeded"
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func newJUnitSuite(name string, cases []junitTestCase, repoPath string, since *time.Time, until, generatedAt time.Time) junitTestSuite {
+	failures := 0
+	for _, tc := range cases {
+		if tc.Failure != nil {
+			failures++
+		}
+	}
+
+	props := []junitProperty{{Name: "repo", Value: repoPath}}
+	if s := formatSinceDate(since); s != nil {
+		props = append(props, junitProperty{Name: "since", Value: *s})
+	}
+	props = append(props, junitProperty{Name: "until", Value: until.Format(reportDateLayout)})
+
+	return junitTestSuite{
+		Name:       name,
+		Tests:      len(cases),
+		Failures:   failures,
+		Time:       "0",
+		Timestamp:  generatedAt.Format(reportDateTimeLayout),
+		Properties: props,
+		TestCases:  cases,
+	}
+}
+
+func writeJUnit(suite junitTestSuite, options OutputOptions) error {
+	doc := junitTestSuites{
+		Name:     "bugspots-go",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	return writeXML(out, doc)
+}
+
+// fileBreakdownText renders the file score breakdown as "name=value" pairs.
+func fileBreakdownText(b *scoring.ScoreBreakdown) string {
+	if b == nil {
+		return ""
+	}
+	text := fmt.Sprintf("commit=%.4f churn=%.4f recency=%.4f burst=%.4f ownership=%.4f bugfix=%.4f complexity=%.4f coupling=%.4f",
+		b.CommitComponent, b.ChurnComponent, b.RecencyComponent, b.BurstComponent,
+		b.OwnershipComponent, b.BugfixComponent, b.ComplexityComponent, b.CouplingComponent)
+	if overrides := overrideText(b); overrides != "" {
+		text += "; " + overrides
+	}
+	return text
+}
+
+// commitBreakdownText renders the commit score breakdown as "name=value" pairs.
+func commitBreakdownText(b *scoring.CommitRiskBreakdown) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprintf("diffusion=%.4f size=%.4f entropy=%.4f breaking=%.4f",
+		b.DiffusionComponent, b.SizeComponent, b.EntropyComponent, b.BreakingComponent)
+}
+
+// failureMessage appends the score breakdown, when available, to a failure reason.
+func failureMessage(reason, breakdown string) string {
+	if breakdown == "" {
+		return reason
+	}
+	return reason + " (" + breakdown + ")"
+}
+
+// JUnitFileWriter writes file analysis reports as JUnit XML. Each file is a test case;
+// files scoring at or above the threshold fail.
+type JUnitFileWriter struct{}
+
+// Write outputs the file analysis report as JUnit XML.
+func (w *JUnitFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+	threshold := options.Threshold
+	if threshold <= 0 {
+		threshold = report.riskThresholds().High
+	}
+
+	cases := make([]junitTestCase, 0, len(items))
+	for i, item := range items {
+		level := report.riskLevel(item)
+		tc := junitTestCase{
+			Name:      item.Path,
+			ClassName: "bugspots.files",
+			Time:      "0",
+			SystemOut: fileHotspotMessage(i+1, item, level),
+		}
+		if item.RiskScore >= threshold {
+			tc.Failure = &junitFailure{
+				Message: failureMessage(
+					fmt.Sprintf("risk score %.4f is at or above threshold %.4f", item.RiskScore, threshold),
+					fileBreakdownText(item.Breakdown)),
+				Type: junitFailureType,
+				Text: fileHotspotMessage(i+1, item, level),
+			}
+		}
+		cases = append(cases, tc)
+	}
+
+	return writeJUnit(newJUnitSuite("bugspots.files", cases,
+		report.RepoPath, report.Since, report.Until, report.GeneratedAt), options)
+}
+
+// JUnitCommitWriter writes commit analysis reports as JUnit XML. Each commit is a test
+// case; high-risk commits fail.
+type JUnitCommitWriter struct{}
+
+// Write outputs the commit analysis report as JUnit XML.
+func (w *JUnitCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	cases := make([]junitTestCase, 0, len(items))
+	for _, item := range items {
+		m := item.Metrics
+		sha := m.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		subject, _, _ := strings.Cut(m.Message, "\n")
+		summary := fmt.Sprintf("%s risk (%.4f): %d files, %d directories, %d subsystems, +%d/-%d lines by %s",
+			item.RiskLevel, item.RiskScore, m.FileCount, m.DirectoryCount, m.SubsystemCount,
+			m.LinesAdded, m.LinesDeleted, m.Author.Name)
+
+		tc := junitTestCase{
+			Name:      sha + " " + subject,
+			ClassName: "bugspots.commits",
+			Time:      "0",
+			SystemOut: summary,
+		}
+
+		if item.RiskLevel == config.RiskLevelHigh {
+			reason := fmt.Sprintf("%s-risk commit with score %.4f", item.RiskLevel, item.RiskScore)
+			tc.Failure = &junitFailure{
+				Message: failureMessage(reason, commitBreakdownText(item.Breakdown)),
+				Type:    junitFailureType,
+				Text:    summary,
+			}
+		}
+		cases = append(cases, tc)
+	}
+
+	return writeJUnit(newJUnitSuite("bugspots.commits", cases,
+		report.RepoPath, report.Since, report.Until, report.GeneratedAt), options)
+}
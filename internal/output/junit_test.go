@@ -0,0 +1,115 @@
+package output
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func readJUnit(t *testing.T, path string) junitTestSuites {
+	t.Helper()
+	data, err := readTestFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if len(doc.Suites) != 1 {
+		t.Fatalf("expected 1 test suite, got %d", len(doc.Suites))
+	}
+	return doc
+}
+
+func TestJUnitFileWriter_Write(t *testing.T) {
+	tests := []struct {
+		name         string
+		threshold    float64
+		wantFailures int
+	}{
+		{"default threshold is the high risk level", 0, 1},
+		{"explicit threshold", 0.4, 2},
+		{"threshold equal to the top score", 0.85, 1},
+		{"threshold above all scores", 0.95, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newTestFileReport()
+			report.Items[0].Breakdown = &scoring.ScoreBreakdown{CommitComponent: 0.25, BugfixComponent: 0.1}
+
+			tmpFile := t.TempDir() + "/junit.xml"
+			if err := (&JUnitFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile, Threshold: tt.threshold}); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+
+			doc := readJUnit(t, tmpFile)
+			suite := doc.Suites[0]
+			if suite.Tests != 3 || len(suite.TestCases) != 3 {
+				t.Errorf("tests = %d, expected 3", suite.Tests)
+			}
+			if suite.Failures != tt.wantFailures || doc.Failures != tt.wantFailures {
+				t.Errorf("failures = %d/%d, expected %d", suite.Failures, doc.Failures, tt.wantFailures)
+			}
+			if suite.TestCases[0].Name != "src/hot.go" {
+				t.Errorf("first test case = %q", suite.TestCases[0].Name)
+			}
+			if f := suite.TestCases[0].Failure; tt.wantFailures > 0 {
+				if f == nil {
+					t.Fatal("expected the top file to fail")
+				}
+				if !strings.Contains(f.Message, "commit=0.2500") || !strings.Contains(f.Message, "bugfix=0.1000") {
+					t.Errorf("failure message %q does not contain the breakdown", f.Message)
+				}
+				if !strings.Contains(f.Message, "risk score 0.8500 is at or above threshold") {
+					t.Errorf("failure message %q does not state the threshold comparison", f.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestJUnitCommitWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.CommitRiskItem{
+			{
+				Metrics:   aggregation.CommitMetrics{SHA: "0123456789abcdef", Message: "rewrite storage\n\ndetails"},
+				RiskScore: 0.8,
+				RiskLevel: config.RiskLevelHigh,
+				Breakdown: &scoring.CommitRiskBreakdown{DiffusionComponent: 0.3, SizeComponent: 0.3, EntropyComponent: 0.2},
+			},
+			{
+				Metrics:   aggregation.CommitMetrics{SHA: "fedcba9876543210", Message: "fix typo"},
+				RiskScore: 0.5,
+				RiskLevel: config.RiskLevelMedium,
+			},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/junit.xml"
+	if err := (&JUnitCommitWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	suite := readJUnit(t, tmpFile).Suites[0]
+	if suite.Failures != 1 {
+		t.Fatalf("failures = %d, expected only the high-risk commit to fail", suite.Failures)
+	}
+	tc := suite.TestCases[0]
+	if tc.Name != "0123456 rewrite storage" {
+		t.Errorf("test case name = %q", tc.Name)
+	}
+	if tc.Failure == nil || !strings.Contains(tc.Failure.Message, "diffusion=0.3000") {
+		t.Errorf("failure = %+v, expected breakdown in message", tc.Failure)
+	}
+}
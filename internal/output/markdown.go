@@ -2,7 +2,10 @@ package output
 
 import (
 	"fmt"
+	"io"
 	"strings"
+
+	"github.com/masmgr/bugspots-go/internal/coupling"
 )
 
 // MarkdownFileWriter writes file analysis reports as Markdown.
@@ -32,27 +35,29 @@ func (w *MarkdownFileWriter) Write(report *FileAnalysisReport, options OutputOpt
 	fmt.Fprintln(out, "## Top Hotspots")
 	fmt.Fprintln(out)
 	if options.Explain {
-		fmt.Fprintln(out, "| # | Path | Score | Commits | Churn | Contributors | Burst | Bugfixes | Lines | C | Ch | R | B | O | Bf | Cx |")
-		fmt.Fprintln(out, "|---|------|-------|---------|-------|--------------|-------|----------|-------|---|----|----|---|---|----|-----|")
+		fmt.Fprintln(out, "| # | Path | Score | Level | Commits | Churn | Contributors | Burst | Bugfixes | Lines | C | Ch | R | B | O | Bf | Cx | Cp |")
+		fmt.Fprintln(out, "|---|------|-------|-------|---------|-------|--------------|-------|----------|-------|---|----|----|---|---|----|-----|----|")
 	} else {
-		fmt.Fprintln(out, "| # | Path | Score | Commits | Churn | Contributors | Burst | Bugfixes | Lines |")
-		fmt.Fprintln(out, "|---|------|-------|---------|-------|--------------|-------|----------|-------|")
+		fmt.Fprintln(out, "| # | Path | Score | Level | Commits | Churn | Contributors | Burst | Bugfixes | Lines |")
+		fmt.Fprintln(out, "|---|------|-------|-------|---------|-------|--------------|-------|----------|-------|")
 	}
 
 	// Table rows
 	for i, item := range items {
+		level := report.riskLevel(item)
+		levelEmoji := getRiskLevelEmoji(string(level))
 		if options.Explain && item.Breakdown != nil {
-			fmt.Fprintf(out, "| %d | `%s` | %.4f | %d | %d | %d | %.2f | %d | %d | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f |\n",
-				i+1, item.Path, item.RiskScore, item.Metrics.CommitCount, item.Metrics.ChurnTotal(),
+			fmt.Fprintf(out, "| %d | `%s` | %.4f | %s %s | %d | %d | %d | %.2f | %d | %d | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f | %.3f |\n",
+				i+1, item.Path, item.RiskScore, levelEmoji, level, item.Metrics.CommitCount, item.Metrics.ChurnTotal(),
 				item.Metrics.ContributorCount(), item.Metrics.BurstScore, item.Metrics.BugfixCount,
 				item.Metrics.FileSize,
 				item.Breakdown.CommitComponent, item.Breakdown.ChurnComponent,
 				item.Breakdown.RecencyComponent, item.Breakdown.BurstComponent,
 				item.Breakdown.OwnershipComponent, item.Breakdown.BugfixComponent,
-				item.Breakdown.ComplexityComponent)
+				item.Breakdown.ComplexityComponent, item.Breakdown.CouplingComponent)
 		} else {
-			fmt.Fprintf(out, "| %d | `%s` | %.4f | %d | %d | %d | %.2f | %d | %d |\n",
-				i+1, item.Path, item.RiskScore, item.Metrics.CommitCount, item.Metrics.ChurnTotal(),
+			fmt.Fprintf(out, "| %d | `%s` | %.4f | %s %s | %d | %d | %d | %.2f | %d | %d |\n",
+				i+1, item.Path, item.RiskScore, levelEmoji, level, item.Metrics.CommitCount, item.Metrics.ChurnTotal(),
 				item.Metrics.ContributorCount(), item.Metrics.BurstScore, item.Metrics.BugfixCount,
 				item.Metrics.FileSize)
 		}
@@ -60,7 +65,17 @@ func (w *MarkdownFileWriter) Write(report *FileAnalysisReport, options OutputOpt
 
 	if options.Explain {
 		fmt.Fprintln(out)
-		fmt.Fprintln(out, "**Score Breakdown:** C=Commit, Ch=Churn, R=Recency, B=Burst, O=Ownership, Bf=Bugfix, Cx=Complexity")
+		fmt.Fprintln(out, "**Score Breakdown:** C=Commit, Ch=Churn, R=Recency, B=Burst, O=Ownership, Bf=Bugfix, Cx=Complexity, Cp=Coupling")
+		var overridden []string
+		for i, item := range items {
+			if text := overrideText(item.Breakdown); text != "" {
+				overridden = append(overridden, fmt.Sprintf("- #%d `%s`: %s", i+1, item.Path, text))
+			}
+		}
+		if len(overridden) > 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, strings.Join(overridden, "\n"))
+		}
 	}
 
 	return nil
@@ -93,8 +108,8 @@ func (w *MarkdownCommitWriter) Write(report *CommitAnalysisReport, options Outpu
 	fmt.Fprintln(out, "## High-Risk Commits")
 	fmt.Fprintln(out)
 	if options.Explain {
-		fmt.Fprintln(out, "| # | SHA | Score | Level | Files | Churn | Entropy | Message | D | S | E |")
-		fmt.Fprintln(out, "|---|-----|-------|-------|-------|-------|---------|---------|---|---|---|")
+		fmt.Fprintln(out, "| # | SHA | Score | Level | Files | Churn | Entropy | Message | D | S | E | B |")
+		fmt.Fprintln(out, "|---|-----|-------|-------|-------|-------|---------|---------|---|---|---|---|")
 	} else {
 		fmt.Fprintln(out, "| # | SHA | Score | Level | Files | Churn | Entropy | Message |")
 		fmt.Fprintln(out, "|---|-----|-------|-------|-------|-------|---------|---------|")
@@ -109,11 +124,11 @@ func (w *MarkdownCommitWriter) Write(report *CommitAnalysisReport, options Outpu
 		}
 
 		if options.Explain && item.Breakdown != nil {
-			fmt.Fprintf(out, "| %d | `%s` | %.4f | %s %s | %d | %d | %.2f | %s | %.3f | %.3f | %.3f |\n",
+			fmt.Fprintf(out, "| %d | `%s` | %.4f | %s %s | %d | %d | %.2f | %s | %.3f | %.3f | %.3f | %.3f |\n",
 				i+1, item.Metrics.SHA[:8], item.RiskScore, levelEmoji, item.RiskLevel,
 				item.Metrics.FileCount, item.Metrics.TotalChurn(), item.Metrics.ChangeEntropy,
 				escapedMsg, item.Breakdown.DiffusionComponent, item.Breakdown.SizeComponent,
-				item.Breakdown.EntropyComponent)
+				item.Breakdown.EntropyComponent, item.Breakdown.BreakingComponent)
 		} else {
 			fmt.Fprintf(out, "| %d | `%s` | %.4f | %s %s | %d | %d | %.2f | %s |\n",
 				i+1, item.Metrics.SHA[:8], item.RiskScore, levelEmoji, item.RiskLevel,
@@ -124,7 +139,137 @@ func (w *MarkdownCommitWriter) Write(report *CommitAnalysisReport, options Outpu
 
 	if options.Explain {
 		fmt.Fprintln(out)
-		fmt.Fprintln(out, "**Score Breakdown:** D=Diffusion, S=Size, E=Entropy")
+		fmt.Fprintln(out, "**Score Breakdown:** D=Diffusion, S=Size, E=Entropy, B=Breaking")
+	}
+
+	if len(report.Scopes) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "## Commits by Scope")
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "| Scope | Commits | High | Breaking | Max | Mean |")
+		fmt.Fprintln(out, "|-------|---------|------|----------|-----|------|")
+		for _, g := range report.Scopes {
+			fmt.Fprintf(out, "| %s | %d | %d | %d | %.4f | %.4f |\n",
+				escapeMarkdown(scopeLabel(g.Scope)), g.Commits, g.HighRisk, g.Breaking, g.MaxScore, g.MeanScore)
+		}
+	}
+
+	return nil
+}
+
+// MarkdownFunctionWriter writes function analysis reports as Markdown.
+type MarkdownFunctionWriter struct{}
+
+// Write outputs the function analysis report as Markdown.
+func (w *MarkdownFunctionWriter) Write(report *FunctionAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	// Header
+	fmt.Fprintln(out, "# Function Hotspot Analysis Results")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "**Repository:** %s\n\n", report.RepoPath)
+	label, value := dateRangeLabelAndValue(report.Since, report.Until)
+	fmt.Fprintf(out, "**%s:** %s\n\n", label, value)
+	fmt.Fprintf(out, "**Total Functions Analyzed:** %d\n\n", len(report.Items))
+
+	// Table header
+	fmt.Fprintln(out, "## Hotspot Functions")
+	fmt.Fprintln(out)
+	if options.Explain {
+		fmt.Fprintln(out, "| # | Function | Location | Score | Level | Commits | Churn | Bugfixes | C | Ch | Bf | R |")
+		fmt.Fprintln(out, "|---|----------|----------|-------|-------|---------|-------|----------|---|----|----|---|")
+	} else {
+		fmt.Fprintln(out, "| # | Function | Location | Score | Level | Commits | Churn | Bugfixes |")
+		fmt.Fprintln(out, "|---|----------|----------|-------|-------|---------|-------|----------|")
+	}
+
+	// Table rows
+	for i, item := range items {
+		m := item.Metrics
+		levelEmoji := getRiskLevelEmoji(string(item.RiskLevel))
+		if options.Explain && item.Breakdown != nil {
+			fmt.Fprintf(out, "| %d | `%s` | `%s` | %.4f | %s %s | %d | %d | %d | %.3f | %.3f | %.3f | %.3f |\n",
+				i+1, m.Name, functionLocation(m), item.RiskScore, levelEmoji, item.RiskLevel,
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+				item.Breakdown.CommitComponent, item.Breakdown.ChurnComponent,
+				item.Breakdown.BugfixComponent, item.Breakdown.RecencyComponent)
+		} else {
+			fmt.Fprintf(out, "| %d | `%s` | `%s` | %.4f | %s %s | %d | %d | %d |\n",
+				i+1, m.Name, functionLocation(m), item.RiskScore, levelEmoji, item.RiskLevel,
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount)
+		}
+	}
+
+	if options.Explain {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "**Score Breakdown:** C=Commit, Ch=Churn, Bf=Bugfix, R=Recency")
+	}
+
+	return nil
+}
+
+// MarkdownRangeWriter writes line range analysis reports as Markdown.
+type MarkdownRangeWriter struct{}
+
+// Write outputs the line range analysis report as Markdown.
+func (w *MarkdownRangeWriter) Write(report *RangeAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	// Header
+	fmt.Fprintln(out, "# Line Range Hotspot Analysis Results")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "**Repository:** %s\n\n", report.RepoPath)
+	label, value := dateRangeLabelAndValue(report.Since, report.Until)
+	fmt.Fprintf(out, "**%s:** %s\n\n", label, value)
+	fmt.Fprintf(out, "**Total Ranges Analyzed:** %d\n\n", len(report.Items))
+
+	// Table header
+	fmt.Fprintln(out, "## Hotspot Line Ranges")
+	fmt.Fprintln(out)
+	if options.Explain {
+		fmt.Fprintln(out, "| # | Location | Lines | Score | Level | Commits | Churn | Bugfixes | C | Ch | Bf | R |")
+		fmt.Fprintln(out, "|---|----------|-------|-------|-------|---------|-------|----------|---|----|----|---|")
+	} else {
+		fmt.Fprintln(out, "| # | Location | Lines | Score | Level | Commits | Churn | Bugfixes |")
+		fmt.Fprintln(out, "|---|----------|-------|-------|-------|---------|-------|----------|")
+	}
+
+	// Table rows
+	for i, item := range items {
+		m := item.Metrics
+		levelEmoji := getRiskLevelEmoji(string(item.RiskLevel))
+		if options.Explain && item.Breakdown != nil {
+			fmt.Fprintf(out, "| %d | `%s` | %d | %.4f | %s %s | %d | %d | %d | %.3f | %.3f | %.3f | %.3f |\n",
+				i+1, rangeLocation(m), m.LineCount(), item.RiskScore, levelEmoji, item.RiskLevel,
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount,
+				item.Breakdown.CommitComponent, item.Breakdown.ChurnComponent,
+				item.Breakdown.BugfixComponent, item.Breakdown.RecencyComponent)
+		} else {
+			fmt.Fprintf(out, "| %d | `%s` | %d | %.4f | %s %s | %d | %d | %d |\n",
+				i+1, rangeLocation(m), m.LineCount(), item.RiskScore, levelEmoji, item.RiskLevel,
+				m.CommitCount, m.ChurnTotal(), m.BugfixCount)
+		}
+	}
+
+	if options.Explain {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "**Score Breakdown:** C=Commit, Ch=Churn, Bf=Bugfix, R=Recency")
 	}
 
 	return nil
@@ -174,9 +319,94 @@ func (w *MarkdownCouplingWriter) Write(report *CouplingAnalysisReport, options O
 			c.JaccardCoefficient, c.Confidence, c.Lift)
 	}
 
+	if result.Clusters != nil {
+		writeMarkdownClusters(out, result.Clusters, options.Top)
+	}
+	if result.CrossBoundary != nil {
+		writeMarkdownCrossBoundary(out, result.CrossBoundary, options.Top)
+	}
+
 	return nil
 }
 
+func writeMarkdownCrossBoundary(out io.Writer, cross *coupling.CrossBoundaryResult, top int) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "## Cross-Boundary Coupling")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "**Cross-boundary pairs:** %d of %d\n\n", len(cross.Pairs), cross.TotalPairs)
+	if len(cross.Pairs) == 0 {
+		fmt.Fprintln(out, "No cross-boundary couplings found.")
+	} else {
+		fmt.Fprintln(out, "| # | Module A | File A | Module B | File B | Co-Commits | Jaccard |")
+		fmt.Fprintln(out, "|---|----------|--------|----------|--------|------------|---------|")
+		for i, p := range limitTop(cross.Pairs, top) {
+			fmt.Fprintf(out, "| %d | %s | `%s` | %s | `%s` | %d | %.3f |\n",
+				i+1, escapeMarkdown(p.BoundaryA), p.FileA, escapeMarkdown(p.BoundaryB), p.FileB,
+				p.CoCommitCount, p.JaccardCoefficient)
+		}
+	}
+
+	if len(cross.Matrix.Boundaries) < 2 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "### Boundary Matrix")
+	fmt.Fprintln(out)
+	header := make([]string, len(cross.Matrix.Boundaries))
+	for i, b := range cross.Matrix.Boundaries {
+		header[i] = escapeMarkdown(b)
+	}
+	fmt.Fprintf(out, "| Module | %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(out, "|--------|%s\n", strings.Repeat("---|", len(header)))
+	for i, name := range header {
+		cells := make([]string, len(cross.Matrix.Counts[i]))
+		for j, n := range cross.Matrix.Counts[i] {
+			cells[j] = fmt.Sprintf("%d", n)
+		}
+		fmt.Fprintf(out, "| %s | %s |\n", name, strings.Join(cells, " | "))
+	}
+}
+
+func writeMarkdownClusters(out io.Writer, clusters *coupling.ClusterAnalysisResult, top int) {
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "## Change Clusters")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "**Modularity:** %.3f\n\n", clusters.Modularity)
+	if len(clusters.Clusters) == 0 {
+		fmt.Fprintln(out, "No clusters found.")
+		return
+	}
+
+	fmt.Fprintln(out, "| # | Files | Cohesion | Directories | Members |")
+	fmt.Fprintln(out, "|---|-------|----------|-------------|---------|")
+	for _, cl := range limitTop(clusters.Clusters, top) {
+		members := make([]string, len(cl.Files))
+		for i, f := range cl.Files {
+			members[i] = "`" + f + "`"
+		}
+		dirs := fmt.Sprintf("%d", len(cl.Directories))
+		if cl.SpansDirectories() {
+			dirs += " ⚠️"
+		}
+		fmt.Fprintf(out, "| %d | %d | %.3f | %s | %s |\n",
+			cl.ID, len(cl.Files), cl.Cohesion, dirs, strings.Join(members, ", "))
+	}
+
+	if len(clusters.Bridges) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "### Bridge Files")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "| Path | Cluster | Linked Clusters | External |")
+	fmt.Fprintln(out, "|------|---------|-----------------|----------|")
+	for _, b := range limitTop(clusters.Bridges, top) {
+		fmt.Fprintf(out, "| `%s` | %s | %d | %.3f |\n", b.Path, clusterLabel(b.ClusterID), b.ConnectedClusters, b.ExternalRatio)
+	}
+}
+
 func getRiskLevelEmoji(level string) string {
 	switch level {
 	case "high":
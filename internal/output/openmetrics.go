@@ -0,0 +1,213 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// metricLabel is a single name="value" pair on a sample.
+type metricLabel struct {
+	Name  string
+	Value string
+}
+
+type metricSample struct {
+	Labels []metricLabel
+	Value  float64
+}
+
+// metricFamily is a gauge with its help text and samples.
+type metricFamily struct {
+	Name    string
+	Help    string
+	Samples []metricSample
+}
+
+func (f *metricFamily) add(value float64, labels ...metricLabel) {
+	f.Samples = append(f.Samples, metricSample{Labels: labels, Value: value})
+}
+
+// escapeLabelValue escapes a label value for the OpenMetrics/Prometheus text format.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// writeOpenMetrics writes gauge families in the OpenMetrics text format. The output
+// also parses as the Prometheus text format, where the trailing "# EOF" is a comment,
+// so it can be dropped into the node-exporter textfile collector directory.
+func writeOpenMetrics(families []*metricFamily, outputPath string) error {
+	out, file, err := openOutputWriter(outputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n", f.Name, f.Help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", f.Name)
+		for _, s := range f.Samples {
+			b.WriteString(f.Name)
+			if len(s.Labels) > 0 {
+				b.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, "%s=\"%s\"", l.Name, escapeLabelValue(l.Value))
+				}
+				b.WriteByte('}')
+			}
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(s.Value, 'g', -1, 64))
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("# EOF\n")
+
+	_, err = io.WriteString(out, b.String())
+	return err
+}
+
+// riskLevelFamily counts items per risk level, always emitting all three levels so
+// dashboards see an explicit zero.
+func riskLevelFamily(name, help string, repo metricLabel, levels []config.RiskLevel) *metricFamily {
+	counts := map[config.RiskLevel]int{}
+	for _, level := range levels {
+		counts[level]++
+	}
+	f := &metricFamily{Name: name, Help: help}
+	for _, level := range []config.RiskLevel{config.RiskLevelHigh, config.RiskLevelMedium, config.RiskLevelLow} {
+		f.add(float64(counts[level]), repo, metricLabel{"level", string(level)})
+	}
+	return f
+}
+
+// scoreComponent is one named factor of a score breakdown.
+type scoreComponent struct {
+	Name  string
+	Value float64
+}
+
+// fileScoreComponents lists file score components by the names used in JSON output.
+func fileScoreComponents(b *scoring.ScoreBreakdown) []scoreComponent {
+	return []scoreComponent{
+		{"commit", b.CommitComponent},
+		{"churn", b.ChurnComponent},
+		{"recency", b.RecencyComponent},
+		{"burst", b.BurstComponent},
+		{"ownership", b.OwnershipComponent},
+		{"bugfix", b.BugfixComponent},
+		{"complexity", b.ComplexityComponent},
+		{"coupling", b.CouplingComponent},
+	}
+}
+
+// commitScoreComponents lists commit score components by the names used in JSON output.
+func commitScoreComponents(b *scoring.CommitRiskBreakdown) []scoreComponent {
+	return []scoreComponent{
+		{"diffusion", b.DiffusionComponent},
+		{"size", b.SizeComponent},
+		{"entropy", b.EntropyComponent},
+		{"breaking", b.BreakingComponent},
+	}
+}
+
+// OpenMetricsFileWriter writes file analysis reports as OpenMetrics gauges.
+type OpenMetricsFileWriter struct{}
+
+// Write outputs per-file risk scores and breakdowns for the top files, plus summary
+// gauges over every analyzed file.
+func (w *OpenMetricsFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+	repo := metricLabel{"repo", report.RepoPath}
+
+	score := &metricFamily{Name: "bugspots_file_risk_score", Help: "Hotspot risk score of a file (0-1)."}
+	component := &metricFamily{Name: "bugspots_file_risk_component", Help: "Contribution of a scoring factor to a file's risk score."}
+	commits := &metricFamily{Name: "bugspots_file_commits", Help: "Number of commits touching a file in the analyzed period."}
+	bugfixes := &metricFamily{Name: "bugspots_file_bugfixes", Help: "Number of bugfix commits touching a file in the analyzed period."}
+	churn := &metricFamily{Name: "bugspots_file_churn_lines", Help: "Lines added plus deleted in a file in the analyzed period."}
+
+	for _, item := range items {
+		path := metricLabel{"path", item.Path}
+		score.add(item.RiskScore, repo, path)
+		commits.add(float64(item.Metrics.CommitCount), repo, path)
+		bugfixes.add(float64(item.Metrics.BugfixCount), repo, path)
+		churn.add(float64(item.Metrics.ChurnTotal()), repo, path)
+		if item.Breakdown != nil {
+			for _, c := range fileScoreComponents(item.Breakdown) {
+				component.add(c.Value, repo, path, metricLabel{"component", c.Name})
+			}
+		}
+	}
+
+	levels := make([]config.RiskLevel, len(report.Items))
+	var maxScore float64
+	for i, item := range report.Items {
+		levels[i] = report.riskLevel(item)
+		maxScore = max(maxScore, item.RiskScore)
+	}
+
+	analyzed := &metricFamily{Name: "bugspots_files_analyzed", Help: "Number of files analyzed."}
+	analyzed.add(float64(len(report.Items)), repo)
+	maxFamily := &metricFamily{Name: "bugspots_file_risk_score_max", Help: "Highest file risk score in the repository."}
+	maxFamily.add(maxScore, repo)
+	generated := &metricFamily{Name: "bugspots_file_report_generated_timestamp_seconds", Help: "Unix time the file report was generated."}
+	generated.add(float64(report.GeneratedAt.Unix()), repo)
+
+	families := []*metricFamily{
+		score, component, commits, bugfixes, churn,
+		analyzed,
+		riskLevelFamily("bugspots_files_by_risk_level", "Number of analyzed files per risk level.", repo, levels),
+		maxFamily, generated,
+	}
+	return writeOpenMetrics(families, options.OutputPath)
+}
+
+// OpenMetricsCommitWriter writes commit analysis reports as OpenMetrics gauges.
+type OpenMetricsCommitWriter struct{}
+
+// Write outputs per-commit risk scores and breakdowns for the top commits, plus
+// summary gauges over every analyzed commit.
+func (w *OpenMetricsCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+	repo := metricLabel{"repo", report.RepoPath}
+
+	score := &metricFamily{Name: "bugspots_commit_risk_score", Help: "JIT defect risk score of a commit (0-1)."}
+	component := &metricFamily{Name: "bugspots_commit_risk_component", Help: "Contribution of a scoring factor to a commit's risk score."}
+
+	for _, item := range items {
+		sha := metricLabel{"sha", item.Metrics.SHA}
+		score.add(item.RiskScore, repo, sha)
+		if item.Breakdown != nil {
+			for _, c := range commitScoreComponents(item.Breakdown) {
+				component.add(c.Value, repo, sha, metricLabel{"component", c.Name})
+			}
+		}
+	}
+
+	levels := make([]config.RiskLevel, len(report.Items))
+	for i, item := range report.Items {
+		levels[i] = item.RiskLevel
+	}
+
+	analyzed := &metricFamily{Name: "bugspots_commits_analyzed", Help: "Number of commits analyzed."}
+	analyzed.add(float64(len(report.Items)), repo)
+	generated := &metricFamily{Name: "bugspots_commit_report_generated_timestamp_seconds", Help: "Unix time the commit report was generated."}
+	generated.add(float64(report.GeneratedAt.Unix()), repo)
+
+	families := []*metricFamily{
+		score, component,
+		analyzed,
+		riskLevelFamily("bugspots_commits_by_risk_level", "Number of analyzed commits per risk level.", repo, levels),
+		generated,
+	}
+	return writeOpenMetrics(families, options.OutputPath)
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func TestEscapeLabelValue(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"src/a.go", "src/a.go"},
+		{`dir\file "x".go`, `dir\\file \"x\".go`},
+		{"line\nbreak", `line\nbreak`},
+	}
+	for _, tt := range tests {
+		if got := escapeLabelValue(tt.input); got != tt.want {
+			t.Errorf("escapeLabelValue(%q) = %q, expected %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestOpenMetricsFileWriter_Write(t *testing.T) {
+	report := newTestFileReport()
+	report.Items[0].Breakdown = &scoring.ScoreBreakdown{CommitComponent: 0.25}
+	report.GeneratedAt = time.Unix(1700000000, 0)
+
+	tmpFile := t.TempDir() + "/bugspots.prom"
+	if err := (&OpenMetricsFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile, Top: 2}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"# TYPE bugspots_file_risk_score gauge\n",
+		`bugspots_file_risk_score{repo="/test/repo",path="src/hot.go"} 0.85` + "\n",
+		`bugspots_file_risk_component{repo="/test/repo",path="src/hot.go",component="commit"} 0.25` + "\n",
+		`bugspots_files_analyzed{repo="/test/repo"} 3` + "\n",
+		`bugspots_files_by_risk_level{repo="/test/repo",level="high"} 1` + "\n",
+		`bugspots_files_by_risk_level{repo="/test/repo",level="low"} 1` + "\n",
+		`bugspots_file_risk_score_max{repo="/test/repo"} 0.85` + "\n",
+		`bugspots_file_report_generated_timestamp_seconds{repo="/test/repo"} 1.7e+09` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+	if strings.Contains(out, `path="src/cold.go"`) {
+		t.Error("per-file series should be limited by Top")
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Error("expected the output to end with # EOF")
+	}
+}
+
+func TestOpenMetricsCommitWriter_Write(t *testing.T) {
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		GeneratedAt: time.Unix(1700000000, 0),
+		Items: []scoring.CommitRiskItem{
+			{
+				Metrics:   aggregation.CommitMetrics{SHA: "abc123"},
+				RiskScore: 0.8,
+				RiskLevel: config.RiskLevelHigh,
+				Breakdown: &scoring.CommitRiskBreakdown{DiffusionComponent: 0.5},
+			},
+			{Metrics: aggregation.CommitMetrics{SHA: "def456"}, RiskScore: 0.3, RiskLevel: config.RiskLevelLow},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/bugspots.prom"
+	if err := (&OpenMetricsCommitWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		`bugspots_commit_risk_score{repo="/test/repo",sha="abc123"} 0.8` + "\n",
+		`bugspots_commit_risk_component{repo="/test/repo",sha="abc123",component="diffusion"} 0.5` + "\n",
+		`bugspots_commits_analyzed{repo="/test/repo"} 2` + "\n",
+		`bugspots_commits_by_risk_level{repo="/test/repo",level="medium"} 0` + "\n",
+		`bugspots_commit_report_generated_timestamp_seconds{repo="/test/repo"} 1.7e+09` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "bugspots-go"
+	sarifToolURI  = "https://github.com/masmgr/bugspots-go"
+)
+
+// SARIF 2.1.0 document structures (the subset used by bugspots-go).
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool              `json:"tool"`
+	Results    []sarifResult          `json:"results"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	FullDescription      sarifMessage           `json:"fullDescription"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId"`
+	RuleIndex           int                    `json:"ruleIndex"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Properties          map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifRiskLevels lists risk levels in rule index order.
+var sarifRiskLevels = []config.RiskLevel{config.RiskLevelHigh, config.RiskLevelMedium, config.RiskLevelLow}
+
+// sarifLevel maps a risk level to a SARIF result level.
+func sarifLevel(level config.RiskLevel) string {
+	switch level {
+	case config.RiskLevelHigh:
+		return "warning"
+	case config.RiskLevelMedium:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// sarifRules builds one rule per risk level for a report kind ("file" or "commit").
+func sarifRules(kind, subject string) []sarifRule {
+	rules := make([]sarifRule, len(sarifRiskLevels))
+	for i, level := range sarifRiskLevels {
+		rules[i] = sarifRule{
+			ID:               sarifRuleID(kind, level),
+			Name:             fmt.Sprintf("%sRisk%s", kind, capitalize(string(level))),
+			ShortDescription: sarifMessage{Text: fmt.Sprintf("%s-risk %s", capitalize(string(level)), subject)},
+			FullDescription: sarifMessage{Text: fmt.Sprintf(
+				"The %s's risk score, derived from its change history, falls in the %s risk band.", kind, level)},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(level)},
+			Properties:           map[string]interface{}{"tags": []string{"hotspot", kind}},
+		}
+	}
+	return rules
+}
+
+func sarifRuleID(kind string, level config.RiskLevel) string {
+	return fmt.Sprintf("bugspots/%s-risk-%s", kind, level)
+}
+
+func sarifRuleIndex(level config.RiskLevel) int {
+	for i, l := range sarifRiskLevels {
+		if l == level {
+			return i
+		}
+	}
+	return len(sarifRiskLevels) - 1
+}
+
+func sarifFileLocation(path string) sarifLocation {
+	return sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: path, URIBaseID: "%SRCROOT%"},
+			Region:           sarifRegion{StartLine: 1},
+		},
+	}
+}
+
+func newSARIFLog(rules []sarifRule, results []sarifResult, properties map[string]interface{}) sarifLog {
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+				Rules:          rules,
+			}},
+			Results:    results,
+			Properties: properties,
+		}},
+	}
+}
+
+func sarifRunProperties(repoPath string, since *time.Time, until, generatedAt time.Time) map[string]interface{} {
+	props := map[string]interface{}{
+		"repo":        repoPath,
+		"until":       until.Format(reportDateLayout),
+		"generatedAt": generatedAt.Format(time.RFC3339),
+	}
+	if s := formatSinceDate(since); s != nil {
+		props["since"] = *s
+	}
+	return props
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// SARIFFileWriter writes file analysis reports as SARIF 2.1.0.
+type SARIFFileWriter struct{}
+
+// Write outputs the file analysis report as SARIF.
+func (w *SARIFFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	results := make([]sarifResult, 0, len(items))
+	for i, item := range items {
+		level := report.riskLevel(item)
+		props := map[string]interface{}{
+			"rank":           i + 1,
+			"riskScore":      item.RiskScore,
+			"riskLevel":      string(level),
+			"commitCount":    item.Metrics.CommitCount,
+			"churnTotal":     item.Metrics.ChurnTotal(),
+			"contributors":   item.Metrics.ContributorCount(),
+			"burstScore":     item.Metrics.BurstScore,
+			"ownershipRatio": item.Metrics.OwnershipRatio(),
+			"bugfixCount":    item.Metrics.BugfixCount,
+			"lastModified":   item.Metrics.LastModifiedAt.Format(time.RFC3339),
+		}
+		if options.Explain && item.Breakdown != nil {
+			props["breakdown"] = map[string]float64{
+				"commit":     item.Breakdown.CommitComponent,
+				"churn":      item.Breakdown.ChurnComponent,
+				"recency":    item.Breakdown.RecencyComponent,
+				"burst":      item.Breakdown.BurstComponent,
+				"ownership":  item.Breakdown.OwnershipComponent,
+				"bugfix":     item.Breakdown.BugfixComponent,
+				"complexity": item.Breakdown.ComplexityComponent,
+				"coupling":   item.Breakdown.CouplingComponent,
+			}
+			if len(item.Breakdown.Overrides) > 0 {
+				props["overrides"] = item.Breakdown.Overrides
+				props["scoreFactor"] = item.Breakdown.ScoreFactor
+				if item.Breakdown.FixedLevel != "" {
+					props["fixedLevel"] = string(item.Breakdown.FixedLevel)
+				}
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:              sarifRuleID("file", level),
+			RuleIndex:           sarifRuleIndex(level),
+			Level:               sarifLevel(level),
+			Message:             sarifMessage{Text: fileHotspotMessage(i+1, item, level)},
+			Locations:           []sarifLocation{sarifFileLocation(item.Path)},
+			PartialFingerprints: map[string]string{"bugspotsPath/v1": item.Path},
+			Properties:          props,
+		})
+	}
+
+	log := newSARIFLog(sarifRules("file", "file hotspot"), results,
+		sarifRunProperties(report.RepoPath, report.Since, report.Until, report.GeneratedAt))
+	return writeJSON(log, options.OutputPath)
+}
+
+// SARIFCommitWriter writes commit analysis reports as SARIF 2.1.0.
+type SARIFCommitWriter struct{}
+
+// Write outputs the commit analysis report as SARIF. Each result points at the files
+// the commit changed.
+func (w *SARIFCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+
+	results := make([]sarifResult, 0, len(items))
+	for _, item := range items {
+		m := item.Metrics
+		props := map[string]interface{}{
+			"sha":            m.SHA,
+			"author":         m.Author.Name,
+			"email":          m.Author.Email,
+			"date":           m.When.Format(time.RFC3339),
+			"riskScore":      item.RiskScore,
+			"riskLevel":      string(item.RiskLevel),
+			"fileCount":      m.FileCount,
+			"directoryCount": m.DirectoryCount,
+			"subsystemCount": m.SubsystemCount,
+			"linesAdded":     m.LinesAdded,
+			"linesDeleted":   m.LinesDeleted,
+			"changeEntropy":  m.ChangeEntropy,
+		}
+		if options.Explain && item.Breakdown != nil {
+			props["breakdown"] = map[string]float64{
+				"diffusion": item.Breakdown.DiffusionComponent,
+				"size":      item.Breakdown.SizeComponent,
+				"entropy":   item.Breakdown.EntropyComponent,
+				"breaking":  item.Breakdown.BreakingComponent,
+			}
+		}
+
+		locations := make([]sarifLocation, 0, len(m.Paths))
+		for _, p := range m.Paths {
+			locations = append(locations, sarifFileLocation(p))
+		}
+
+		sha := m.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    sarifRuleID("commit", item.RiskLevel),
+			RuleIndex: sarifRuleIndex(item.RiskLevel),
+			Level:     sarifLevel(item.RiskLevel),
+			Message: sarifMessage{Text: fmt.Sprintf("Commit %s has %s risk score %.4f: %s",
+				sha, item.RiskLevel, item.RiskScore, m.Message)},
+			Locations:           locations,
+			PartialFingerprints: map[string]string{"bugspotsCommit/v1": m.SHA},
+			Properties:          props,
+		})
+	}
+
+	log := newSARIFLog(sarifRules("commit", "commit"), results,
+		sarifRunProperties(report.RepoPath, report.Since, report.Until, report.GeneratedAt))
+	return writeJSON(log, options.OutputPath)
+}
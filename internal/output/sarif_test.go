@@ -0,0 +1,152 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func readSARIF(t *testing.T, path string) sarifLog {
+	t.Helper()
+	data, err := readTestFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log.Version != sarifVersion || log.Schema != sarifSchema {
+		t.Errorf("version/schema = %q/%q", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(log.Runs))
+	}
+	return log
+}
+
+func TestSARIFFileWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &FileAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.FileRiskItem{
+			{Path: "src/hot.go", RiskScore: 0.85, Metrics: &aggregation.FileMetrics{CommitCount: 10, BugfixCount: 3},
+				Breakdown: &scoring.ScoreBreakdown{CommitComponent: 0.2}},
+			{Path: "src/warm.go", RiskScore: 0.50, Metrics: &aggregation.FileMetrics{CommitCount: 5}},
+			{Path: "src/cold.go", RiskScore: 0.20, Metrics: &aggregation.FileMetrics{CommitCount: 2}},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/out.sarif"
+	writer := &SARIFFileWriter{}
+	if err := writer.Write(report, OutputOptions{Format: FormatSARIF, OutputPath: tmpFile, Explain: true}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	run := readSARIF(t, tmpFile).Runs[0]
+	if run.Tool.Driver.Name != sarifToolName {
+		t.Errorf("driver name = %q", run.Tool.Driver.Name)
+	}
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+
+	tests := []struct {
+		path   string
+		ruleID string
+		level  string
+	}{
+		{"src/hot.go", "bugspots/file-risk-high", "warning"},
+		{"src/warm.go", "bugspots/file-risk-medium", "note"},
+		{"src/cold.go", "bugspots/file-risk-low", "none"},
+	}
+	for i, tt := range tests {
+		r := run.Results[i]
+		if r.RuleID != tt.ruleID || r.Level != tt.level {
+			t.Errorf("result %d: rule/level = %s/%s, expected %s/%s", i, r.RuleID, r.Level, tt.ruleID, tt.level)
+		}
+		if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+			t.Errorf("result %d: ruleIndex %d does not point at %s", i, r.RuleIndex, r.RuleID)
+		}
+		if len(r.Locations) != 1 || r.Locations[0].PhysicalLocation.ArtifactLocation.URI != tt.path {
+			t.Errorf("result %d: locations = %+v", i, r.Locations)
+		}
+		if r.PartialFingerprints["bugspotsPath/v1"] != tt.path {
+			t.Errorf("result %d: fingerprint = %v", i, r.PartialFingerprints)
+		}
+	}
+
+	if _, ok := run.Results[0].Properties["breakdown"]; !ok {
+		t.Error("expected breakdown in properties with Explain")
+	}
+	if _, ok := run.Results[1].Properties["breakdown"]; ok {
+		t.Error("did not expect breakdown for an item without one")
+	}
+}
+
+func TestSARIFFileWriter_Top(t *testing.T) {
+	report := &FileAnalysisReport{
+		Items: []scoring.FileRiskItem{
+			{Path: "a.go", RiskScore: 0.9, Metrics: &aggregation.FileMetrics{}},
+			{Path: "b.go", RiskScore: 0.8, Metrics: &aggregation.FileMetrics{}},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/out.sarif"
+	if err := (&SARIFFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile, Top: 1}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if n := len(readSARIF(t, tmpFile).Runs[0].Results); n != 1 {
+		t.Errorf("expected 1 result with Top=1, got %d", n)
+	}
+}
+
+func TestSARIFCommitWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		Items: []scoring.CommitRiskItem{
+			{
+				Metrics: aggregation.CommitMetrics{
+					SHA:     "0123456789abcdef",
+					When:    now,
+					Message: "refactor storage",
+					Paths:   []string{"store/a.go", "store/b.go"},
+				},
+				RiskScore: 0.75,
+				RiskLevel: config.RiskLevelHigh,
+			},
+		},
+	}
+
+	tmpFile := t.TempDir() + "/out.sarif"
+	if err := (&SARIFCommitWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	run := readSARIF(t, tmpFile).Runs[0]
+	if len(run.Results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(run.Results))
+	}
+	r := run.Results[0]
+	if r.RuleID != "bugspots/commit-risk-high" || r.Level != "warning" {
+		t.Errorf("rule/level = %s/%s", r.RuleID, r.Level)
+	}
+	if len(r.Locations) != 2 || r.Locations[1].PhysicalLocation.ArtifactLocation.URI != "store/b.go" {
+		t.Errorf("locations = %+v, expected the changed files", r.Locations)
+	}
+	if r.PartialFingerprints["bugspotsCommit/v1"] != "0123456789abcdef" {
+		t.Errorf("fingerprint = %v", r.PartialFingerprints)
+	}
+}
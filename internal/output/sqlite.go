@@ -0,0 +1,322 @@
+package output
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// Pure-Go SQLite driver (no cgo), registered as "sqlite".
+	_ "modernc.org/sqlite"
+
+	"github.com/masmgr/bugspots-go/internal/git"
+)
+
+// sqliteSchema creates the export tables. Every row references the run that produced
+// it, so repeated exports into the same database can be compared with SQL.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	command       TEXT NOT NULL,
+	repo          TEXT NOT NULL,
+	since         TEXT,
+	until         TEXT NOT NULL,
+	generated_at  TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS commits (
+	run_id        INTEGER NOT NULL REFERENCES runs(id),
+	sha           TEXT NOT NULL,
+	author_name   TEXT NOT NULL,
+	author_email  TEXT NOT NULL,
+	committed_at  TEXT NOT NULL,
+	message       TEXT NOT NULL,
+	PRIMARY KEY (run_id, sha)
+);
+CREATE TABLE IF NOT EXISTS file_changes (
+	run_id        INTEGER NOT NULL REFERENCES runs(id),
+	sha           TEXT NOT NULL,
+	path          TEXT NOT NULL,
+	old_path      TEXT,
+	kind          TEXT NOT NULL,
+	lines_added   INTEGER NOT NULL,
+	lines_deleted INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS file_changes_path ON file_changes (run_id, path);
+CREATE TABLE IF NOT EXISTS file_metrics (
+	run_id            INTEGER NOT NULL REFERENCES runs(id),
+	path              TEXT NOT NULL,
+	commit_count      INTEGER NOT NULL,
+	lines_added       INTEGER NOT NULL,
+	lines_deleted     INTEGER NOT NULL,
+	contributors      INTEGER NOT NULL,
+	ownership_ratio   REAL NOT NULL,
+	burst_score       REAL NOT NULL,
+	bugfix_count      INTEGER NOT NULL,
+	file_size         INTEGER NOT NULL,
+	coupling_partners INTEGER NOT NULL,
+	coupling_degree   REAL NOT NULL,
+	last_modified     TEXT NOT NULL,
+	PRIMARY KEY (run_id, path)
+);
+CREATE TABLE IF NOT EXISTS file_scores (
+	run_id               INTEGER NOT NULL REFERENCES runs(id),
+	path                 TEXT NOT NULL,
+	rank                 INTEGER NOT NULL,
+	risk_score           REAL NOT NULL,
+	risk_level           TEXT NOT NULL,
+	commit_component     REAL,
+	churn_component      REAL,
+	recency_component    REAL,
+	burst_component      REAL,
+	ownership_component  REAL,
+	bugfix_component     REAL,
+	complexity_component REAL,
+	coupling_component   REAL,
+	PRIMARY KEY (run_id, path)
+);
+CREATE TABLE IF NOT EXISTS commit_scores (
+	run_id              INTEGER NOT NULL REFERENCES runs(id),
+	sha                 TEXT NOT NULL,
+	rank                INTEGER NOT NULL,
+	risk_score          REAL NOT NULL,
+	risk_level          TEXT NOT NULL,
+	file_count          INTEGER NOT NULL,
+	directory_count     INTEGER NOT NULL,
+	subsystem_count     INTEGER NOT NULL,
+	lines_added         INTEGER NOT NULL,
+	lines_deleted       INTEGER NOT NULL,
+	change_entropy      REAL NOT NULL,
+	diffusion_component REAL,
+	size_component      REAL,
+	entropy_component   REAL,
+	commit_type         TEXT,
+	commit_scope        TEXT,
+	breaking            INTEGER NOT NULL DEFAULT 0,
+	breaking_component  REAL,
+	PRIMARY KEY (run_id, sha)
+);
+CREATE TABLE IF NOT EXISTS couplings (
+	run_id              INTEGER NOT NULL REFERENCES runs(id),
+	file_a              TEXT NOT NULL,
+	file_b              TEXT NOT NULL,
+	co_commit_count     INTEGER NOT NULL,
+	file_a_commit_count INTEGER NOT NULL,
+	file_b_commit_count INTEGER NOT NULL,
+	jaccard             REAL NOT NULL,
+	confidence          REAL NOT NULL,
+	lift                REAL NOT NULL,
+	PRIMARY KEY (run_id, file_a, file_b)
+);
+`
+
+// errSQLiteOutputPath is returned when the SQLite format is used without --output.
+var errSQLiteOutputPath = errors.New("sqlite output requires --output <database path>")
+
+// sqliteExport runs fn inside a transaction on the database at path, after creating
+// the schema and inserting a row into runs. The database is created if missing and
+// appended to otherwise.
+func sqliteExport(path, command, repoPath string, since *time.Time, until, generatedAt time.Time,
+	changeSets []git.CommitChangeSet, fn func(tx *sql.Tx, runID int64) error) (err error) {
+	if path == "" {
+		return errSQLiteOutputPath
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create SQLite schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin SQLite transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	res, err := tx.Exec(`INSERT INTO runs (command, repo, since, until, generated_at) VALUES (?, ?, ?, ?, ?)`,
+		command, repoPath, formatSinceDate(since), until.Format(reportDateLayout), generatedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to insert run: %w", err)
+	}
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to read run id: %w", err)
+	}
+
+	if err := insertSQLiteHistory(tx, runID, changeSets); err != nil {
+		return err
+	}
+	if err := fn(tx, runID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit SQLite transaction: %w", err)
+	}
+	return nil
+}
+
+// insertRows prepares query once and executes it for every argument list.
+func insertRows(tx *sql.Tx, table, query string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare %s insert: %w", table, err)
+	}
+	defer stmt.Close()
+
+	for _, args := range rows {
+		if _, err := stmt.Exec(args...); err != nil {
+			return fmt.Errorf("failed to insert into %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func insertSQLiteHistory(tx *sql.Tx, runID int64, changeSets []git.CommitChangeSet) error {
+	commits := make([][]interface{}, 0, len(changeSets))
+	var changes [][]interface{}
+	seen := make(map[string]struct{}, len(changeSets))
+	for _, cs := range changeSets {
+		c := cs.Commit
+		if _, dup := seen[c.SHA]; dup {
+			continue
+		}
+		seen[c.SHA] = struct{}{}
+		commits = append(commits, []interface{}{runID, c.SHA, c.Author.Name, c.Author.Email, c.When.Format(time.RFC3339), c.Message})
+		for _, fc := range cs.Changes {
+			var oldPath interface{}
+			if fc.OldPath != "" {
+				oldPath = fc.OldPath
+			}
+			changes = append(changes, []interface{}{runID, c.SHA, fc.Path, oldPath, fc.Kind.String(), fc.LinesAdded, fc.LinesDeleted})
+		}
+	}
+
+	if err := insertRows(tx, "commits",
+		`INSERT INTO commits (run_id, sha, author_name, author_email, committed_at, message) VALUES (?, ?, ?, ?, ?, ?)`,
+		commits); err != nil {
+		return err
+	}
+	return insertRows(tx, "file_changes",
+		`INSERT INTO file_changes (run_id, sha, path, old_path, kind, lines_added, lines_deleted) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		changes)
+}
+
+// nullableComponent returns the component value, or nil (SQL NULL) without a breakdown.
+func nullableComponent(hasBreakdown bool, value float64) interface{} {
+	if !hasBreakdown {
+		return nil
+	}
+	return value
+}
+
+// nullableText returns s, or nil (SQL NULL) when it is empty.
+func nullableText(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// SQLiteFileWriter exports file analysis results into a SQLite database. Every
+// analyzed file is exported regardless of --top.
+type SQLiteFileWriter struct{}
+
+// Write appends a run with its history, file metrics and file scores to the database.
+func (w *SQLiteFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	return sqliteExport(options.OutputPath, "analyze", report.RepoPath, report.Since, report.Until, report.GeneratedAt,
+		report.ChangeSets, func(tx *sql.Tx, runID int64) error {
+			metrics := make([][]interface{}, 0, len(report.Items))
+			scores := make([][]interface{}, 0, len(report.Items))
+			for i, item := range report.Items {
+				m := item.Metrics
+				metrics = append(metrics, []interface{}{runID, item.Path, m.CommitCount, m.AddedLines, m.DeletedLines,
+					m.ContributorCount(), m.OwnershipRatio(), m.BurstScore, m.BugfixCount, m.FileSize,
+					m.CouplingPartners, m.CouplingDegree, m.LastModifiedAt.Format(time.RFC3339)})
+
+				row := []interface{}{runID, item.Path, i + 1, item.RiskScore, string(report.riskLevel(item))}
+				b := item.Breakdown
+				if b == nil {
+					row = append(row, nil, nil, nil, nil, nil, nil, nil, nil)
+				} else {
+					for _, c := range fileScoreComponents(b) {
+						row = append(row, c.Value)
+					}
+				}
+				scores = append(scores, row)
+			}
+
+			if err := insertRows(tx, "file_metrics",
+				`INSERT INTO file_metrics (run_id, path, commit_count, lines_added, lines_deleted, contributors,
+					ownership_ratio, burst_score, bugfix_count, file_size, coupling_partners, coupling_degree, last_modified)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, metrics); err != nil {
+				return err
+			}
+			return insertRows(tx, "file_scores",
+				`INSERT INTO file_scores (run_id, path, rank, risk_score, risk_level, commit_component, churn_component,
+					recency_component, burst_component, ownership_component, bugfix_component, complexity_component,
+					coupling_component)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, scores)
+		})
+}
+
+// SQLiteCommitWriter exports commit analysis results into a SQLite database. Every
+// analyzed commit is exported regardless of --top.
+type SQLiteCommitWriter struct{}
+
+// Write appends a run with its history and commit scores to the database.
+func (w *SQLiteCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	return sqliteExport(options.OutputPath, "commits", report.RepoPath, report.Since, report.Until, report.GeneratedAt,
+		report.ChangeSets, func(tx *sql.Tx, runID int64) error {
+			rows := make([][]interface{}, 0, len(report.Items))
+			for i, item := range report.Items {
+				m := item.Metrics
+				b := item.Breakdown
+				var diffusion, size, entropy, breaking float64
+				if b != nil {
+					diffusion, size, entropy = b.DiffusionComponent, b.SizeComponent, b.EntropyComponent
+					breaking = b.BreakingComponent
+				}
+				rows = append(rows, []interface{}{runID, m.SHA, i + 1, item.RiskScore, string(item.RiskLevel),
+					m.FileCount, m.DirectoryCount, m.SubsystemCount, m.LinesAdded, m.LinesDeleted, m.ChangeEntropy,
+					nullableComponent(b != nil, diffusion), nullableComponent(b != nil, size), nullableComponent(b != nil, entropy),
+					nullableText(m.Type), nullableText(m.Scope), m.Breaking, nullableComponent(b != nil, breaking)})
+			}
+			return insertRows(tx, "commit_scores",
+				`INSERT INTO commit_scores (run_id, sha, rank, risk_score, risk_level, file_count, directory_count,
+					subsystem_count, lines_added, lines_deleted, change_entropy, diffusion_component, size_component,
+					entropy_component, commit_type, commit_scope, breaking, breaking_component)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, rows)
+		})
+}
+
+// SQLiteCouplingWriter exports coupling analysis results into a SQLite database.
+type SQLiteCouplingWriter struct{}
+
+// Write appends a run with its history and coupled pairs to the database. The pairs
+// are those in the report, i.e. already limited to the configured top pairs.
+func (w *SQLiteCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	return sqliteExport(options.OutputPath, "coupling", report.RepoPath, report.Since, report.Until, report.GeneratedAt,
+		report.ChangeSets, func(tx *sql.Tx, runID int64) error {
+			rows := make([][]interface{}, 0, len(report.Result.Couplings))
+			for _, c := range report.Result.Couplings {
+				rows = append(rows, []interface{}{runID, c.FileA, c.FileB, c.CoCommitCount, c.FileACommitCount,
+					c.FileBCommitCount, c.JaccardCoefficient, c.Confidence, c.Lift})
+			}
+			return insertRows(tx, "couplings",
+				`INSERT INTO couplings (run_id, file_a, file_b, co_commit_count, file_a_commit_count, file_b_commit_count,
+					jaccard, confidence, lift)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, rows)
+		})
+}
@@ -0,0 +1,200 @@
+package output
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/git"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func newTestChangeSets() []git.CommitChangeSet {
+	when := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	return []git.CommitChangeSet{
+		{
+			Commit: git.CommitInfo{SHA: "aaa111", When: when, Author: git.AuthorInfo{Name: "Alice", Email: "alice@example.com"}, Message: "fix: crash"},
+			Changes: []git.FileChange{
+				{Path: "src/hot.go", LinesAdded: 10, LinesDeleted: 2, Kind: git.ChangeKindModified},
+				{Path: "src/warm.go", OldPath: "src/old.go", Kind: git.ChangeKindRenamed},
+			},
+		},
+		{
+			Commit:  git.CommitInfo{SHA: "bbb222", When: when.Add(time.Hour), Author: git.AuthorInfo{Name: "Bob", Email: "bob@example.com"}, Message: "add cold"},
+			Changes: []git.FileChange{{Path: "src/cold.go", LinesAdded: 40, Kind: git.ChangeKindAdded}},
+		},
+	}
+}
+
+func openTestDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func queryInt(t *testing.T, db *sql.DB, query string, args ...interface{}) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	return n
+}
+
+func TestSQLiteFileWriter_Write(t *testing.T) {
+	report := newTestFileReport()
+	report.ChangeSets = newTestChangeSets()
+	report.Items[0].Breakdown = &scoring.ScoreBreakdown{CommitComponent: 0.3, BugfixComponent: 0.2}
+
+	path := t.TempDir() + "/bugspots.db"
+	// Top is ignored: the export always contains every analyzed file.
+	if err := (&SQLiteFileWriter{}).Write(report, OutputOptions{OutputPath: path, Top: 1}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	db := openTestDB(t, path)
+
+	counts := []struct {
+		table string
+		want  int
+	}{
+		{"runs", 1},
+		{"commits", 2},
+		{"file_changes", 3},
+		{"file_metrics", 3},
+		{"file_scores", 3},
+		{"commit_scores", 0},
+		{"couplings", 0},
+	}
+	for _, tt := range counts {
+		if got := queryInt(t, db, "SELECT COUNT(*) FROM "+tt.table); got != tt.want {
+			t.Errorf("%s rows = %d, want %d", tt.table, got, tt.want)
+		}
+	}
+
+	var command, repo, until string
+	var since sql.NullString
+	if err := db.QueryRow(`SELECT command, repo, since, until FROM runs`).Scan(&command, &repo, &since, &until); err != nil {
+		t.Fatalf("query runs: %v", err)
+	}
+	if command != "analyze" || repo != "/test/repo" || since.Valid || until != "2026-02-10" {
+		t.Errorf("run = %s %s %v %s", command, repo, since, until)
+	}
+
+	var level string
+	var rank int
+	var commitComponent sql.NullFloat64
+	if err := db.QueryRow(`SELECT rank, risk_level, commit_component FROM file_scores WHERE path = 'src/hot.go'`).
+		Scan(&rank, &level, &commitComponent); err != nil {
+		t.Fatalf("query file_scores: %v", err)
+	}
+	if rank != 1 || level != string(config.RiskLevelHigh) || !commitComponent.Valid || commitComponent.Float64 != 0.3 {
+		t.Errorf("hot.go score row = %d %s %v", rank, level, commitComponent)
+	}
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM file_scores WHERE commit_component IS NULL`); n != 2 {
+		t.Errorf("rows without breakdown = %d, want 2", n)
+	}
+
+	var oldPath sql.NullString
+	var kind string
+	if err := db.QueryRow(`SELECT old_path, kind FROM file_changes WHERE path = 'src/warm.go'`).Scan(&oldPath, &kind); err != nil {
+		t.Fatalf("query file_changes: %v", err)
+	}
+	if oldPath.String != "src/old.go" || kind != "renamed" {
+		t.Errorf("rename row = %v %s", oldPath, kind)
+	}
+}
+
+func TestSQLiteFileWriter_AppendsRuns(t *testing.T) {
+	path := t.TempDir() + "/bugspots.db"
+	for i := 0; i < 2; i++ {
+		if err := (&SQLiteFileWriter{}).Write(newTestFileReport(), OutputOptions{OutputPath: path}); err != nil {
+			t.Fatalf("Write %d failed: %v", i, err)
+		}
+	}
+	db := openTestDB(t, path)
+
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM runs`); n != 2 {
+		t.Errorf("runs = %d, want 2", n)
+	}
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM file_scores WHERE run_id = 2`); n != 3 {
+		t.Errorf("second run scores = %d, want 3", n)
+	}
+}
+
+func TestSQLiteFileWriter_RequiresOutputPath(t *testing.T) {
+	err := (&SQLiteFileWriter{}).Write(newTestFileReport(), OutputOptions{})
+	if !errors.Is(err, errSQLiteOutputPath) {
+		t.Errorf("err = %v, want errSQLiteOutputPath", err)
+	}
+}
+
+func TestSQLiteCommitWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &CommitAnalysisReport{
+		RepoPath:    "/test/repo",
+		Until:       now,
+		GeneratedAt: now,
+		ChangeSets:  newTestChangeSets(),
+		Items: []scoring.CommitRiskItem{
+			{Metrics: aggregation.CommitMetrics{SHA: "aaa111", FileCount: 2, LinesAdded: 10, Type: "fix", Breaking: true},
+				RiskScore: 0.75, RiskLevel: config.RiskLevelHigh,
+				Breakdown: &scoring.CommitRiskBreakdown{SizeComponent: 0.4}},
+			{Metrics: aggregation.CommitMetrics{SHA: "bbb222", FileCount: 1, LinesAdded: 40},
+				RiskScore: 0.1, RiskLevel: config.RiskLevelLow},
+		},
+	}
+
+	path := t.TempDir() + "/bugspots.db"
+	if err := (&SQLiteCommitWriter{}).Write(report, OutputOptions{OutputPath: path}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	db := openTestDB(t, path)
+
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM commit_scores`); n != 2 {
+		t.Fatalf("commit_scores rows = %d, want 2", n)
+	}
+	// Scores join to the exported history by run and SHA.
+	var author string
+	var size sql.NullFloat64
+	if err := db.QueryRow(`SELECT c.author_name, s.size_component FROM commit_scores s
+		JOIN commits c ON c.run_id = s.run_id AND c.sha = s.sha WHERE s.rank = 1`).Scan(&author, &size); err != nil {
+		t.Fatalf("join commits: %v", err)
+	}
+	if author != "Alice" || size.Float64 != 0.4 {
+		t.Errorf("joined row = %s %v", author, size)
+	}
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM commit_scores WHERE commit_type = 'fix' AND breaking = 1`); n != 1 {
+		t.Errorf("breaking fix commits = %d, want 1", n)
+	}
+}
+
+func TestSQLiteCouplingWriter_Write(t *testing.T) {
+	report := newTestCouplingReport()
+	path := t.TempDir() + "/bugspots.db"
+	if err := (&SQLiteCouplingWriter{}).Write(report, OutputOptions{OutputPath: path}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	db := openTestDB(t, path)
+
+	if n := queryInt(t, db, `SELECT COUNT(*) FROM couplings`); n != 2 {
+		t.Errorf("couplings rows = %d, want 2", n)
+	}
+	var lift float64
+	if err := db.QueryRow(`SELECT lift FROM couplings WHERE file_b = 'docs/a.md'`).Scan(&lift); err != nil {
+		t.Fatalf("query couplings: %v", err)
+	}
+	if lift != 4.0 {
+		t.Errorf("lift = %v, want 4", lift)
+	}
+	var command string
+	if err := db.QueryRow(`SELECT command FROM runs`).Scan(&command); err != nil || command != "coupling" {
+		t.Errorf("run command = %q (%v)", command, err)
+	}
+}
@@ -0,0 +1,148 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	svgTreemapWidth  = 1200.0
+	svgTreemapHeight = 800.0
+	svgHeaderHeight  = 36.0
+	svgLegendHeight  = 28.0
+	svgDirHeader     = 16.0
+	svgMaxDepth      = 4 // Deeper directories are drawn as a single tile colored by their highest score
+)
+
+// riskColorStops interpolate risk scores from green (0) through amber (0.5) to red (1).
+var riskColorStops = [3][3]float64{
+	{0x2d, 0xa4, 0x4e},
+	{0xd4, 0xa7, 0x2c},
+	{0xcf, 0x22, 0x2e},
+}
+
+// riskColorHex maps a risk score to a hex color on the green-amber-red scale.
+func riskColorHex(score float64) string {
+	s := math.Max(0, math.Min(1, score)) * 2
+	lo := int(math.Min(s, 1))
+	t := s - float64(lo)
+	var rgb [3]int
+	for i := range rgb {
+		rgb[i] = int(math.Round(riskColorStops[lo][i] + (riskColorStops[lo+1][i]-riskColorStops[lo][i])*t))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// svgEscape escapes text for use in SVG character data and attribute values.
+func svgEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// svgFitLabel shortens a label to fit the given width at an 11px font, or returns
+// "" when not even a few characters fit.
+func svgFitLabel(label string, width float64) string {
+	const charWidth = 6.5
+	maxChars := int((width - 6) / charWidth)
+	runes := []rune(label)
+	if len(runes) <= maxChars {
+		return label
+	}
+	if maxChars < 4 {
+		return ""
+	}
+	return string(runes[:maxChars-1]) + "…"
+}
+
+// SVGFileWriter writes file analysis reports as a static SVG treemap of hotspot
+// scores grouped by directory.
+type SVGFileWriter struct{}
+
+// Write outputs the file analysis report as an SVG treemap.
+func (w *SVGFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	items := limitTop(report.Items, options.Top)
+	root := buildTreemap(items)
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.0f %.0f\" font-family=\"Verdana,DejaVu Sans,sans-serif\" font-size=\"11\">\n",
+		svgTreemapWidth, svgTreemapHeight, svgTreemapWidth, svgTreemapHeight)
+	fmt.Fprintf(&b, "  <rect width=\"100%%\" height=\"100%%\" fill=\"#ffffff\"/>\n")
+
+	label, value := dateRangeLabelAndValue(report.Since, report.Until)
+	fmt.Fprintf(&b, "  <text x=\"8\" y=\"22\" font-size=\"15\" font-weight=\"bold\" fill=\"#1f2328\">Hotspots: %s</text>\n", svgEscape(report.RepoPath))
+	fmt.Fprintf(&b, "  <text x=\"%.0f\" y=\"22\" text-anchor=\"end\" fill=\"#656d76\">%s: %s · %d of %d files</text>\n",
+		svgTreemapWidth-8, label, value, len(items), len(report.Items))
+
+	area := treemapRect{X: 4, Y: svgHeaderHeight, W: svgTreemapWidth - 8, H: svgTreemapHeight - svgHeaderHeight - svgLegendHeight}
+	if len(items) == 0 {
+		fmt.Fprintf(&b, "  <text x=\"%.0f\" y=\"%.0f\" text-anchor=\"middle\" fill=\"#656d76\">No files to display</text>\n",
+			svgTreemapWidth/2, area.Y+area.H/2)
+	}
+	writeSVGTreemapLevel(&b, root.Children, area, 0)
+	writeSVGLegend(&b)
+	b.WriteString("</svg>\n")
+
+	_, err = io.WriteString(out, b.String())
+	return err
+}
+
+func writeSVGTreemapLevel(b *strings.Builder, nodes []*treemapNode, area treemapRect, depth int) {
+	indent := strings.Repeat("  ", depth+1)
+	for _, tile := range squarifyTreemap(nodes, area) {
+		n, r := tile.Node, tile.Rect
+		if n.IsFile {
+			fmt.Fprintf(b, "%s<g><title>%s&#10;risk %.4f</title><rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\" stroke=\"#ffffff\"/>",
+				indent, svgEscape(n.Path), n.Score, r.X, r.Y, r.W, r.H, riskColorHex(n.Score))
+			if text := svgFitLabel(n.Name, r.W); text != "" && r.H >= 14 {
+				fmt.Fprintf(b, "<text x=\"%.1f\" y=\"%.1f\" fill=\"#ffffff\">%s</text>", r.X+3, r.Y+12, svgEscape(text))
+			}
+			b.WriteString("</g>\n")
+			continue
+		}
+
+		inner := treemapRect{X: r.X + 2, Y: r.Y + svgDirHeader, W: r.W - 4, H: r.H - svgDirHeader - 2}
+		if depth+1 >= svgMaxDepth || inner.W < 8 || inner.H < 8 {
+			// Too small or too deep to show the files inside: draw the directory as one tile.
+			fmt.Fprintf(b, "%s<g><title>%s/&#10;max risk %.4f</title><rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"%s\" stroke=\"#ffffff\"/>",
+				indent, svgEscape(n.Path), n.Score, r.X, r.Y, r.W, r.H, riskColorHex(n.Score))
+			if text := svgFitLabel(n.Name+"/", r.W); text != "" && r.H >= 14 {
+				fmt.Fprintf(b, "<text x=\"%.1f\" y=\"%.1f\" fill=\"#ffffff\">%s</text>", r.X+3, r.Y+12, svgEscape(text))
+			}
+			b.WriteString("</g>\n")
+			continue
+		}
+
+		fmt.Fprintf(b, "%s<g><title>%s/&#10;max risk %.4f</title><rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%.1f\" fill=\"#57606a\" stroke=\"#ffffff\"/>",
+			indent, svgEscape(n.Path), n.Score, r.X, r.Y, r.W, r.H)
+		if text := svgFitLabel(n.Name+"/", r.W); text != "" {
+			fmt.Fprintf(b, "<text x=\"%.1f\" y=\"%.1f\" fill=\"#ffffff\" font-weight=\"bold\">%s</text>", r.X+3, r.Y+12, svgEscape(text))
+		}
+		b.WriteString("</g>\n")
+		writeSVGTreemapLevel(b, n.Children, inner, depth+1)
+	}
+}
+
+func writeSVGLegend(b *strings.Builder) {
+	y := svgTreemapHeight - svgLegendHeight + 8
+	b.WriteString("  <defs><linearGradient id=\"risk\">")
+	for i, stop := range []float64{0, 0.5, 1} {
+		fmt.Fprintf(b, "<stop offset=\"%d%%\" stop-color=\"%s\"/>", i*50, riskColorHex(stop))
+	}
+	b.WriteString("</linearGradient></defs>\n")
+	fmt.Fprintf(b, "  <text x=\"8\" y=\"%.0f\" fill=\"#656d76\">Area: lines of code (churn when not measured) · Color: risk score</text>\n", y+10)
+	fmt.Fprintf(b, "  <text x=\"%.0f\" y=\"%.0f\" text-anchor=\"end\" fill=\"#656d76\">0</text>\n", svgTreemapWidth-218, y+10)
+	fmt.Fprintf(b, "  <rect x=\"%.0f\" y=\"%.0f\" width=\"180\" height=\"12\" fill=\"url(#risk)\"/>\n", svgTreemapWidth-212, y)
+	fmt.Fprintf(b, "  <text x=\"%.0f\" y=\"%.0f\" fill=\"#656d76\">1</text>\n", svgTreemapWidth-26, y+10)
+}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func TestRiskColorHex(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{0, "#2da44e"},
+		{0.5, "#d4a72c"},
+		{1, "#cf222e"},
+		{-1, "#2da44e"},
+		{2, "#cf222e"},
+	}
+	for _, tt := range tests {
+		if got := riskColorHex(tt.score); got != tt.want {
+			t.Errorf("riskColorHex(%v) = %s, expected %s", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestSVGFitLabel(t *testing.T) {
+	tests := []struct {
+		label string
+		width float64
+		want  string
+	}{
+		{"main.go", 200, "main.go"},
+		{"a_very_long_file_name.go", 70, "a_very_l…"},
+		{"main.go", 20, ""},
+	}
+	for _, tt := range tests {
+		if got := svgFitLabel(tt.label, tt.width); got != tt.want {
+			t.Errorf("svgFitLabel(%q, %v) = %q, expected %q", tt.label, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestSVGFileWriter_Write(t *testing.T) {
+	report := newTestFileReport()
+	report.Items = append(report.Items, treemapItem("docs/<guide>.md", 40, 0.05))
+
+	tmpFile := t.TempDir() + "/treemap.svg"
+	if err := (&SVGFileWriter{}).Write(report, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if err := xml.Unmarshal(data, new(struct{})); err != nil {
+		t.Fatalf("output is not well-formed XML: %v", err)
+	}
+
+	svg := string(data)
+	if !strings.HasPrefix(svg, "<svg xmlns=\"http://www.w3.org/2000/svg\"") {
+		t.Error("expected an SVG root element")
+	}
+	for _, want := range []string{"src/hot.go", "src/cold.go", "docs/&lt;guide&gt;.md", riskColorHex(0.85)} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG does not contain %q", want)
+		}
+	}
+}
+
+func TestSVGFileWriter_EmptyReport(t *testing.T) {
+	tmpFile := t.TempDir() + "/treemap.svg"
+	if err := (&SVGFileWriter{}).Write(&FileAnalysisReport{Items: []scoring.FileRiskItem{}}, OutputOptions{OutputPath: tmpFile}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(tmpFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.Contains(string(data), "No files to display") {
+		t.Error("expected a placeholder message for an empty report")
+	}
+}
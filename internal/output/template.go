@@ -0,0 +1,222 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/coupling"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// errTemplatePath is returned when the template format is used without --template.
+var errTemplatePath = errors.New("template output requires --template <path>")
+
+// templateExecutor is satisfied by both text/template and html/template templates.
+type templateExecutor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// isHTMLTemplate reports whether a template path names an HTML document, ignoring a
+// trailing .tmpl or .gotmpl extension (report.html.tmpl is HTML).
+func isHTMLTemplate(templatePath string) bool {
+	name := strings.ToLower(filepath.Base(templatePath))
+	for _, suffix := range []string{".tmpl", ".gotmpl"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	ext := filepath.Ext(name)
+	return ext == ".html" || ext == ".htm"
+}
+
+// parseReportTemplate reads and parses a user template. HTML templates use
+// html/template so report data is escaped for the page; all others use text/template.
+func parseReportTemplate(templatePath string, thresholds config.RiskThresholds) (templateExecutor, error) {
+	if templatePath == "" {
+		return nil, errTemplatePath
+	}
+	data, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	name := filepath.Base(templatePath)
+	funcs := templateFuncs(thresholds)
+	var tmpl templateExecutor
+	if isHTMLTemplate(templatePath) {
+		tmpl, err = htmltemplate.New(name).Funcs(htmltemplate.FuncMap(funcs)).Parse(string(data))
+	} else {
+		tmpl, err = template.New(name).Funcs(funcs).Parse(string(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ValidateTemplate checks that the template at path can be read and parsed, so a
+// command can reject a broken template before analyzing history.
+func ValidateTemplate(templatePath string) error {
+	_, err := parseReportTemplate(templatePath, config.DefaultRiskThresholds())
+	return err
+}
+
+// renderTemplate executes the template into a buffer before writing, so a failing
+// template never leaves a partial output file behind. The riskLevel helper classifies
+// scores with thresholds.
+func renderTemplate(data interface{}, thresholds config.RiskThresholds, options OutputOptions) error {
+	tmpl, err := parseReportTemplate(options.TemplatePath, thresholds)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render template: %w", err)
+	}
+
+	out, file, err := openOutputWriter(options.OutputPath)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	_, err = buf.WriteTo(out)
+	return err
+}
+
+// templateFuncs returns the helper functions available to user templates.
+func templateFuncs(thresholds config.RiskThresholds) template.FuncMap {
+	return template.FuncMap{
+		"score": func(v float64) string {
+			return fmt.Sprintf("%.4f", v)
+		},
+		"percent": func(v float64) string {
+			return fmt.Sprintf("%.1f%%", v*100)
+		},
+		"riskLevel": func(v float64) string {
+			return string(thresholds.Classify(v))
+		},
+		"date":     func(v interface{}) string { return formatTemplateTime(v, reportDateLayout) },
+		"datetime": func(v interface{}) string { return formatTemplateTime(v, reportDateTimeLayout) },
+		"truncate": truncateRunes,
+		"shortSHA": func(sha string) string {
+			if len(sha) > 7 {
+				return sha[:7]
+			}
+			return sha
+		},
+		"add":   func(a, b int) int { return a + b },
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+		"base":  path.Base,
+		"dir":   path.Dir,
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+// formatTemplateTime formats a time.Time or *time.Time; a nil pointer or zero time
+// renders as the empty string.
+func formatTemplateTime(v interface{}, layout string) string {
+	switch t := v.(type) {
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil || t.IsZero() {
+			return ""
+		}
+		return t.Format(layout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// truncateRunes shortens s to at most n characters, ending with an ellipsis when cut.
+func truncateRunes(n int, s string) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n == 1 {
+		return "…"
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// FileTemplateData is the value passed to templates for file reports. Items is
+// limited to --top; Total counts every analyzed file.
+type FileTemplateData struct {
+	*FileAnalysisReport
+	Items []scoring.FileRiskItem
+	Total int
+}
+
+// CommitTemplateData is the value passed to templates for commit reports.
+type CommitTemplateData struct {
+	*CommitAnalysisReport
+	Items []scoring.CommitRiskItem
+	Total int
+}
+
+// CouplingTemplateData is the value passed to templates for coupling reports.
+// .Result.Couplings is already limited to the coupling topPairs setting, so Total
+// counts the reported pairs; .Result.TotalPairs counts every co-changed pair.
+type CouplingTemplateData struct {
+	*CouplingAnalysisReport
+	Couplings []coupling.ChangeCoupling // Reported pairs, limited to --top
+	Total     int                       // Number of reported pairs before --top
+}
+
+// TemplateFileWriter renders file analysis reports through a user template.
+type TemplateFileWriter struct{}
+
+// Write renders the report through options.TemplatePath.
+func (w *TemplateFileWriter) Write(report *FileAnalysisReport, options OutputOptions) error {
+	return renderTemplate(FileTemplateData{
+		FileAnalysisReport: report,
+		Items:              limitTop(report.Items, options.Top),
+		Total:              len(report.Items),
+	}, report.riskThresholds(), options)
+}
+
+// TemplateCommitWriter renders commit analysis reports through a user template.
+type TemplateCommitWriter struct{}
+
+// Write renders the report through options.TemplatePath.
+func (w *TemplateCommitWriter) Write(report *CommitAnalysisReport, options OutputOptions) error {
+	return renderTemplate(CommitTemplateData{
+		CommitAnalysisReport: report,
+		Items:                limitTop(report.Items, options.Top),
+		Total:                len(report.Items),
+	}, report.riskThresholds(), options)
+}
+
+// TemplateCouplingWriter renders coupling analysis reports through a user template.
+type TemplateCouplingWriter struct{}
+
+// Write renders the report through options.TemplatePath.
+func (w *TemplateCouplingWriter) Write(report *CouplingAnalysisReport, options OutputOptions) error {
+	return renderTemplate(CouplingTemplateData{
+		CouplingAnalysisReport: report,
+		Couplings:              limitTop(report.Result.Couplings, options.Top),
+		Total:                  len(report.Result.Couplings),
+	}, report.riskThresholds(), options)
+}
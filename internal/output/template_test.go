@@ -0,0 +1,155 @@
+package output
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func writeTestTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+	path := t.TempDir() + "/" + name
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	return path
+}
+
+func renderTestTemplate(t *testing.T, write func(OutputOptions) error, name, content string, top int) string {
+	t.Helper()
+	outPath := t.TempDir() + "/out"
+	options := OutputOptions{Format: FormatTemplate, TemplatePath: writeTestTemplate(t, name, content), OutputPath: outPath, Top: top}
+	if err := write(options); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, err := readTestFile(outPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	return string(data)
+}
+
+func TestTemplateFileWriter_Write(t *testing.T) {
+	report := newTestFileReport()
+	write := func(o OutputOptions) error { return (&TemplateFileWriter{}).Write(report, o) }
+
+	got := renderTestTemplate(t, write, "slack.tmpl",
+		`{{date .Until}} {{len .Items}}/{{.Total}}{{range $i, $f := .Items}}|{{add $i 1}} {{base $f.Path}} {{score $f.RiskScore}} {{riskLevel $f.RiskScore}}{{end}}`, 2)
+	want := "2026-02-10 2/3|1 hot.go 0.8500 high|2 warm.go 0.5000 medium"
+	if got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateCommitWriter_Write(t *testing.T) {
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	report := &CommitAnalysisReport{
+		RepoPath: "/test/repo",
+		Until:    now,
+		Items: []scoring.CommitRiskItem{
+			{Metrics: aggregation.CommitMetrics{SHA: "0123456789abcdef", Message: "<b>refactor</b> the storage layer"},
+				RiskScore: 0.75, RiskLevel: config.RiskLevelHigh},
+		},
+	}
+	write := func(o OutputOptions) error { return (&TemplateCommitWriter{}).Write(report, o) }
+
+	t.Run("Text", func(t *testing.T) {
+		got := renderTestTemplate(t, write, "commits.md",
+			`{{range .Items}}{{shortSHA .Metrics.SHA}} {{upper (print .RiskLevel)}} {{truncate 12 .Metrics.Message}}{{end}}`, 0)
+		if want := "0123456 HIGH <b>refactor…"; got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("HTMLEscapes", func(t *testing.T) {
+		got := renderTestTemplate(t, write, "commits.html.tmpl", `<p>{{(index .Items 0).Metrics.Message}}</p>`, 0)
+		if !strings.Contains(got, "&lt;b&gt;refactor&lt;/b&gt;") {
+			t.Errorf("expected escaped message in HTML template output, got %q", got)
+		}
+	})
+
+	t.Run("ConfiguredThresholds", func(t *testing.T) {
+		custom := *report
+		custom.Thresholds = config.RiskThresholds{High: 0.9, Medium: 0.8}
+		write := func(o OutputOptions) error { return (&TemplateCommitWriter{}).Write(&custom, o) }
+		got := renderTestTemplate(t, write, "commits.txt", `{{range .Items}}{{riskLevel .RiskScore}}{{end}}`, 0)
+		if want := "low"; got != want {
+			t.Errorf("output = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestTemplateCouplingWriter_Write(t *testing.T) {
+	report := newTestCouplingReport()
+	report.Result.TotalPairs = 7 // Co-changed pairs, including those below the reporting cut
+	write := func(o OutputOptions) error { return (&TemplateCouplingWriter{}).Write(report, o) }
+
+	got := renderTestTemplate(t, write, "pairs.txt",
+		`{{.Total}}/{{.Result.TotalPairs}}{{range .Couplings}} {{.FileA}}+{{.FileB}}={{percent .JaccardCoefficient}}{{end}} {{.Result.TotalCommits}}`, 1)
+	if want := "2/7 src/a.go+src/b.go=80.0% 10"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateWriter_Errors(t *testing.T) {
+	report := newTestFileReport()
+
+	t.Run("MissingPath", func(t *testing.T) {
+		err := (&TemplateFileWriter{}).Write(report, OutputOptions{Format: FormatTemplate})
+		if !errors.Is(err, errTemplatePath) {
+			t.Errorf("err = %v, want errTemplatePath", err)
+		}
+	})
+
+	t.Run("ParseError", func(t *testing.T) {
+		if err := ValidateTemplate(writeTestTemplate(t, "bad.tmpl", "{{.Items")); err == nil {
+			t.Error("expected parse error")
+		}
+	})
+
+	t.Run("ExecuteErrorLeavesNoFile", func(t *testing.T) {
+		outPath := t.TempDir() + "/out.txt"
+		options := OutputOptions{TemplatePath: writeTestTemplate(t, "bad.tmpl", "{{.NoSuchField}}"), OutputPath: outPath}
+		if err := (&TemplateFileWriter{}).Write(report, options); err == nil {
+			t.Fatal("expected execute error")
+		}
+		if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+			t.Errorf("output file should not exist after a failed render: %v", err)
+		}
+	})
+}
+
+func TestTemplateHelpers(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	var nilTime *time.Time
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"TruncateShort", truncateRunes(10, "short"), "short"},
+		{"TruncateRunes", truncateRunes(4, "日本語のパス"), "日本語…"},
+		{"TruncateZero", truncateRunes(0, "unchanged"), "unchanged"},
+		{"DateValue", formatTemplateTime(since, reportDateLayout), "2025-01-02"},
+		{"DatePointer", formatTemplateTime(&since, reportDateTimeLayout), "2025-01-02T03:04:05"},
+		{"DateNilPointer", formatTemplateTime(nilTime, reportDateLayout), ""},
+		{"HTMLSuffix", strconv.FormatBool(isHTMLTemplate("wiki/report.html.tmpl")), "true"},
+		{"HTMSuffix", strconv.FormatBool(isHTMLTemplate("page.HTM")), "true"},
+		{"TextSuffix", strconv.FormatBool(isHTMLTemplate("slack.md.tmpl")), "false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package output
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+// treemapNode is a directory or file in the hotspot treemap hierarchy.
+type treemapNode struct {
+	Name     string
+	Path     string
+	Size     float64 // File weight, or the sum of the children's weights for directories
+	Score    float64 // File risk score, or the highest risk score below a directory
+	Children []*treemapNode
+	IsFile   bool
+}
+
+// treemapRect is an axis-aligned rectangle in treemap coordinates.
+type treemapRect struct {
+	X, Y, W, H float64
+}
+
+// treemapTile is a node placed at a rectangle by the layout.
+type treemapTile struct {
+	Node *treemapNode
+	Rect treemapRect
+}
+
+// treemapWeight sizes a file by its line count, falling back to churn when the
+// line count was not measured.
+func treemapWeight(item scoring.FileRiskItem) float64 {
+	if item.Metrics.FileSize > 0 {
+		return float64(item.Metrics.FileSize)
+	}
+	if churn := item.Metrics.ChurnTotal(); churn > 0 {
+		return float64(churn)
+	}
+	return 1
+}
+
+// buildTreemap groups scored files into a directory tree. Children are sorted by
+// size descending (path ascending on ties) so the layout is deterministic.
+func buildTreemap(items []scoring.FileRiskItem) *treemapNode {
+	root := &treemapNode{}
+	dirs := map[string]*treemapNode{"": root}
+
+	for _, item := range items {
+		parts := strings.Split(item.Path, "/")
+		parent := root
+		for i := 0; i < len(parts)-1; i++ {
+			dirPath := strings.Join(parts[:i+1], "/")
+			dir, ok := dirs[dirPath]
+			if !ok {
+				dir = &treemapNode{Name: parts[i], Path: dirPath}
+				dirs[dirPath] = dir
+				parent.Children = append(parent.Children, dir)
+			}
+			parent = dir
+		}
+		parent.Children = append(parent.Children, &treemapNode{
+			Name:   parts[len(parts)-1],
+			Path:   item.Path,
+			Size:   treemapWeight(item),
+			Score:  item.RiskScore,
+			IsFile: true,
+		})
+	}
+
+	rollUpTreemap(root)
+	return root
+}
+
+func rollUpTreemap(node *treemapNode) {
+	if node.IsFile {
+		return
+	}
+	node.Size = 0
+	node.Score = 0
+	for _, child := range node.Children {
+		rollUpTreemap(child)
+		node.Size += child.Size
+		if child.Score > node.Score {
+			node.Score = child.Score
+		}
+	}
+	sort.Slice(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if a.Size != b.Size {
+			return a.Size > b.Size
+		}
+		return a.Path < b.Path
+	})
+}
+
+// squarifyTreemap lays out nodes (sorted by size, descending) inside rect using the
+// squarified treemap algorithm, which keeps tiles close to square.
+func squarifyTreemap(nodes []*treemapNode, rect treemapRect) []treemapTile {
+	var total float64
+	for _, n := range nodes {
+		total += n.Size
+	}
+	if total <= 0 || rect.W <= 0 || rect.H <= 0 {
+		return nil
+	}
+
+	scale := rect.W * rect.H / total
+	areas := make([]float64, len(nodes))
+	for i, n := range nodes {
+		areas[i] = n.Size * scale
+	}
+
+	tiles := make([]treemapTile, 0, len(nodes))
+	start := 0
+	for start < len(nodes) {
+		side := rect.W
+		if rect.H < side {
+			side = rect.H
+		}
+
+		// Grow the row while it improves the worst aspect ratio.
+		end := start + 1
+		best := worstAspectRatio(areas[start:end], side)
+		for end < len(nodes) {
+			worst := worstAspectRatio(areas[start:end+1], side)
+			if worst > best {
+				break
+			}
+			best = worst
+			end++
+		}
+
+		var rowArea float64
+		for _, a := range areas[start:end] {
+			rowArea += a
+		}
+		thickness := rowArea / side
+		offset := 0.0
+		for i := start; i < end; i++ {
+			length := areas[i] / thickness
+			var r treemapRect
+			if rect.W >= rect.H {
+				r = treemapRect{X: rect.X, Y: rect.Y + offset, W: thickness, H: length}
+			} else {
+				r = treemapRect{X: rect.X + offset, Y: rect.Y, W: length, H: thickness}
+			}
+			tiles = append(tiles, treemapTile{Node: nodes[i], Rect: r})
+			offset += length
+		}
+
+		if rect.W >= rect.H {
+			rect.X += thickness
+			rect.W -= thickness
+		} else {
+			rect.Y += thickness
+			rect.H -= thickness
+		}
+		start = end
+	}
+	return tiles
+}
+
+// worstAspectRatio returns the largest aspect ratio of a row of areas laid along side.
+func worstAspectRatio(areas []float64, side float64) float64 {
+	var sum, lo, hi float64
+	for i, a := range areas {
+		sum += a
+		if i == 0 || a < lo {
+			lo = a
+		}
+		if a > hi {
+			hi = a
+		}
+	}
+	if sum <= 0 || lo <= 0 {
+		return 0
+	}
+	s2 := sum * sum
+	side2 := side * side
+	return max(side2*hi/s2, s2/(side2*lo))
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"math"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+	"github.com/masmgr/bugspots-go/internal/scoring"
+)
+
+func treemapItem(path string, lines int, score float64) scoring.FileRiskItem {
+	return scoring.FileRiskItem{Path: path, RiskScore: score, Metrics: &aggregation.FileMetrics{FileSize: lines}}
+}
+
+func TestBuildTreemap(t *testing.T) {
+	root := buildTreemap([]scoring.FileRiskItem{
+		treemapItem("cmd/main.go", 100, 0.3),
+		treemapItem("internal/a/x.go", 300, 0.9),
+		treemapItem("internal/a/y.go", 100, 0.2),
+		treemapItem("README.md", 50, 0.1),
+	})
+
+	if root.Size != 550 || root.Score != 0.9 {
+		t.Errorf("root size/score = %v/%v, expected 550/0.9", root.Size, root.Score)
+	}
+	if len(root.Children) != 3 {
+		t.Fatalf("expected 3 top-level entries, got %d", len(root.Children))
+	}
+	internal := root.Children[0]
+	if internal.Path != "internal" || internal.IsFile || internal.Size != 400 {
+		t.Errorf("largest child = %+v, expected the internal directory", internal)
+	}
+	if a := internal.Children[0]; a.Path != "internal/a" || a.Score != 0.9 || len(a.Children) != 2 {
+		t.Errorf("internal/a = %+v", a)
+	}
+	if last := root.Children[2]; !last.IsFile || last.Path != "README.md" {
+		t.Errorf("smallest child = %+v, expected README.md", last)
+	}
+}
+
+func TestTreemapWeight_FallsBackToChurn(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics aggregation.FileMetrics
+		want    float64
+	}{
+		{"line count", aggregation.FileMetrics{FileSize: 120, AddedLines: 5}, 120},
+		{"churn", aggregation.FileMetrics{AddedLines: 30, DeletedLines: 10}, 40},
+		{"minimum", aggregation.FileMetrics{}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := treemapWeight(scoring.FileRiskItem{Metrics: &tt.metrics}); got != tt.want {
+				t.Errorf("treemapWeight() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSquarifyTreemap_AreasAndBounds(t *testing.T) {
+	nodes := []*treemapNode{{Size: 6}, {Size: 6}, {Size: 4}, {Size: 3}, {Size: 2}, {Size: 2}, {Size: 1}}
+	rect := treemapRect{X: 10, Y: 20, W: 600, H: 400}
+
+	tiles := squarifyTreemap(nodes, rect)
+	if len(tiles) != len(nodes) {
+		t.Fatalf("expected %d tiles, got %d", len(nodes), len(tiles))
+	}
+
+	const eps = 1e-6
+	var covered float64
+	for i, tile := range tiles {
+		r := tile.Rect
+		wantArea := nodes[i].Size / 24 * rect.W * rect.H
+		if math.Abs(r.W*r.H-wantArea) > 1e-3 {
+			t.Errorf("tile %d area = %v, expected %v", i, r.W*r.H, wantArea)
+		}
+		if r.X < rect.X-eps || r.Y < rect.Y-eps || r.X+r.W > rect.X+rect.W+eps || r.Y+r.H > rect.Y+rect.H+eps {
+			t.Errorf("tile %d = %+v lies outside %+v", i, r, rect)
+		}
+		covered += r.W * r.H
+	}
+	if math.Abs(covered-rect.W*rect.H) > 1e-3 {
+		t.Errorf("tiles cover %v, expected %v", covered, rect.W*rect.H)
+	}
+}
+
+func TestSquarifyTreemap_Empty(t *testing.T) {
+	if tiles := squarifyTreemap(nil, treemapRect{W: 10, H: 10}); len(tiles) != 0 {
+		t.Errorf("expected no tiles, got %d", len(tiles))
+	}
+	if tiles := squarifyTreemap([]*treemapNode{{Size: 1}}, treemapRect{}); len(tiles) != 0 {
+		t.Errorf("expected no tiles for an empty rectangle, got %d", len(tiles))
+	}
+}
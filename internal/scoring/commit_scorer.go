@@ -20,6 +20,7 @@ type CommitRiskBreakdown struct {
 	DiffusionComponent float64
 	SizeComponent      float64
 	EntropyComponent   float64
+	BreakingComponent  float64
 }
 
 // CommitNormalizationContext holds the min/max values needed for normalization.
@@ -126,8 +127,14 @@ func (s *CommitScorer) ScoreAndRank(
 		// Entropy is already normalized (0-1), just apply weight
 		entropyComponent := weights.Entropy * cm.ChangeEntropy
 
+		// Breaking changes of Conventional Commits add their full weight
+		breakingComponent := 0.0
+		if cm.Breaking {
+			breakingComponent = weights.Breaking
+		}
+
 		// Calculate total score
-		totalScore := diffusionComponent + sizeComponent + entropyComponent
+		totalScore := diffusionComponent + sizeComponent + entropyComponent + breakingComponent
 
 		// Clamp to [0, 1]
 		totalScore = Clamp(totalScore)
@@ -140,6 +147,7 @@ func (s *CommitScorer) ScoreAndRank(
 				DiffusionComponent: diffusionComponent,
 				SizeComponent:      sizeComponent,
 				EntropyComponent:   entropyComponent,
+				BreakingComponent:  breakingComponent,
 			}
 		}
 
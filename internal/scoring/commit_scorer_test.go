@@ -134,6 +134,29 @@ func TestCommitScorer_ScoreAndRank_ExplainBreakdown(t *testing.T) {
 	}
 }
 
+func TestCommitScorer_ScoreAndRank_Breaking(t *testing.T) {
+	options := config.DefaultConfig().CommitScoring
+	options.Weights.Breaking = 0.2
+	scorer := NewCommitScorer(options)
+
+	metrics := []aggregation.CommitMetrics{
+		{SHA: "plain", FileCount: 2, DirectoryCount: 1, SubsystemCount: 1, LinesAdded: 10},
+		{SHA: "breaking", FileCount: 2, DirectoryCount: 1, SubsystemCount: 1, LinesAdded: 10, Breaking: true},
+	}
+
+	items := scorer.ScoreAndRank(metrics, true)
+	if items[0].Metrics.SHA != "breaking" {
+		t.Fatalf("Expected 'breaking' commit first, got %q", items[0].Metrics.SHA)
+	}
+	if items[0].Breakdown.BreakingComponent != 0.2 || items[1].Breakdown.BreakingComponent != 0 {
+		t.Errorf("BreakingComponent = %f and %f, expected 0.2 and 0",
+			items[0].Breakdown.BreakingComponent, items[1].Breakdown.BreakingComponent)
+	}
+	if diff := items[0].RiskScore - items[1].RiskScore; diff < 0.2-1e-9 || diff > 0.2+1e-9 {
+		t.Errorf("Breaking commit scores %f higher, expected 0.2", diff)
+	}
+}
+
 func TestCommitScorer_ScoreAndRank_ScoreBounded(t *testing.T) {
 	scorer := NewCommitScorer(config.DefaultConfig().CommitScoring)
 
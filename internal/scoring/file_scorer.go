@@ -12,6 +12,7 @@ import (
 type FileRiskItem struct {
 	Path      string
 	RiskScore float64
+	RiskLevel config.RiskLevel
 	Metrics   *aggregation.FileMetrics
 	Breakdown *ScoreBreakdown
 }
@@ -25,6 +26,12 @@ type ScoreBreakdown struct {
 	OwnershipComponent  float64
 	BugfixComponent     float64
 	ComplexityComponent float64
+	CouplingComponent   float64
+
+	// Set when path overrides match the file
+	Overrides   []string         // Patterns of the matching overrides, in config order
+	ScoreFactor float64          // Product of their score factors, applied to the component sum
+	FixedLevel  config.RiskLevel // Risk level set by an override instead of the thresholds
 }
 
 // NormalizationContext holds the min/max values needed for normalization.
@@ -33,6 +40,7 @@ type NormalizationContext struct {
 	ChurnTotal  MinMax
 	BugfixCount MinMax
 	FileSize    MinMax
+	Coupling    MinMax
 }
 
 // FromMetrics computes the normalization context from file metrics.
@@ -42,6 +50,7 @@ func FromMetrics(metrics map[string]*aggregation.FileMetrics) NormalizationConte
 		ChurnTotal:  MinMax{Min: 0, Max: 0},
 		BugfixCount: MinMax{Min: 0, Max: 0},
 		FileSize:    MinMax{Min: 0, Max: 0},
+		Coupling:    MinMax{Min: 0, Max: 0},
 	}
 
 	first := true
@@ -50,12 +59,14 @@ func FromMetrics(metrics map[string]*aggregation.FileMetrics) NormalizationConte
 		churnTotal := float64(fm.ChurnTotal())
 		bugfixCount := float64(fm.BugfixCount)
 		fileSize := float64(fm.FileSize)
+		coupling := fm.CouplingDegree
 
 		if first {
 			ctx.CommitCount = MinMax{Min: commitCount, Max: commitCount}
 			ctx.ChurnTotal = MinMax{Min: churnTotal, Max: churnTotal}
 			ctx.BugfixCount = MinMax{Min: bugfixCount, Max: bugfixCount}
 			ctx.FileSize = MinMax{Min: fileSize, Max: fileSize}
+			ctx.Coupling = MinMax{Min: coupling, Max: coupling}
 			first = false
 			continue
 		}
@@ -84,6 +95,12 @@ func FromMetrics(metrics map[string]*aggregation.FileMetrics) NormalizationConte
 		if fileSize > ctx.FileSize.Max {
 			ctx.FileSize.Max = fileSize
 		}
+		if coupling < ctx.Coupling.Min {
+			ctx.Coupling.Min = coupling
+		}
+		if coupling > ctx.Coupling.Max {
+			ctx.Coupling.Max = coupling
+		}
 	}
 
 	return ctx
@@ -91,12 +108,60 @@ func FromMetrics(metrics map[string]*aggregation.FileMetrics) NormalizationConte
 
 // FileScorer calculates risk scores for files based on their metrics.
 type FileScorer struct {
-	options config.ScoringConfig
+	options    config.ScoringConfig
+	thresholds config.RiskThresholds
+	overrides  []config.PathOverride
 }
 
-// NewFileScorer creates a new file scorer with the given options.
+// NewFileScorer creates a new file scorer with the given options. Items are classified
+// with the default risk thresholds unless WithThresholds is used.
 func NewFileScorer(options config.ScoringConfig) *FileScorer {
-	return &FileScorer{options: options}
+	return &FileScorer{options: options, thresholds: config.DefaultRiskThresholds()}
+}
+
+// WithThresholds sets the thresholds used to assign each item's risk level.
+func (s *FileScorer) WithThresholds(thresholds config.RiskThresholds) *FileScorer {
+	s.thresholds = thresholds
+	return s
+}
+
+// WithOverrides sets the path overrides that change weights, half-life, score or risk
+// level for matching files.
+func (s *FileScorer) WithOverrides(overrides []config.PathOverride) *FileScorer {
+	s.overrides = overrides
+	return s
+}
+
+// fileOptions are the scoring options for one file after its path overrides.
+type fileOptions struct {
+	weights      config.WeightConfig
+	halfLifeDays int
+	scoreFactor  float64
+	riskLevel    config.RiskLevel
+	overrides    []string
+}
+
+func (s *FileScorer) optionsFor(path string) fileOptions {
+	opts := fileOptions{weights: s.options.Weights, halfLifeDays: s.options.HalfLifeDays, scoreFactor: 1}
+	for _, o := range s.overrides {
+		if !o.Matches(path) {
+			continue
+		}
+		opts.overrides = append(opts.overrides, o.Path)
+		if o.Weights != nil {
+			opts.weights = o.Weights.Apply(opts.weights)
+		}
+		if o.HalfLifeDays != nil {
+			opts.halfLifeDays = *o.HalfLifeDays
+		}
+		if o.ScoreFactor != nil {
+			opts.scoreFactor *= *o.ScoreFactor
+		}
+		if o.RiskLevel != "" {
+			opts.riskLevel = o.RiskLevel
+		}
+	}
+	return opts
 }
 
 // ScoreAndRank scores all files and returns them sorted by risk score (descending).
@@ -110,12 +175,13 @@ func (s *FileScorer) ScoreAndRank(
 	}
 
 	ctx := FromMetrics(metrics)
-	weights := s.options.Weights
-	halfLifeDays := s.options.HalfLifeDays
 
 	items := make([]FileRiskItem, 0, len(metrics))
 
 	for path, fm := range metrics {
+		opts := s.optionsFor(path)
+		weights := opts.weights
+
 		// Calculate commit frequency component
 		commitComponent := weights.Commit * NormLog(float64(fm.CommitCount), ctx.CommitCount)
 
@@ -124,7 +190,7 @@ func (s *FileScorer) ScoreAndRank(
 
 		// Calculate recency component
 		daysSinceModified := until.Sub(fm.LastModifiedAt).Hours() / 24
-		recencyComponent := weights.Recency * RecencyDecay(daysSinceModified, halfLifeDays)
+		recencyComponent := weights.Recency * RecencyDecay(daysSinceModified, opts.halfLifeDays)
 
 		// Calculate burst component
 		burstComponent := weights.Burst * fm.BurstScore
@@ -140,10 +206,19 @@ func (s *FileScorer) ScoreAndRank(
 		// Calculate complexity component (file size in lines)
 		complexityComponent := weights.Complexity * NormLog(float64(fm.FileSize), ctx.FileSize)
 
+		// Calculate coupling component (sum of co-change strengths with other files)
+		couplingComponent := weights.Coupling * NormLog(fm.CouplingDegree, ctx.Coupling)
+
 		// Calculate total score
 		totalScore := commitComponent + churnComponent + recencyComponent +
-			burstComponent + ownershipComponent + bugfixComponent + complexityComponent
-		totalScore = Clamp(totalScore)
+			burstComponent + ownershipComponent + bugfixComponent + complexityComponent +
+			couplingComponent
+		totalScore = Clamp(Clamp(totalScore) * opts.scoreFactor)
+
+		riskLevel := opts.riskLevel
+		if riskLevel == "" {
+			riskLevel = s.thresholds.Classify(totalScore)
+		}
 
 		var breakdown *ScoreBreakdown
 		if explain {
@@ -155,12 +230,17 @@ func (s *FileScorer) ScoreAndRank(
 				OwnershipComponent:  ownershipComponent,
 				BugfixComponent:     bugfixComponent,
 				ComplexityComponent: complexityComponent,
+				CouplingComponent:   couplingComponent,
+				Overrides:           opts.overrides,
+				ScoreFactor:         opts.scoreFactor,
+				FixedLevel:          opts.riskLevel,
 			}
 		}
 
 		items = append(items, FileRiskItem{
 			Path:      path,
 			RiskScore: totalScore,
+			RiskLevel: riskLevel,
 			Metrics:   fm,
 			Breakdown: breakdown,
 		})
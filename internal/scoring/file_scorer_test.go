@@ -1,6 +1,7 @@
 package scoring
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -92,6 +93,25 @@ func TestFileScorer_ScoreAndRank_Ordering(t *testing.T) {
 	}
 }
 
+func TestFileScorer_ScoreAndRank_RiskLevel(t *testing.T) {
+	now := time.Now()
+	metrics := map[string]*aggregation.FileMetrics{
+		"a.go": {CommitCount: 5, AddedLines: 50, LastModifiedAt: now, ContributorCommitCounts: map[string]int{"a": 5}},
+	}
+
+	items := NewFileScorer(config.DefaultConfig().Scoring).ScoreAndRank(metrics, false, now)
+	if want := config.DefaultRiskThresholds().Classify(items[0].RiskScore); items[0].RiskLevel != want {
+		t.Errorf("default RiskLevel = %q, want %q", items[0].RiskLevel, want)
+	}
+
+	// Thresholds below any score classify every file as high risk.
+	strict := config.RiskThresholds{High: 0, Medium: 0}
+	items = NewFileScorer(config.DefaultConfig().Scoring).WithThresholds(strict).ScoreAndRank(metrics, false, now)
+	if items[0].RiskLevel != config.RiskLevelHigh {
+		t.Errorf("RiskLevel with zero thresholds = %q, want high", items[0].RiskLevel)
+	}
+}
+
 func TestFileScorer_ScoreAndRank_ExplainBreakdown(t *testing.T) {
 	scorer := NewFileScorer(config.DefaultConfig().Scoring)
 	now := time.Now()
@@ -242,3 +262,109 @@ func TestFileScorer_ScoreAndRank_RecencyEffect(t *testing.T) {
 		t.Errorf("Recent file score %f should be > old file score %f", recentScore, oldScore)
 	}
 }
+
+func TestFileScorer_ScoreAndRank_CouplingEffect(t *testing.T) {
+	now := time.Now()
+	newMetrics := func(degree float64) *aggregation.FileMetrics {
+		return &aggregation.FileMetrics{
+			CommitCount:             5,
+			AddedLines:              50,
+			DeletedLines:            20,
+			LastModifiedAt:          now.Add(-7 * 24 * time.Hour),
+			Contributors:            map[string]struct{}{"a": {}},
+			ContributorCommitCounts: map[string]int{"a": 5},
+			CouplingDegree:          degree,
+		}
+	}
+	metrics := map[string]*aggregation.FileMetrics{
+		"hub.go":    newMetrics(2.5),
+		"island.go": newMetrics(0),
+	}
+
+	// Default weights leave the coupling factor disabled
+	items := NewFileScorer(config.DefaultConfig().Scoring).ScoreAndRank(metrics, true, now)
+	for _, item := range items {
+		if item.Breakdown.CouplingComponent != 0 {
+			t.Errorf("%s CouplingComponent = %f, expected 0 with default weights", item.Path, item.Breakdown.CouplingComponent)
+		}
+	}
+
+	cfg := config.DefaultConfig().Scoring
+	cfg.Weights.Coupling = 0.1
+	items = NewFileScorer(cfg).ScoreAndRank(metrics, true, now)
+
+	if items[0].Path != "hub.go" {
+		t.Fatalf("expected hub.go first, got %s", items[0].Path)
+	}
+	if items[0].Breakdown.CouplingComponent != 0.1 {
+		t.Errorf("hub.go CouplingComponent = %f, expected 0.1", items[0].Breakdown.CouplingComponent)
+	}
+	if items[1].Breakdown.CouplingComponent != 0 {
+		t.Errorf("island.go CouplingComponent = %f, expected 0", items[1].Breakdown.CouplingComponent)
+	}
+}
+
+func TestFileScorer_ScoreAndRank_Overrides(t *testing.T) {
+	now := time.Now()
+	newMetrics := func() *aggregation.FileMetrics {
+		return &aggregation.FileMetrics{
+			CommitCount:             5,
+			AddedLines:              50,
+			DeletedLines:            20,
+			LastModifiedAt:          now.Add(-30 * 24 * time.Hour),
+			Contributors:            map[string]struct{}{"a": {}},
+			ContributorCommitCounts: map[string]int{"a": 5},
+			BurstScore:              0.5,
+		}
+	}
+	metrics := map[string]*aggregation.FileMetrics{
+		"src/app.go":        newMetrics(),
+		"src/legacy/old.go": newMetrics(),
+		"vendor/lib.go":     newMetrics(),
+	}
+	half, double := 0.5, 2.0
+	zero, halfLife := 0.0, 90
+	overrides := []config.PathOverride{
+		{Path: "vendor/**", ScoreFactor: &half, RiskLevel: config.RiskLevelLow},
+		{Path: "src/legacy/**", ScoreFactor: &double, HalfLifeDays: &halfLife},
+		{Path: "src/legacy/**", ScoreFactor: &half, Weights: &config.WeightOverrides{Burst: &zero}},
+	}
+
+	cfg := config.DefaultConfig().Scoring
+	base := map[string]FileRiskItem{}
+	for _, item := range NewFileScorer(cfg).ScoreAndRank(metrics, true, now) {
+		base[item.Path] = item
+	}
+	got := map[string]FileRiskItem{}
+	for _, item := range NewFileScorer(cfg).WithOverrides(overrides).ScoreAndRank(metrics, true, now) {
+		got[item.Path] = item
+	}
+
+	if app := got["src/app.go"]; app.RiskScore != base["src/app.go"].RiskScore || len(app.Breakdown.Overrides) != 0 {
+		t.Errorf("src/app.go = %f with overrides %v, expected it unchanged", app.RiskScore, app.Breakdown.Overrides)
+	}
+
+	vendor := got["vendor/lib.go"]
+	if want := base["vendor/lib.go"].RiskScore * 0.5; math.Abs(vendor.RiskScore-want) > 1e-9 {
+		t.Errorf("vendor/lib.go RiskScore = %f, expected %f", vendor.RiskScore, want)
+	}
+	if vendor.RiskLevel != config.RiskLevelLow || vendor.Breakdown.FixedLevel != config.RiskLevelLow {
+		t.Errorf("vendor/lib.go RiskLevel = %q, expected the fixed low level", vendor.RiskLevel)
+	}
+
+	// Matching overrides apply in order: factors multiply, later values win
+	legacy := got["src/legacy/old.go"]
+	if legacy.Breakdown.ScoreFactor != 1 {
+		t.Errorf("src/legacy/old.go ScoreFactor = %f, expected 2 * 0.5", legacy.Breakdown.ScoreFactor)
+	}
+	if want := []string{"src/legacy/**", "src/legacy/**"}; len(legacy.Breakdown.Overrides) != len(want) {
+		t.Errorf("src/legacy/old.go Overrides = %v, expected %v", legacy.Breakdown.Overrides, want)
+	}
+	if legacy.Breakdown.BurstComponent != 0 {
+		t.Errorf("src/legacy/old.go BurstComponent = %f, expected 0 from the weight override", legacy.Breakdown.BurstComponent)
+	}
+	if legacy.Breakdown.RecencyComponent <= base["src/legacy/old.go"].Breakdown.RecencyComponent {
+		t.Errorf("src/legacy/old.go RecencyComponent = %f, expected more than %f with a longer half-life",
+			legacy.Breakdown.RecencyComponent, base["src/legacy/old.go"].Breakdown.RecencyComponent)
+	}
+}
@@ -0,0 +1,72 @@
+package scoring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+// FunctionRiskItem represents a Go function with its calculated risk score.
+type FunctionRiskItem struct {
+	Metrics   *aggregation.FunctionMetrics
+	RiskScore float64
+	RiskLevel config.RiskLevel
+	Breakdown *RegionScoreBreakdown
+}
+
+// FunctionScorer calculates risk scores for Go functions with the region factors:
+// log-normalized commit, churn and bugfix counts and recency decay.
+type FunctionScorer struct {
+	regionScorer
+}
+
+// NewFunctionScorer creates a new function scorer. Recency decays with halfLifeDays.
+func NewFunctionScorer(options config.FunctionScoringConfig, halfLifeDays int) *FunctionScorer {
+	return &FunctionScorer{regionScorer{weights: options.Weights, thresholds: options.Thresholds, halfLifeDays: halfLifeDays}}
+}
+
+// ScoreAndRank scores all functions and returns them sorted by risk score
+// (descending), then by path and name.
+func (s *FunctionScorer) ScoreAndRank(
+	metrics []*aggregation.FunctionMetrics,
+	explain bool,
+	until time.Time,
+) []FunctionRiskItem {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	histories := make([]RegionHistory, len(metrics))
+	for i, fm := range metrics {
+		histories[i] = RegionHistory{
+			CommitCount:    fm.CommitCount,
+			ChurnTotal:     fm.ChurnTotal(),
+			BugfixCount:    fm.BugfixCount,
+			LastModifiedAt: fm.LastModifiedAt,
+		}
+	}
+
+	items := make([]FunctionRiskItem, 0, len(metrics))
+	for i, score := range s.score(histories, explain, until) {
+		items = append(items, FunctionRiskItem{
+			Metrics:   metrics[i],
+			RiskScore: score.riskScore,
+			RiskLevel: score.riskLevel,
+			Breakdown: score.breakdown,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].RiskScore != items[j].RiskScore {
+			return items[i].RiskScore > items[j].RiskScore
+		}
+		if items[i].Metrics.Path != items[j].Metrics.Path {
+			return items[i].Metrics.Path < items[j].Metrics.Path
+		}
+		return items[i].Metrics.Name < items[j].Metrics.Name
+	})
+
+	return items
+}
@@ -0,0 +1,50 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+func TestFunctionScorer_ScoreAndRank(t *testing.T) {
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	metrics := []*aggregation.FunctionMetrics{
+		{Path: "a.go", Name: "quiet", CommitCount: 1, AddedLines: 2, LastModifiedAt: until.AddDate(-1, 0, 0)},
+		{Path: "a.go", Name: "hot", CommitCount: 12, AddedLines: 300, DeletedLines: 120, BugfixCount: 5, LastModifiedAt: until},
+		{Path: "b.go", Name: "warm", CommitCount: 4, AddedLines: 30, DeletedLines: 10, BugfixCount: 1, LastModifiedAt: until.AddDate(0, 0, -30)},
+	}
+
+	scorer := NewFunctionScorer(config.DefaultConfig().FunctionScoring, 30)
+	items := scorer.ScoreAndRank(metrics, true, until)
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(items))
+	}
+	for i, name := range []string{"hot", "warm", "quiet"} {
+		if items[i].Metrics.Name != name {
+			t.Errorf("items[%d] = %s, expected %s", i, items[i].Metrics.Name, name)
+		}
+	}
+	if items[0].RiskScore != 1 || items[0].RiskLevel != config.RiskLevelHigh {
+		t.Errorf("hot = %f %s, expected 1 and high", items[0].RiskScore, items[0].RiskLevel)
+	}
+
+	b := items[1].Breakdown
+	sum := b.CommitComponent + b.ChurnComponent + b.BugfixComponent + b.RecencyComponent
+	if math.Abs(sum-items[1].RiskScore) > 1e-9 {
+		t.Errorf("Breakdown sums to %f, expected the score %f", sum, items[1].RiskScore)
+	}
+	if math.Abs(b.RecencyComponent-0.15*0.5) > 1e-9 {
+		t.Errorf("RecencyComponent = %f, expected half the recency weight after one half-life", b.RecencyComponent)
+	}
+}
+
+func TestFunctionScorer_ScoreAndRank_Empty(t *testing.T) {
+	scorer := NewFunctionScorer(config.DefaultConfig().FunctionScoring, 30)
+	if items := scorer.ScoreAndRank(nil, false, time.Now()); items != nil {
+		t.Errorf("ScoreAndRank(nil) = %v, expected nil", items)
+	}
+}
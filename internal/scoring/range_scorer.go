@@ -0,0 +1,72 @@
+package scoring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+// RangeRiskItem represents a hot line range with its calculated risk score.
+type RangeRiskItem struct {
+	Metrics   *aggregation.RangeMetrics
+	RiskScore float64
+	RiskLevel config.RiskLevel
+	Breakdown *RegionScoreBreakdown
+}
+
+// RangeScorer calculates risk scores for line ranges with the factors of
+// FunctionScorer.
+type RangeScorer struct {
+	regionScorer
+}
+
+// NewRangeScorer creates a new line range scorer. Recency decays with halfLifeDays.
+func NewRangeScorer(options config.RangeScoringConfig, halfLifeDays int) *RangeScorer {
+	return &RangeScorer{regionScorer{weights: options.Weights, thresholds: options.Thresholds, halfLifeDays: halfLifeDays}}
+}
+
+// ScoreAndRank scores all ranges and returns them sorted by risk score (descending),
+// then by path and start line.
+func (s *RangeScorer) ScoreAndRank(
+	metrics []*aggregation.RangeMetrics,
+	explain bool,
+	until time.Time,
+) []RangeRiskItem {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	histories := make([]RegionHistory, len(metrics))
+	for i, rm := range metrics {
+		histories[i] = RegionHistory{
+			CommitCount:    rm.CommitCount,
+			ChurnTotal:     rm.ChurnTotal(),
+			BugfixCount:    rm.BugfixCount,
+			LastModifiedAt: rm.LastModifiedAt,
+		}
+	}
+
+	items := make([]RangeRiskItem, 0, len(metrics))
+	for i, score := range s.score(histories, explain, until) {
+		items = append(items, RangeRiskItem{
+			Metrics:   metrics[i],
+			RiskScore: score.riskScore,
+			RiskLevel: score.riskLevel,
+			Breakdown: score.breakdown,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].RiskScore != items[j].RiskScore {
+			return items[i].RiskScore > items[j].RiskScore
+		}
+		if items[i].Metrics.Path != items[j].Metrics.Path {
+			return items[i].Metrics.Path < items[j].Metrics.Path
+		}
+		return items[i].Metrics.StartLine < items[j].Metrics.StartLine
+	})
+
+	return items
+}
@@ -0,0 +1,52 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+func TestRangeScorer_ScoreAndRank(t *testing.T) {
+	until := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	metrics := []*aggregation.RangeMetrics{
+		{Path: "parser.c", StartLine: 10, EndLine: 50, CommitCount: 2, AddedLines: 4, LastModifiedAt: until.AddDate(-1, 0, 0)},
+		{Path: "parser.c", StartLine: 120, EndLine: 160, CommitCount: 15, AddedLines: 200, DeletedLines: 90,
+			BugfixCount: 12, LastModifiedAt: until},
+		{Path: "lexer.c", StartLine: 5, EndLine: 9, CommitCount: 4, AddedLines: 20, DeletedLines: 8,
+			BugfixCount: 1, LastModifiedAt: until.AddDate(0, 0, -30)},
+	}
+
+	scorer := NewRangeScorer(config.DefaultConfig().RangeScoring, 30)
+	items := scorer.ScoreAndRank(metrics, true, until)
+
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(items))
+	}
+	for i, start := range []int{120, 5, 10} {
+		if items[i].Metrics.StartLine != start {
+			t.Errorf("items[%d] starts at line %d, expected %d", i, items[i].Metrics.StartLine, start)
+		}
+	}
+	if items[0].RiskScore != 1 || items[0].RiskLevel != config.RiskLevelHigh {
+		t.Errorf("hottest range = %f %s, expected 1 and high", items[0].RiskScore, items[0].RiskLevel)
+	}
+
+	b := items[1].Breakdown
+	sum := b.CommitComponent + b.ChurnComponent + b.BugfixComponent + b.RecencyComponent
+	if math.Abs(sum-items[1].RiskScore) > 1e-9 {
+		t.Errorf("Breakdown sums to %f, expected the score %f", sum, items[1].RiskScore)
+	}
+	if items[2].Breakdown.BugfixComponent != 0 {
+		t.Errorf("BugfixComponent = %f, expected 0 without bugfixes", items[2].Breakdown.BugfixComponent)
+	}
+}
+
+func TestRangeScorer_ScoreAndRank_Empty(t *testing.T) {
+	scorer := NewRangeScorer(config.DefaultConfig().RangeScoring, 30)
+	if items := scorer.ScoreAndRank(nil, false, time.Now()); items != nil {
+		t.Errorf("ScoreAndRank(nil) = %v, expected nil", items)
+	}
+}
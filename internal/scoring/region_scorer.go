@@ -0,0 +1,108 @@
+package scoring
+
+import (
+	"time"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// RegionHistory is the change history of a block of lines inside a file, such as a
+// Go function or a hot line range.
+type RegionHistory struct {
+	CommitCount    int
+	ChurnTotal     int
+	BugfixCount    int
+	LastModifiedAt time.Time
+}
+
+// RegionScoreBreakdown shows the contribution of each component to the total score.
+type RegionScoreBreakdown struct {
+	CommitComponent  float64
+	ChurnComponent   float64
+	BugfixComponent  float64
+	RecencyComponent float64
+}
+
+// RegionNormalizationContext holds the min/max values needed for normalization.
+type RegionNormalizationContext struct {
+	CommitCount MinMax
+	ChurnTotal  MinMax
+	BugfixCount MinMax
+}
+
+// RegionContextFromHistories computes the normalization context from region histories.
+func RegionContextFromHistories(histories []RegionHistory) RegionNormalizationContext {
+	ctx := RegionNormalizationContext{}
+	for i, h := range histories {
+		commitCount := float64(h.CommitCount)
+		churnTotal := float64(h.ChurnTotal)
+		bugfixCount := float64(h.BugfixCount)
+
+		if i == 0 {
+			ctx.CommitCount = MinMax{Min: commitCount, Max: commitCount}
+			ctx.ChurnTotal = MinMax{Min: churnTotal, Max: churnTotal}
+			ctx.BugfixCount = MinMax{Min: bugfixCount, Max: bugfixCount}
+			continue
+		}
+
+		ctx.CommitCount.Min = min(ctx.CommitCount.Min, commitCount)
+		ctx.CommitCount.Max = max(ctx.CommitCount.Max, commitCount)
+		ctx.ChurnTotal.Min = min(ctx.ChurnTotal.Min, churnTotal)
+		ctx.ChurnTotal.Max = max(ctx.ChurnTotal.Max, churnTotal)
+		ctx.BugfixCount.Min = min(ctx.BugfixCount.Min, bugfixCount)
+		ctx.BugfixCount.Max = max(ctx.BugfixCount.Max, bugfixCount)
+	}
+	return ctx
+}
+
+// regionScorer scores region histories with the normalization of FileScorer:
+// log-normalized commit, churn and bugfix counts and recency decay. FunctionScorer
+// and RangeScorer wrap it with their own items and ordering.
+type regionScorer struct {
+	weights      config.RegionWeightConfig
+	thresholds   config.RiskThresholds
+	halfLifeDays int
+}
+
+// regionScore is the score of one region history.
+type regionScore struct {
+	riskScore float64
+	riskLevel config.RiskLevel
+	breakdown *RegionScoreBreakdown
+}
+
+// score returns the score of each history, in order. Scores are normalized across
+// all of histories.
+func (s regionScorer) score(histories []RegionHistory, explain bool, until time.Time) []regionScore {
+	ctx := RegionContextFromHistories(histories)
+	weights := s.weights
+
+	scores := make([]regionScore, len(histories))
+	for i, h := range histories {
+		commitComponent := weights.Commit * NormLog(float64(h.CommitCount), ctx.CommitCount)
+		churnComponent := weights.Churn * NormLog(float64(h.ChurnTotal), ctx.ChurnTotal)
+		bugfixComponent := weights.Bugfix * NormLog(float64(h.BugfixCount), ctx.BugfixCount)
+
+		daysSinceModified := until.Sub(h.LastModifiedAt).Hours() / 24
+		recencyComponent := weights.Recency * RecencyDecay(daysSinceModified, s.halfLifeDays)
+
+		totalScore := Clamp(commitComponent + churnComponent + bugfixComponent + recencyComponent)
+
+		var breakdown *RegionScoreBreakdown
+		if explain {
+			breakdown = &RegionScoreBreakdown{
+				CommitComponent:  commitComponent,
+				ChurnComponent:   churnComponent,
+				BugfixComponent:  bugfixComponent,
+				RecencyComponent: recencyComponent,
+			}
+		}
+
+		scores[i] = regionScore{
+			riskScore: totalScore,
+			riskLevel: s.thresholds.Classify(totalScore),
+			breakdown: breakdown,
+		}
+	}
+	return scores
+}
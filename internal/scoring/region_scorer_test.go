@@ -0,0 +1,23 @@
+package scoring
+
+import "testing"
+
+func TestRegionContextFromHistories(t *testing.T) {
+	histories := []RegionHistory{
+		{CommitCount: 2, ChurnTotal: 10, BugfixCount: 0},
+		{CommitCount: 9, ChurnTotal: 50, BugfixCount: 3},
+		{CommitCount: 1, ChurnTotal: 1, BugfixCount: 1},
+	}
+
+	ctx := RegionContextFromHistories(histories)
+
+	if ctx.CommitCount != (MinMax{Min: 1, Max: 9}) {
+		t.Errorf("CommitCount = %+v, expected {1, 9}", ctx.CommitCount)
+	}
+	if ctx.ChurnTotal != (MinMax{Min: 1, Max: 50}) {
+		t.Errorf("ChurnTotal = %+v, expected {1, 50}", ctx.ChurnTotal)
+	}
+	if ctx.BugfixCount != (MinMax{Min: 0, Max: 3}) {
+		t.Errorf("BugfixCount = %+v, expected {0, 3}", ctx.BugfixCount)
+	}
+}
@@ -0,0 +1,57 @@
+package scoring
+
+import (
+	"sort"
+
+	"github.com/masmgr/bugspots-go/config"
+)
+
+// ScopeGroup summarizes the scored commits of one Conventional Commits scope.
+type ScopeGroup struct {
+	Scope     string // Empty for Conventional Commits without a scope
+	Commits   int
+	HighRisk  int
+	Breaking  int
+	MaxScore  float64
+	MeanScore float64
+}
+
+// GroupByScope groups Conventional Commits by scope, ordered by mean risk score
+// (descending). Commits that are not Conventional Commits are left out.
+func GroupByScope(items []CommitRiskItem) []ScopeGroup {
+	byScope := make(map[string]*ScopeGroup)
+	for _, item := range items {
+		if item.Metrics.Type == "" {
+			continue
+		}
+		g, ok := byScope[item.Metrics.Scope]
+		if !ok {
+			g = &ScopeGroup{Scope: item.Metrics.Scope}
+			byScope[item.Metrics.Scope] = g
+		}
+		g.Commits++
+		if item.RiskLevel == config.RiskLevelHigh {
+			g.HighRisk++
+		}
+		if item.Metrics.Breaking {
+			g.Breaking++
+		}
+		if item.RiskScore > g.MaxScore {
+			g.MaxScore = item.RiskScore
+		}
+		g.MeanScore += item.RiskScore
+	}
+
+	groups := make([]ScopeGroup, 0, len(byScope))
+	for _, g := range byScope {
+		g.MeanScore /= float64(g.Commits)
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].MeanScore != groups[j].MeanScore {
+			return groups[i].MeanScore > groups[j].MeanScore
+		}
+		return groups[i].Scope < groups[j].Scope
+	})
+	return groups
+}
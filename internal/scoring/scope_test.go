@@ -0,0 +1,49 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/masmgr/bugspots-go/config"
+	"github.com/masmgr/bugspots-go/internal/aggregation"
+)
+
+func TestGroupByScope(t *testing.T) {
+	item := func(typ, scope string, breaking bool, score float64, level config.RiskLevel) CommitRiskItem {
+		return CommitRiskItem{
+			Metrics:   aggregation.CommitMetrics{Type: typ, Scope: scope, Breaking: breaking},
+			RiskScore: score,
+			RiskLevel: level,
+		}
+	}
+	items := []CommitRiskItem{
+		item("feat", "api", true, 0.9, config.RiskLevelHigh),
+		item("fix", "api", false, 0.5, config.RiskLevelMedium),
+		item("docs", "", false, 0.1, config.RiskLevelLow),
+		item("fix", "ui", false, 0.2, config.RiskLevelLow),
+		item("", "", false, 1.0, config.RiskLevelHigh), // not a Conventional Commit
+	}
+
+	groups := GroupByScope(items)
+	if len(groups) != 3 {
+		t.Fatalf("GroupByScope() returned %d groups, want 3: %+v", len(groups), groups)
+	}
+
+	api := groups[0]
+	if api.Scope != "api" || api.Commits != 2 || api.HighRisk != 1 || api.Breaking != 1 {
+		t.Errorf("groups[0] = %+v, want scope api with 2 commits, 1 high-risk, 1 breaking", api)
+	}
+	if api.MaxScore != 0.9 || math.Abs(api.MeanScore-0.7) > 1e-9 {
+		t.Errorf("api scores = max %f mean %f, want 0.9 and 0.7", api.MaxScore, api.MeanScore)
+	}
+	if groups[1].Scope != "ui" || groups[2].Scope != "" {
+		t.Errorf("scope order = %q, %q, want ui then the empty scope", groups[1].Scope, groups[2].Scope)
+	}
+}
+
+func TestGroupByScope_NoConventionalCommits(t *testing.T) {
+	groups := GroupByScope([]CommitRiskItem{{RiskScore: 0.5}})
+	if len(groups) != 0 {
+		t.Errorf("GroupByScope() = %+v, want no groups", groups)
+	}
+}